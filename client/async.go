@@ -0,0 +1,200 @@
+package client
+
+import (
+	gocontext "context"
+	"fmt"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"io"
+	"sync"
+)
+
+//DefaultInFlight is how many requests an asynchronous context keeps
+//in progress when no limit is given
+const DefaultInFlight = 16
+
+//future is resolved exactly once, by the operation or its cancellation
+type future struct {
+	once      sync.Once
+	done      chan struct{}
+	signature []byte
+	err       error
+}
+
+func newFuture() *future {
+	return &future{done: make(chan struct{})}
+}
+
+func (f *future) resolve(signature []byte, err error) {
+	f.once.Do(func() {
+		f.signature, f.err = signature, err
+		close(f.done)
+	})
+}
+
+func (f *future) Done() <-chan struct{} {
+	return f.done
+}
+
+func (f *future) Result() ([]byte, error) {
+	<-f.done
+	return f.signature, f.err
+}
+
+//asyncContext runs operations over a pool of contexts sharing
+//the same HandlerClient. A context is only used by one request
+//at a time, since its invoker is not safe for concurrent use.
+type asyncContext struct {
+	scheme   string
+	contexts chan *context
+	closers  map[*context]io.Closer
+
+	//lock orders the contexts returned to the pool with closing it,
+	//so no context is returned once closed is
+	lock   sync.Mutex
+	closed chan struct{}
+	close  sync.Once
+}
+
+var _ crypto.AsyncContextFactory = (*cryptoClient)(nil)
+
+func (c *cryptoClient) GetAsyncSignerVerifierAggregator(cryptoId string, inFlight int) (crypto.AsyncSignerVerifierAggregator, io.Closer) {
+	if inFlight <= 0 {
+		inFlight = DefaultInFlight
+	}
+
+	contexts := make([]*context, inFlight)
+	closers := make([]io.Closer, inFlight)
+	for i := range contexts {
+		invoker, closer := c.client.GetContext(cryptoId)
+		contexts[i] = &context{c, cryptoId, invoker}
+		closers[i] = closer
+	}
+
+	async := newAsyncContext(cryptoId, contexts, closers)
+	return async, async
+}
+
+func newAsyncContext(scheme string, contexts []*context, closers []io.Closer) *asyncContext {
+	async := &asyncContext{
+		scheme:   scheme,
+		contexts: make(chan *context, len(contexts)),
+		closers:  make(map[*context]io.Closer, len(contexts)),
+		closed:   make(chan struct{}),
+	}
+
+	for i, c := range contexts {
+		async.closers[c] = closers[i]
+		async.contexts <- c
+	}
+
+	return async
+}
+
+//GetAsyncSignerVerifierAggregator returns an asynchronous context for scheme
+//keeping up to inFlight requests in progress.
+//It fails with crypto.ErrUnsupported if factory has no asynchronous API.
+func GetAsyncSignerVerifierAggregator(factory crypto.ContextFactory, scheme string, inFlight int) (crypto.AsyncSignerVerifierAggregator, io.Closer, error) {
+	async, ok := factory.(crypto.AsyncContextFactory)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %T has no asynchronous API", crypto.ErrUnsupported, factory)
+	}
+
+	sva, closer := async.GetAsyncSignerVerifierAggregator(scheme, inFlight)
+	return sva, closer, nil
+}
+
+func (a *asyncContext) SignAsync(ctx gocontext.Context, digest []byte, key crypto.PrivateKey) crypto.Future {
+	return a.submit(ctx, func(c *context) ([]byte, error) {
+		return c.SignContext(ctx, digest, key)
+	})
+}
+
+func (a *asyncContext) VerifyAsync(ctx gocontext.Context, signature []byte, msg []byte, key crypto.PublicKey) crypto.Future {
+	return a.submit(ctx, func(c *context) ([]byte, error) {
+		return nil, c.VerifyContext(ctx, signature, msg, key)
+	})
+}
+
+func (a *asyncContext) AggregateAsync(ctx gocontext.Context, share [][]byte, digest []byte, key crypto.PublicKey, t, n int) crypto.Future {
+	return a.submit(ctx, func(c *context) ([]byte, error) {
+		return c.AggregateContext(ctx, share, digest, key, t, n)
+	})
+}
+
+//submit runs op on the first idle context.
+//The future fails as soon as ctx is done or the context is closed,
+//but the context is only released once its request completed.
+func (a *asyncContext) submit(ctx gocontext.Context, op func(c *context) ([]byte, error)) crypto.Future {
+	f := newFuture()
+
+	select {
+	case <-a.closed:
+		f.resolve(nil, fmt.Errorf("%w: %v", ErrClosed, a.scheme))
+		return f
+	default:
+	}
+
+	go func() {
+		var c *context
+		select {
+		case c = <-a.contexts:
+		case <-ctx.Done():
+			f.resolve(nil, ctx.Err())
+			return
+		case <-a.closed:
+			f.resolve(nil, fmt.Errorf("%w: %v", ErrClosed, a.scheme))
+			return
+		}
+
+		go func() {
+			signature, err := op(c)
+			a.release(c)
+			f.resolve(signature, err)
+		}()
+
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			f.resolve(nil, ctx.Err())
+		}
+	}()
+
+	return f
+}
+
+//release returns c to the pool, or closes it if the pool is closed
+func (a *asyncContext) release(c *context) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	select {
+	case <-a.closed:
+		a.closers[c].Close()
+	default:
+		a.contexts <- c
+	}
+}
+
+//Close fails the requests waiting for a context and releases the idle
+//contexts. It does not wait for the requests in progress: their contexts
+//are released as soon as they complete, which may be never for a request
+//without a deadline to a signer node that does not answer.
+func (a *asyncContext) Close() error {
+	a.close.Do(func() {
+		a.lock.Lock()
+		defer a.lock.Unlock()
+
+		close(a.closed)
+
+		for {
+			select {
+			case c := <-a.contexts:
+				a.closers[c].Close()
+			default:
+				return
+			}
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package client
+
+import (
+	gocontext "context"
+	"errors"
+	"github.com/golang/protobuf/proto"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+//echoInvoker signs by echoing the digest after a delay
+//and records how many invocations overlap
+type echoInvoker struct {
+	delay    time.Duration
+	release  chan struct{}
+	inFlight *int32
+	maxSeen  *int32
+}
+
+func (e *echoInvoker) Invoke(request []byte, msgType int32) ([]byte, int32, error) {
+	n := atomic.AddInt32(e.inFlight, 1)
+	defer atomic.AddInt32(e.inFlight, -1)
+	for {
+		max := atomic.LoadInt32(e.maxSeen)
+		if n <= max || atomic.CompareAndSwapInt32(e.maxSeen, max, n) {
+			break
+		}
+	}
+
+	if e.release != nil {
+		<-e.release
+	}
+	time.Sleep(e.delay)
+
+	req := pb.SignRequest{}
+	_ = proto.Unmarshal(request, &req)
+	b, _ := proto.Marshal(&pb.SignResponse{Status: pb.SignResponse_OK, Signature: req.Digest})
+	return b, int32(pb.Type_SIGN_RESPONSE), nil
+}
+
+type countingCloser struct {
+	closed *int32
+}
+
+func (c countingCloser) Close() error {
+	atomic.AddInt32(c.closed, 1)
+	return nil
+}
+
+func newTestAsyncContext(inFlight int, invoker *echoInvoker, closed *int32) *asyncContext {
+	contexts := make([]*context, inFlight)
+	closers := make([]io.Closer, inFlight)
+	for i := range contexts {
+		contexts[i] = &context{scheme: "Mock", context: invoker}
+		closers[i] = countingCloser{closed}
+	}
+	return newAsyncContext("Mock", contexts, closers)
+}
+
+func TestAsyncSignPipelinesRequests(test *testing.T) {
+	var inFlight, maxSeen, closed int32
+	async := newTestAsyncContext(4, &echoInvoker{delay: 5 * time.Millisecond, inFlight: &inFlight, maxSeen: &maxSeen}, &closed)
+
+	futures := make([]interface{ Result() ([]byte, error) }, 32)
+	for i := range futures {
+		futures[i] = async.SignAsync(gocontext.Background(), []byte{byte(i)}, key("priv"))
+	}
+
+	for i, f := range futures {
+		sig, err := f.Result()
+		require.Nil(test, err)
+		assert.Equal(test, []byte{byte(i)}, sig)
+	}
+
+	assert.True(test, maxSeen > 1, "requests were not pipelined")
+	assert.True(test, maxSeen <= 4, "more requests in flight than allowed")
+
+	require.Nil(test, async.Close())
+	require.Nil(test, async.Close())
+	assert.Equal(test, int32(4), closed)
+}
+
+func TestAsyncSignCancelled(test *testing.T) {
+	var inFlight, maxSeen, closed int32
+	release := make(chan struct{})
+	async := newTestAsyncContext(1, &echoInvoker{release: release, inFlight: &inFlight, maxSeen: &maxSeen}, &closed)
+
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	running := async.SignAsync(ctx, []byte("a"), key("priv"))
+	queued := async.SignAsync(ctx, []byte("b"), key("priv"))
+	cancel()
+
+	for _, f := range []interface{ Result() ([]byte, error) }{running, queued} {
+		sig, err := f.Result()
+		assert.Nil(test, sig)
+		assert.True(test, errors.Is(err, gocontext.Canceled))
+	}
+
+	//the context is reusable once the cancelled request completed
+	close(release)
+	sig, err := async.SignAsync(gocontext.Background(), []byte("c"), key("priv")).Result()
+	require.Nil(test, err)
+	assert.Equal(test, []byte("c"), sig)
+}
+
+func TestAsyncDeadline(test *testing.T) {
+	var inFlight, maxSeen, closed int32
+	async := newTestAsyncContext(1, &echoInvoker{delay: time.Second, inFlight: &inFlight, maxSeen: &maxSeen}, &closed)
+
+	ctx, cancel := gocontext.WithTimeout(gocontext.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	f := async.VerifyAsync(ctx, []byte("sig"), []byte("msg"), key("pub"))
+	select {
+	case <-f.Done():
+	case <-time.After(500 * time.Millisecond):
+		test.Fatal("deadline was not enforced")
+	}
+
+	_, err := f.Result()
+	assert.True(test, errors.Is(err, gocontext.DeadlineExceeded))
+}
+
+func TestAsyncClose(test *testing.T) {
+	var inFlight, maxSeen, closed int32
+	release := make(chan struct{})
+	async := newTestAsyncContext(1, &echoInvoker{release: release, inFlight: &inFlight, maxSeen: &maxSeen}, &closed)
+
+	running := async.SignAsync(gocontext.Background(), []byte("a"), key("priv"))
+	for atomic.LoadInt32(&inFlight) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	queued := async.SignAsync(gocontext.Background(), []byte("b"), key("priv"))
+
+	//closing does not wait for the request in progress
+	done := make(chan struct{})
+	go func() {
+		require.Nil(test, async.Close())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		test.Fatal("Close waited for the request in progress")
+	}
+
+	_, err := queued.Result()
+	assert.True(test, errors.Is(err, ErrClosed))
+
+	_, err = async.SignAsync(gocontext.Background(), []byte("c"), key("priv")).Result()
+	assert.True(test, errors.Is(err, ErrClosed))
+
+	//the context in use is released once its request completed
+	assert.Equal(test, int32(0), atomic.LoadInt32(&closed))
+	close(release)
+	sig, err := running.Result()
+	require.Nil(test, err)
+	assert.Equal(test, []byte("a"), sig)
+	assert.Equal(test, int32(1), atomic.LoadInt32(&closed))
+}
+
+func TestAsyncUnsupportedFactory(test *testing.T) {
+	_, _, err := GetAsyncSignerVerifierAggregator(nil, "Mock", 1)
+
+	assert.True(test, errors.Is(err, crypto.ErrUnsupported))
+}
+
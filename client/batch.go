@@ -0,0 +1,132 @@
+package client
+
+import (
+	"fmt"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+)
+
+var _ crypto.BatchSignerVerifierAggregator = (*context)(nil)
+
+func (c *context) SignBatch(items []crypto.SignItem) ([]crypto.SignResult, error) {
+	logger.Debugf("Sign batch of %v for %v", len(items), c.scheme)
+
+	req := pb.BatchSignRequest{Scheme: c.scheme, Items: make([]*pb.SignRequest, len(items))}
+	for i, item := range items {
+		itemReq, err := c.signRequest(item.Digest, item.Key)
+		if err != nil {
+			return nil, fmt.Errorf("item %v: %w", i, err)
+		}
+		req.Items[i] = itemReq
+	}
+
+	reply := pb.BatchSignResponse{}
+	err := c.invoke(&req, pb.Type_BATCH_SIGN_REQUEST, pb.Type_BATCH_SIGN_RESPONSE, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	switch reply.Status {
+	case pb.BatchSignResponse_OK:
+	case pb.BatchSignResponse_ERROR:
+		return nil, responseError(reply.ErrorCode, reply.ErrorMessage, pb.ErrorCode_INTERNAL)
+	default:
+		return nil, unknownStatusError(reply.Status)
+	}
+
+	if err := checkBatchLength(len(reply.Items), len(items)); err != nil {
+		return nil, err
+	}
+
+	results := make([]crypto.SignResult, len(items))
+	for i, item := range reply.Items {
+		results[i].Signature, results[i].Err = signResult(item)
+	}
+
+	return results, nil
+}
+
+func (c *context) VerifyBatch(items []crypto.VerifyItem) ([]error, error) {
+	logger.Debugf("Verify batch of %v for %v", len(items), c.scheme)
+
+	req := pb.BatchVerifyRequest{Scheme: c.scheme, Items: make([]*pb.VerifyRequest, len(items))}
+	for i, item := range items {
+		itemReq, err := c.verifyRequest(item.Signature, item.Msg, item.Key)
+		if err != nil {
+			return nil, fmt.Errorf("item %v: %w", i, err)
+		}
+		req.Items[i] = itemReq
+	}
+
+	reply := pb.BatchVerifyResponse{}
+	err := c.invoke(&req, pb.Type_BATCH_VERIFY_REQUEST, pb.Type_BATCH_VERIFY_RESPONSE, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	switch reply.Status {
+	case pb.BatchVerifyResponse_OK:
+	case pb.BatchVerifyResponse_ERROR:
+		return nil, responseError(reply.ErrorCode, reply.ErrorMessage, pb.ErrorCode_INTERNAL)
+	default:
+		return nil, unknownStatusError(reply.Status)
+	}
+
+	if err := checkBatchLength(len(reply.Items), len(items)); err != nil {
+		return nil, err
+	}
+
+	results := make([]error, len(items))
+	for i, item := range reply.Items {
+		results[i] = verifyResult(item)
+	}
+
+	return results, nil
+}
+
+func (c *context) AggregateBatch(items []crypto.AggregateItem) ([]crypto.SignResult, error) {
+	logger.Debugf("Aggregate batch of %v for %v", len(items), c.scheme)
+
+	req := pb.BatchAggregateRequest{Scheme: c.scheme, Items: make([]*pb.AggregateRequest, len(items))}
+	for i, item := range items {
+		itemReq, err := c.aggregateRequest(item.Shares, item.Digest, item.Key, item.T, item.N)
+		if err != nil {
+			return nil, fmt.Errorf("item %v: %w", i, err)
+		}
+		req.Items[i] = itemReq
+	}
+
+	reply := pb.BatchAggregateResponse{}
+	err := c.invoke(&req, pb.Type_BATCH_AGGREGATE_REQUEST, pb.Type_BATCH_AGGREGATE_RESPONSE, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	switch reply.Status {
+	case pb.BatchAggregateResponse_OK:
+	case pb.BatchAggregateResponse_ERROR:
+		return nil, responseError(reply.ErrorCode, reply.ErrorMessage, pb.ErrorCode_INTERNAL)
+	default:
+		return nil, unknownStatusError(reply.Status)
+	}
+
+	if err := checkBatchLength(len(reply.Items), len(items)); err != nil {
+		return nil, err
+	}
+
+	results := make([]crypto.SignResult, len(items))
+	for i, item := range reply.Items {
+		results[i].Signature, results[i].Err = aggregateResult(item)
+	}
+
+	return results, nil
+}
+
+//checkBatchLength checks a batch reply answered every item sent
+func checkBatchLength(replied, sent int) error {
+	if replied != sent {
+		return fmt.Errorf("%w: %v results for %v items", ErrResponseDecoding, replied, sent)
+	}
+
+	return nil
+}
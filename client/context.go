@@ -1,7 +1,9 @@
 package client
 
 import (
-	"errors"
+	gocontext "context"
+	"encoding"
+	"fmt"
 	"github.com/golang/protobuf/proto"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
@@ -15,6 +17,21 @@ type context struct {
 	context handlerClient.Invoker
 }
 
+var (
+	_ crypto.StoredKeyGenerator = (*context)(nil)
+	_ crypto.ShareExporter      = (*context)(nil)
+
+	_ crypto.ContextSigner            = (*context)(nil)
+	_ crypto.ContextVerifier          = (*context)(nil)
+	_ crypto.ContextAggregator        = (*context)(nil)
+	_ crypto.ContextKeyShareGenerator = (*context)(nil)
+
+	_ crypto.ContextStoredKeyGenerator = (*context)(nil)
+	_ crypto.ContextShareExporter      = (*context)(nil)
+
+	_ crypto.Fingerprinter = (*context)(nil)
+)
+
 type key []byte
 
 func (key key) MarshalBinary() (data []byte, err error) {
@@ -34,150 +51,379 @@ func (c *cryptoClient) GetKeyGenerator(cryptoId string) (crypto.KeyShareGenerato
 }
 
 func (c *context) Sign(digest []byte, key crypto.PrivateKey) (signature []byte, err error) {
-	logger.Debugf("Sign Key for %v", c.scheme)
+	return c.SignContext(gocontext.Background(), digest, key)
+}
 
-	d, _ := key.MarshalBinary()
+//SignContext signs as Sign, sending the deadline of ctx along
+//with the request so the signer node can give up on it
+func (c *context) SignContext(ctx gocontext.Context, digest []byte, key crypto.PrivateKey) (signature []byte, err error) {
+	logger.Debugf("Sign Key for %v", c.scheme)
 
-	req := pb.SignRequest{
-		Scheme:      c.scheme,
-		Digest:      digest,
-		PrivateKeys: d,
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	b,err := proto.Marshal(&req)
+	req, err := c.signRequest(digest, key)
 	if err != nil {
 		return nil, err
 	}
+	req.Deadline = crypto.RequestDeadline(ctx)
 
-	content,_,err := c.context.Invoke(b,int32(pb.Type_SIGN_REQUEST))
-
+	reply := pb.SignResponse{}
+	err = c.invoke(req, pb.Type_SIGN_REQUEST, pb.Type_SIGN_RESPONSE, &reply)
 	if err != nil {
 		return nil, err
 	}
 
-	replySign := pb.SignResponse{}
-	err = proto.Unmarshal(content, &replySign)
+	return signResult(&reply)
+}
 
-	if err != nil {
-		return nil, err
+func (c *context) signRequest(digest []byte, key crypto.PrivateKey) (*pb.SignRequest, error) {
+	req := pb.SignRequest{
+		Scheme: c.scheme,
+		Digest: digest,
+	}
+
+	if id, ok := key.(crypto.KeyID); ok {
+		if id == "" {
+			return nil, fmt.Errorf("%w: empty key id", ErrRequestEncoding)
+		}
+		req.KeyId = string(id)
+		return &req, nil
 	}
 
-	if replySign.Status != pb.SignResponse_OK {
-		return nil, errors.New("error signing")
+	var err error
+	req.PrivateKeys, err = marshalKey(key)
+	if err != nil {
+		return nil, err
 	}
 
-	return replySign.Signature, nil
+	return &req, nil
+}
 
+func signResult(reply *pb.SignResponse) ([]byte, error) {
+	switch reply.Status {
+	case pb.SignResponse_OK:
+		return reply.Signature, nil
+	case pb.SignResponse_ERROR:
+		return nil, responseError(reply.ErrorCode, reply.ErrorMessage, pb.ErrorCode_INTERNAL)
+	default:
+		return nil, unknownStatusError(reply.Status)
+	}
 }
 
 func (c *context) Verify(signature []byte, msg []byte, key crypto.PublicKey) error {
+	return c.VerifyContext(gocontext.Background(), signature, msg, key)
+}
+
+//VerifyContext verifies as Verify, sending the deadline of ctx along
+//with the request so the signer node can give up on it
+func (c *context) VerifyContext(ctx gocontext.Context, signature []byte, msg []byte, key crypto.PublicKey) error {
 	logger.Debugf("Verify Request for %v", c.scheme)
 
-	keyBytes, _ := key.MarshalBinary()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
-	req := pb.VerifyRequest{
-		Scheme:    c.scheme,
-		Signature: signature,
-		Msg:       msg,
-		PubKey:    keyBytes,
+	req, err := c.verifyRequest(signature, msg, key)
+	if err != nil {
+		return err
 	}
+	req.Deadline = crypto.RequestDeadline(ctx)
 
-	b,err := proto.Marshal(&req)
+	reply := pb.VerifyResponse{}
+	err = c.invoke(req, pb.Type_VERIFY_REQUEST, pb.Type_VERIFY_RESPONSE, &reply)
 	if err != nil {
-		return nil
+		return err
 	}
 
-	content,_,err := c.context.Invoke(b,int32(pb.Type_VERIFY_REQUEST))
+	return verifyResult(&reply)
+}
 
+func (c *context) verifyRequest(signature []byte, msg []byte, key crypto.PublicKey) (*pb.VerifyRequest, error) {
+	keyBytes, err := marshalKey(key)
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
-	replySign := pb.VerifyResponse{}
-	_ = proto.Unmarshal(content, &replySign)
+	return &pb.VerifyRequest{
+		Scheme:    c.scheme,
+		Signature: signature,
+		Msg:       msg,
+		PubKey:    keyBytes,
+	}, nil
+}
 
-	if replySign.Status == pb.VerifyResponse_ERROR {
-		return errors.New("invalid signature")
+func verifyResult(reply *pb.VerifyResponse) error {
+	switch reply.Status {
+	case pb.VerifyResponse_OK:
+		return nil
+	case pb.VerifyResponse_ERROR:
+		return responseError(reply.ErrorCode, reply.ErrorMessage, pb.ErrorCode_INVALID_SIGNATURE)
+	default:
+		return unknownStatusError(reply.Status)
 	}
-
-	return nil
 }
 
 func (c *context) Aggregate(share [][]byte, digest []byte, key crypto.PublicKey, t, n int) (signature []byte, err error) {
+	return c.AggregateContext(gocontext.Background(), share, digest, key, t, n)
+}
+
+//AggregateContext aggregates as Aggregate, sending the deadline of ctx
+//along with the request so the signer node can give up on it
+func (c *context) AggregateContext(ctx gocontext.Context, share [][]byte, digest []byte, key crypto.PublicKey, t, n int) (signature []byte, err error) {
 	logger.Debugf("Aggregating Request for %v", c.scheme)
 
-	keyBytes, _ := key.MarshalBinary()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
-	req := pb.AggregateRequest{
+	req, err := c.aggregateRequest(share, digest, key, t, n)
+	if err != nil {
+		return nil, err
+	}
+	req.Deadline = crypto.RequestDeadline(ctx)
+
+	reply := pb.AggregateResponse{}
+	err = c.invoke(req, pb.Type_AGGREGATE_REQUEST, pb.Type_AGGREGATE_RESPONSE, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	return aggregateResult(&reply)
+}
+
+func (c *context) aggregateRequest(share [][]byte, digest []byte, key crypto.PublicKey, t, n int) (*pb.AggregateRequest, error) {
+	keyBytes, err := marshalKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AggregateRequest{
 		Scheme: c.scheme,
 		Share:  share,
 		Digest: digest,
 		PubKey: keyBytes,
 		T:      int32(t),
 		N:      int32(n),
+	}, nil
+}
+
+func aggregateResult(reply *pb.AggregateResponse) ([]byte, error) {
+	switch reply.Status {
+	case pb.AggregateResponse_OK:
+		return reply.Signature, nil
+	case pb.AggregateResponse_ERROR:
+		return nil, responseError(reply.ErrorCode, reply.ErrorMessage, pb.ErrorCode_INTERNAL)
+	default:
+		return nil, unknownStatusError(reply.Status)
 	}
+}
 
-	b,err := proto.Marshal(&req)
-	if err != nil {
-		return nil, nil
+func (c *context) Gen(n int, t int) (crypto.PublicKey, crypto.PrivateKeyList, error) {
+	return c.GenContext(gocontext.Background(), n, t)
+}
+
+//GenContext generates keys as Gen, sending the deadline of ctx along
+//with the request so the signer node can give up on it
+func (c *context) GenContext(ctx gocontext.Context, n int, t int) (crypto.PublicKey, crypto.PrivateKeyList, error) {
+	logger.Debugf("Requesting Key Gen for %v", c.scheme)
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
 	}
 
-	content,_,err := c.context.Invoke(b,int32(pb.Type_AGGREGATE_REQUEST))
+	req := pb.GenerateTHSRequest{
+		Scheme:   c.scheme,
+		T:        uint32(t),
+		N:        uint32(n),
+		Deadline: crypto.RequestDeadline(ctx),
+	}
 
+	reply := pb.GenerateTHSResponse{}
+	err := c.invoke(&req, pb.Type_GENERATE_THS_REQUEST, pb.Type_GENERATE_THS_RESPONSE, &reply)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	switch reply.Status {
+	case pb.GenerateTHSResponse_OK:
+	case pb.GenerateTHSResponse_ERROR:
+		return nil, nil, responseError(reply.ErrorCode, reply.ErrorMessage, pb.ErrorCode_INTERNAL)
+	default:
+		return nil, nil, unknownStatusError(reply.Status)
 	}
 
-	replySign := pb.AggregateResponse{}
-	err = proto.Unmarshal(content, &replySign)
+	pubKey := key(reply.PublicKey)
+	privKeySlice := make([]crypto.PrivateKey, len(reply.PrivateKeys))
 
-	if replySign.Status == pb.AggregateResponse_ERROR {
-		return nil, errors.New("error aggregating")
+	for i, v := range reply.PrivateKeys {
+		privKeySlice[i] = key(v)
 	}
 
-	return replySign.Signature, nil
+	return &pubKey, privKeySlice, nil
 }
 
-func (c *context) Gen(n int, t int) (crypto.PublicKey, crypto.PrivateKeyList) {
-	logger.Debugf("Requesting Key Gen for %v", c.scheme)
+//GenStored asks the signer node to generate keys and keep the shares
+//in its keychain under id, returning only the public key and the id
+func (c *context) GenStored(n int, t int, id crypto.KeyID) (crypto.PublicKey, crypto.KeyID, error) {
+	return c.GenStoredContext(gocontext.Background(), n, t, id)
+}
+
+//GenStoredContext generates keys as GenStored, sending the deadline of ctx
+//along with the request so the signer node can give up on it
+func (c *context) GenStoredContext(ctx gocontext.Context, n int, t int, id crypto.KeyID) (crypto.PublicKey, crypto.KeyID, error) {
+	logger.Debugf("Requesting Stored Key Gen for %v", c.scheme)
+
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
 
 	req := pb.GenerateTHSRequest{
-		Scheme: c.scheme,
-		T:      uint32(t),
-		N:      uint32(n),
+		Scheme:   c.scheme,
+		T:        uint32(t),
+		N:        uint32(n),
+		Store:    true,
+		KeyId:    string(id),
+		Deadline: crypto.RequestDeadline(ctx),
+	}
+
+	reply := pb.GenerateTHSResponse{}
+	err := c.invoke(&req, pb.Type_GENERATE_THS_REQUEST, pb.Type_GENERATE_THS_RESPONSE, &reply)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch reply.Status {
+	case pb.GenerateTHSResponse_OK:
+	case pb.GenerateTHSResponse_ERROR:
+		return nil, "", responseError(reply.ErrorCode, reply.ErrorMessage, pb.ErrorCode_INTERNAL)
+	default:
+		return nil, "", unknownStatusError(reply.Status)
+	}
+
+	if reply.KeyId == "" {
+		return nil, "", fmt.Errorf("%w: missing key id", ErrResponseDecoding)
+	}
+
+	pubKey := key(reply.PublicKey)
+
+	if fingerprint, err := c.Fingerprint(pubKey); err == nil {
+		logger.Debugf("Signer node stored %v as %v", fingerprint, reply.KeyId)
+	}
+
+	return &pubKey, crypto.KeyID(reply.KeyId), nil
+}
+
+//Fingerprint returns the fingerprint of pub, a public key of the
+//scheme of the context, so it can be told apart from other keys
+func (c *context) Fingerprint(pub crypto.PublicKey) (string, error) {
+	return crypto.Fingerprint(c.scheme, pub)
+}
+
+//ExportShare retrieves share index of the keys stored under id
+func (c *context) ExportShare(id crypto.KeyID, index int, token []byte) (crypto.PrivateKey, error) {
+	return c.ExportShareContext(gocontext.Background(), id, index, token)
+}
+
+//ExportShareContext exports as ExportShare, sending the deadline of ctx
+//along with the request so the signer node can give up on it
+func (c *context) ExportShareContext(ctx gocontext.Context, id crypto.KeyID, index int, token []byte) (crypto.PrivateKey, error) {
+	logger.Debugf("Requesting export of share %v of %v", index, id)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	req := pb.ExportShareRequest{
+		Scheme:   c.scheme,
+		KeyId:    string(id),
+		Index:    uint32(index),
+		Token:    token,
+		Deadline: crypto.RequestDeadline(ctx),
 	}
 
-	b,err := proto.Marshal(&req)
+	reply := pb.ExportShareResponse{}
+	err := c.invoke(&req, pb.Type_EXPORT_SHARE_REQUEST, pb.Type_EXPORT_SHARE_RESPONSE, &reply)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 
-	content,respType,err := c.context.Invoke(b,int32(pb.Type_GENERATE_THS_REQUEST))
+	switch reply.Status {
+	case pb.ExportShareResponse_OK:
+		return key(reply.PrivateKey), nil
+	case pb.ExportShareResponse_ERROR:
+		return nil, responseError(reply.ErrorCode, reply.ErrorMessage, pb.ErrorCode_INTERNAL)
+	default:
+		return nil, unknownStatusError(reply.Status)
+	}
+}
 
+//invoke sends req to the signer node and decodes the reply into resp.
+//It only returns nil if a reply of type respType was received and decoded,
+//so callers fail closed on every encoding or transport problem.
+func (c *context) invoke(req proto.Message, reqType pb.Type, respType pb.Type, resp proto.Message) error {
+	b, err := proto.Marshal(req)
 	if err != nil {
-		return nil, nil
+		return fmt.Errorf("%w: %v", ErrRequestEncoding, err)
 	}
 
-	if respType != int32(pb.Type_GENERATE_THS_RESPONSE) {
-		panic("Wrong message received")
+	content, replyType, err := c.context.Invoke(b, int32(reqType))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrTransport, err)
 	}
 
-	replyTHS := pb.GenerateTHSResponse{}
+	if replyType == int32(pb.Type_UNSUPPORTED_RESPONSE) {
+		return unsupportedError(content)
+	}
 
-	err = proto.Unmarshal(content, &replyTHS)
+	if replyType != int32(respType) {
+		return fmt.Errorf("%w: expected %v but received %v", ErrResponseDecoding, respType, pb.Type(replyType))
+	}
 
+	err = proto.Unmarshal(content, resp)
 	if err != nil {
-		panic("error unmarshalling msg")
+		return fmt.Errorf("%w: %v", ErrResponseDecoding, err)
 	}
 
-	pubKey := key(replyTHS.PublicKey)
-	privKeySlice := make([]crypto.PrivateKey, len(replyTHS.PrivateKeys))
+	return nil
+}
 
-	for i, v := range replyTHS.PrivateKeys {
-		privKeySlice[i] = key(v)
+func marshalKey(key encoding.BinaryMarshaler) ([]byte, error) {
+	if key == nil {
+		return nil, fmt.Errorf("%w: missing key", ErrRequestEncoding)
+	}
+
+	b, err := key.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRequestEncoding, err)
+	}
+
+	return b, nil
+}
+
+//unsupportedError decodes an UnsupportedResponse into a *crypto.Error
+func unsupportedError(content []byte) error {
+	reply := pb.UnsupportedResponse{}
+	if err := proto.Unmarshal(content, &reply); err != nil {
+		return fmt.Errorf("%w: %v", ErrResponseDecoding, err)
+	}
+
+	return responseError(reply.ErrorCode, reply.ErrorMessage, pb.ErrorCode_UNSUPPORTED)
+}
+
+func unknownStatusError(status fmt.Stringer) error {
+	return fmt.Errorf("%w: unexpected status %v", ErrResponseDecoding, status)
+}
+
+//responseError converts the error code carried by a response
+//into a *crypto.Error, using fallback for servers that do not set one.
+func responseError(code pb.ErrorCode, message string, fallback pb.ErrorCode) error {
+	if code == pb.ErrorCode_NO_ERROR {
+		code = fallback
 	}
 
-	return &pubKey, privKeySlice
+	return crypto.NewError(code, message)
 }
 
 func (c *context) Close() error {
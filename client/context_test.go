@@ -0,0 +1,538 @@
+package client
+
+import (
+	gocontext "context"
+	"errors"
+	"github.com/golang/protobuf/proto"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+//fakeInvoker answers every request with the same canned reply
+type fakeInvoker struct {
+	content  []byte
+	respType pb.Type
+	err      error
+	request  []byte
+}
+
+func (f *fakeInvoker) Invoke(request []byte, msgType int32) ([]byte, int32, error) {
+	f.request = request
+	return f.content, int32(f.respType), f.err
+}
+
+func replyWith(msg proto.Message, respType pb.Type) *fakeInvoker {
+	b, _ := proto.Marshal(msg)
+	return &fakeInvoker{content: b, respType: respType}
+}
+
+type badKey struct{}
+
+func (badKey) MarshalBinary() ([]byte, error) {
+	return nil, errors.New("cannot marshal")
+}
+
+func newTestContext(invoker *fakeInvoker) *context {
+	return &context{scheme: "Mock", context: invoker}
+}
+
+var transportError = &fakeInvoker{err: errors.New("connection dropped")}
+
+func TestVerifyFailsOnTransportError(test *testing.T) {
+	err := newTestContext(transportError).Verify([]byte("sig"), []byte("msg"), key("pub"))
+
+	require.NotNil(test, err)
+	assert.True(test, errors.Is(err, ErrTransport))
+}
+
+func TestVerifyFailsOnWrongResponseType(test *testing.T) {
+	invoker := replyWith(&pb.SignResponse{Status: pb.SignResponse_OK}, pb.Type_SIGN_RESPONSE)
+	err := newTestContext(invoker).Verify([]byte("sig"), []byte("msg"), key("pub"))
+
+	require.NotNil(test, err)
+	assert.True(test, errors.Is(err, ErrResponseDecoding))
+}
+
+func TestVerifyFailsOnUndecodableResponse(test *testing.T) {
+	invoker := &fakeInvoker{content: []byte{0xff, 0xff}, respType: pb.Type_VERIFY_RESPONSE}
+	err := newTestContext(invoker).Verify([]byte("sig"), []byte("msg"), key("pub"))
+
+	require.NotNil(test, err)
+	assert.True(test, errors.Is(err, ErrResponseDecoding))
+}
+
+func TestVerifyFailsOnEmptyResponse(test *testing.T) {
+	invoker := &fakeInvoker{respType: pb.Type_VERIFY_RESPONSE}
+	err := newTestContext(invoker).Verify([]byte("sig"), []byte("msg"), key("pub"))
+
+	require.NotNil(test, err)
+	assert.True(test, errors.Is(err, ErrResponseDecoding))
+}
+
+func TestVerifyFailsOnKeyEncodingError(test *testing.T) {
+	invoker := replyWith(&pb.VerifyResponse{Status: pb.VerifyResponse_OK}, pb.Type_VERIFY_RESPONSE)
+
+	err := newTestContext(invoker).Verify([]byte("sig"), []byte("msg"), badKey{})
+	assert.True(test, errors.Is(err, ErrRequestEncoding))
+
+	err = newTestContext(invoker).Verify([]byte("sig"), []byte("msg"), nil)
+	assert.True(test, errors.Is(err, ErrRequestEncoding))
+}
+
+func TestVerifyInvalidSignature(test *testing.T) {
+	invoker := replyWith(&pb.VerifyResponse{
+		Status:       pb.VerifyResponse_ERROR,
+		ErrorCode:    pb.ErrorCode_INVALID_SIGNATURE,
+		ErrorMessage: "bls: invalid signature",
+	}, pb.Type_VERIFY_RESPONSE)
+	err := newTestContext(invoker).Verify([]byte("sig"), []byte("msg"), key("pub"))
+
+	require.NotNil(test, err)
+	assert.True(test, errors.Is(err, crypto.ErrInvalidSignature))
+	assert.False(test, errors.Is(err, ErrTransport))
+}
+
+func TestVerifyValidSignature(test *testing.T) {
+	invoker := replyWith(&pb.VerifyResponse{Status: pb.VerifyResponse_OK}, pb.Type_VERIFY_RESPONSE)
+	err := newTestContext(invoker).Verify([]byte("sig"), []byte("msg"), key("pub"))
+
+	assert.Nil(test, err)
+}
+
+func TestSignFailsOnTransportError(test *testing.T) {
+	sig, err := newTestContext(transportError).Sign([]byte("msg"), key("priv"))
+
+	assert.Nil(test, sig)
+	assert.True(test, errors.Is(err, ErrTransport))
+}
+
+func TestSignFailsOnEmptyResponse(test *testing.T) {
+	invoker := &fakeInvoker{respType: pb.Type_SIGN_RESPONSE}
+	sig, err := newTestContext(invoker).Sign([]byte("msg"), key("priv"))
+
+	assert.Nil(test, sig)
+	assert.True(test, errors.Is(err, ErrResponseDecoding))
+}
+
+func TestSignReturnsSignature(test *testing.T) {
+	invoker := replyWith(&pb.SignResponse{Status: pb.SignResponse_OK, Signature: []byte("sig")}, pb.Type_SIGN_RESPONSE)
+	sig, err := newTestContext(invoker).Sign([]byte("msg"), key("priv"))
+
+	assert.Nil(test, err)
+	assert.Equal(test, []byte("sig"), sig)
+}
+
+func TestAggregateFailsOnKeyEncodingError(test *testing.T) {
+	invoker := replyWith(&pb.AggregateResponse{Status: pb.AggregateResponse_OK}, pb.Type_AGGREGATE_RESPONSE)
+	sig, err := newTestContext(invoker).Aggregate(nil, []byte("msg"), badKey{}, 3, 5)
+
+	assert.Nil(test, sig)
+	assert.True(test, errors.Is(err, ErrRequestEncoding))
+}
+
+func TestAggregateFailsOnTransportError(test *testing.T) {
+	sig, err := newTestContext(transportError).Aggregate(nil, []byte("msg"), key("pub"), 3, 5)
+
+	assert.Nil(test, sig)
+	assert.True(test, errors.Is(err, ErrTransport))
+}
+
+func TestAggregateNotEnoughShares(test *testing.T) {
+	invoker := replyWith(&pb.AggregateResponse{
+		Status:    pb.AggregateResponse_ERROR,
+		ErrorCode: pb.ErrorCode_NOT_ENOUGH_SHARES,
+	}, pb.Type_AGGREGATE_RESPONSE)
+	sig, err := newTestContext(invoker).Aggregate(nil, []byte("msg"), key("pub"), 3, 5)
+
+	assert.Nil(test, sig)
+	assert.True(test, errors.Is(err, crypto.ErrNotEnoughShares))
+}
+
+func TestGenFailsOnTransportError(test *testing.T) {
+	pub, priv, err := newTestContext(transportError).Gen(5, 3)
+
+	assert.Nil(test, pub)
+	assert.Nil(test, priv)
+	assert.True(test, errors.Is(err, ErrTransport))
+}
+
+func TestGenFailsOnWrongResponseType(test *testing.T) {
+	invoker := replyWith(&pb.SignResponse{Status: pb.SignResponse_OK}, pb.Type_SIGN_RESPONSE)
+	_, _, err := newTestContext(invoker).Gen(5, 3)
+
+	assert.True(test, errors.Is(err, ErrResponseDecoding))
+}
+
+func TestAggregateUnsupportedResponse(test *testing.T) {
+	invoker := replyWith(&pb.UnsupportedResponse{
+		Scheme:       "Mock",
+		RequestType:  pb.Type_AGGREGATE_REQUEST,
+		ErrorCode:    pb.ErrorCode_UNSUPPORTED,
+		ErrorMessage: "Mock does not support aggregate",
+	}, pb.Type_UNSUPPORTED_RESPONSE)
+	sig, err := newTestContext(invoker).Aggregate(nil, []byte("msg"), key("pub"), 3, 5)
+
+	assert.Nil(test, sig)
+	assert.True(test, errors.Is(err, crypto.ErrUnsupported))
+	assert.False(test, errors.Is(err, ErrResponseDecoding))
+}
+
+func TestListSchemes(test *testing.T) {
+	invoker := replyWith(&pb.ListSchemesResponse{
+		Status: pb.ListSchemesResponse_OK,
+		Schemes: []*pb.SchemeInfo{{
+			Name:        "TBLS256Optimistic",
+			Operations:  []pb.Operation{pb.Operation_SIGN, pb.Operation_AGGREGATE},
+			KeySize:     256,
+			Threshold:   true,
+			Aggregation: pb.AggregationStrategy_OPTIMISTIC,
+		}},
+	}, pb.Type_LIST_SCHEMES_RESPONSE)
+	schemes, err := newTestContext(invoker).listSchemes()
+
+	require.Nil(test, err)
+	require.Len(test, schemes, 1)
+	assert.Equal(test, crypto.SchemeInfo{
+		Name:        "TBLS256Optimistic",
+		Operations:  []crypto.Operation{crypto.SignOperation, crypto.AggregateOperation},
+		KeySize:     256,
+		Threshold:   true,
+		Aggregation: crypto.OptimisticAggregation,
+	}, schemes[0])
+}
+
+func TestListSchemesFailsOnTransportError(test *testing.T) {
+	schemes, err := newTestContext(transportError).listSchemes()
+
+	assert.Nil(test, schemes)
+	assert.True(test, errors.Is(err, ErrTransport))
+}
+
+//staticLister is a factory of a node serving a fixed set of schemes
+type staticLister struct {
+	crypto.ContextFactory
+	schemes []crypto.SchemeInfo
+}
+
+func (l staticLister) ListSchemes() ([]crypto.SchemeInfo, error) {
+	return l.schemes, nil
+}
+
+func TestFindScheme(test *testing.T) {
+	factory := staticLister{schemes: []crypto.SchemeInfo{
+		{Name: "TBLS256", Family: "TBLS", KeySize: 256, Aggregation: crypto.NormalAggregation},
+		{Name: "TRSA1024Optimistic", KeySize: 1024, Aggregation: crypto.OptimisticAggregation},
+	}}
+
+	info, err := FindScheme(factory, crypto.Descriptor{Family: "TBLS", KeySize: 256, Aggregation: crypto.NormalAggregation})
+	require.Nil(test, err)
+	assert.Equal(test, "TBLS256", info.Name)
+
+	info, err = FindScheme(factory, crypto.Descriptor{Family: "TRSA", KeySize: 1024, Aggregation: crypto.OptimisticAggregation})
+	require.Nil(test, err)
+	assert.Equal(test, "TRSA1024Optimistic", info.Name)
+
+	_, err = FindScheme(factory, crypto.Descriptor{Family: "TBLS", KeySize: 256, Aggregation: crypto.PessimisticAggregation})
+	assert.True(test, errors.Is(err, crypto.ErrUnsupported))
+}
+
+func TestListSchemesUnsupportedFactory(test *testing.T) {
+	_, err := ListSchemes(nil)
+
+	assert.True(test, errors.Is(err, crypto.ErrUnsupported))
+}
+
+func TestSignByKeyID(test *testing.T) {
+	invoker := replyWith(&pb.SignResponse{Status: pb.SignResponse_OK, Signature: []byte("sig")}, pb.Type_SIGN_RESPONSE)
+	sig, err := newTestContext(invoker).Sign([]byte("msg"), crypto.KeyID("TBLS256_5_3"))
+
+	require.Nil(test, err)
+	assert.Equal(test, []byte("sig"), sig)
+
+	req := pb.SignRequest{}
+	require.Nil(test, proto.Unmarshal(invoker.request, &req))
+	assert.Equal(test, "TBLS256_5_3", req.KeyId)
+	assert.Nil(test, req.PrivateKeys)
+}
+
+func TestSignByEmptyKeyID(test *testing.T) {
+	invoker := replyWith(&pb.SignResponse{Status: pb.SignResponse_OK}, pb.Type_SIGN_RESPONSE)
+	_, err := newTestContext(invoker).Sign([]byte("msg"), crypto.KeyID(""))
+
+	assert.True(test, errors.Is(err, ErrRequestEncoding))
+}
+
+func TestSignContextSendsDeadline(test *testing.T) {
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := gocontext.WithDeadline(gocontext.Background(), deadline)
+	defer cancel()
+
+	invoker := replyWith(&pb.SignResponse{Status: pb.SignResponse_OK, Signature: []byte("sig")}, pb.Type_SIGN_RESPONSE)
+	_, err := newTestContext(invoker).SignContext(ctx, []byte("msg"), key("priv"))
+	require.Nil(test, err)
+
+	req := pb.SignRequest{}
+	require.Nil(test, proto.Unmarshal(invoker.request, &req))
+	assert.Equal(test, deadline.UnixNano(), req.Deadline)
+}
+
+func TestAggregateContextCancelled(test *testing.T) {
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	cancel()
+
+	invoker := replyWith(&pb.AggregateResponse{Status: pb.AggregateResponse_OK}, pb.Type_AGGREGATE_RESPONSE)
+	_, err := newTestContext(invoker).AggregateContext(ctx, [][]byte{[]byte("share")}, []byte("msg"), key("pub"), 1, 1)
+
+	assert.Equal(test, gocontext.Canceled, err)
+	assert.Nil(test, invoker.request)
+}
+
+func TestSignDeadlineExceeded(test *testing.T) {
+	invoker := replyWith(&pb.SignResponse{
+		Status:       pb.SignResponse_ERROR,
+		ErrorCode:    pb.ErrorCode_DEADLINE_EXCEEDED,
+		ErrorMessage: "TBLS256 gave up on SIGN_REQUEST",
+	}, pb.Type_SIGN_RESPONSE)
+	_, err := newTestContext(invoker).Sign([]byte("msg"), key("priv"))
+
+	assert.True(test, errors.Is(err, gocontext.DeadlineExceeded))
+	assert.True(test, errors.Is(err, crypto.ErrDeadlineExceeded))
+}
+
+func TestGenStored(test *testing.T) {
+	invoker := replyWith(&pb.GenerateTHSResponse{
+		Status:    pb.GenerateTHSResponse_OK,
+		PublicKey: []byte("pub"),
+		KeyId:     "TBLS256_5_3",
+	}, pb.Type_GENERATE_THS_RESPONSE)
+	pub, id, err := newTestContext(invoker).GenStored(5, 3, "TBLS256_5_3")
+
+	require.Nil(test, err)
+	assert.Equal(test, crypto.KeyID("TBLS256_5_3"), id)
+	pubBytes, _ := pub.MarshalBinary()
+	assert.Equal(test, []byte("pub"), pubBytes)
+
+	req := pb.GenerateTHSRequest{}
+	require.Nil(test, proto.Unmarshal(invoker.request, &req))
+	assert.True(test, req.Store)
+	assert.Equal(test, "TBLS256_5_3", req.KeyId)
+}
+
+func TestFingerprint(test *testing.T) {
+	pubEnv, err := crypto.NewKeyEnvelope("Mock", 1, 1, 0, "Mock_1_1", key("pub"))
+	require.Nil(test, err)
+	envBytes, _ := pubEnv.MarshalBinary()
+
+	fp, err := newTestContext(nil).Fingerprint(key(envBytes))
+	require.Nil(test, err)
+	assert.Equal(test, pubEnv.Fingerprint, fp)
+
+	fp, err = newTestContext(nil).Fingerprint(key("pub"))
+	require.Nil(test, err)
+	assert.Equal(test, pubEnv.Fingerprint, fp)
+
+	//keys generated by a sibling scheme the client has no handler for
+	sibling, err := crypto.NewKeyEnvelope("Mock256Optimistic", 1, 1, 0, "Mock256_1_1", key("pub"))
+	require.Nil(test, err)
+	siblingBytes, _ := sibling.MarshalBinary()
+
+	ctx := newTestContext(nil)
+	ctx.scheme = "Mock256Pessimistic"
+	fp, err = ctx.Fingerprint(key(siblingBytes))
+	require.Nil(test, err)
+	assert.Equal(test, sibling.Fingerprint, fp)
+
+	fp, err = ctx.Fingerprint(key("pub"))
+	require.Nil(test, err)
+	assert.Equal(test, sibling.Fingerprint, fp)
+}
+
+func TestGenStoredFailsWithoutKeyID(test *testing.T) {
+	invoker := replyWith(&pb.GenerateTHSResponse{Status: pb.GenerateTHSResponse_OK}, pb.Type_GENERATE_THS_RESPONSE)
+	_, _, err := newTestContext(invoker).GenStored(5, 3, "")
+
+	assert.True(test, errors.Is(err, ErrResponseDecoding))
+}
+
+func TestExportSharePermissionDenied(test *testing.T) {
+	invoker := replyWith(&pb.ExportShareResponse{
+		Status:    pb.ExportShareResponse_ERROR,
+		ErrorCode: pb.ErrorCode_PERMISSION_DENIED,
+	}, pb.Type_EXPORT_SHARE_RESPONSE)
+	priv, err := newTestContext(invoker).ExportShare("TBLS256_5_3", 1, []byte("guess"))
+
+	assert.Nil(test, priv)
+	assert.True(test, errors.Is(err, crypto.ErrPermissionDenied))
+}
+
+func TestGenStoredContextSendsDeadline(test *testing.T) {
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := gocontext.WithDeadline(gocontext.Background(), deadline)
+	defer cancel()
+
+	invoker := replyWith(&pb.GenerateTHSResponse{
+		Status:    pb.GenerateTHSResponse_OK,
+		PublicKey: []byte("pub"),
+		KeyId:     "TBLS256_5_3",
+	}, pb.Type_GENERATE_THS_RESPONSE)
+	_, _, err := newTestContext(invoker).GenStoredContext(ctx, 5, 3, "TBLS256_5_3")
+	require.Nil(test, err)
+
+	req := pb.GenerateTHSRequest{}
+	require.Nil(test, proto.Unmarshal(invoker.request, &req))
+	assert.Equal(test, deadline.UnixNano(), req.Deadline)
+}
+
+func TestExportShareContext(test *testing.T) {
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := gocontext.WithDeadline(gocontext.Background(), deadline)
+	defer cancel()
+
+	invoker := replyWith(&pb.ExportShareResponse{
+		Status:     pb.ExportShareResponse_OK,
+		PrivateKey: []byte("share"),
+	}, pb.Type_EXPORT_SHARE_RESPONSE)
+	_, err := newTestContext(invoker).ExportShareContext(ctx, "TBLS256_5_3", 1, []byte("token"))
+	require.Nil(test, err)
+
+	req := pb.ExportShareRequest{}
+	require.Nil(test, proto.Unmarshal(invoker.request, &req))
+	assert.Equal(test, deadline.UnixNano(), req.Deadline)
+
+	//cancelled exports are not sent
+	invoker.request = nil
+	cancel()
+	_, err = newTestContext(invoker).ExportShareContext(ctx, "TBLS256_5_3", 1, []byte("token"))
+	assert.Equal(test, gocontext.Canceled, err)
+	assert.Nil(test, invoker.request)
+}
+
+func TestSignBatch(test *testing.T) {
+	invoker := replyWith(&pb.BatchSignResponse{
+		Status: pb.BatchSignResponse_OK,
+		Items: []*pb.SignResponse{
+			{Status: pb.SignResponse_OK, Signature: []byte("sig")},
+			{Status: pb.SignResponse_ERROR, ErrorCode: pb.ErrorCode_KEY_NOT_FOUND},
+		},
+	}, pb.Type_BATCH_SIGN_RESPONSE)
+	results, err := newTestContext(invoker).SignBatch([]crypto.SignItem{
+		{Digest: []byte("a"), Key: key("priv")},
+		{Digest: []byte("b"), Key: crypto.KeyID("TBLS256_5_3")},
+	})
+
+	require.Nil(test, err)
+	require.Len(test, results, 2)
+	assert.Equal(test, []byte("sig"), results[0].Signature)
+	assert.Nil(test, results[0].Err)
+	assert.True(test, errors.Is(results[1].Err, crypto.ErrKeyNotFound))
+
+	req := pb.BatchSignRequest{}
+	require.Nil(test, proto.Unmarshal(invoker.request, &req))
+	require.Len(test, req.Items, 2)
+	assert.Equal(test, "TBLS256_5_3", req.Items[1].KeyId)
+}
+
+func TestVerifyBatchFailsOnMissingResults(test *testing.T) {
+	invoker := replyWith(&pb.BatchVerifyResponse{
+		Status: pb.BatchVerifyResponse_OK,
+		Items:  []*pb.VerifyResponse{{Status: pb.VerifyResponse_OK}},
+	}, pb.Type_BATCH_VERIFY_RESPONSE)
+	results, err := newTestContext(invoker).VerifyBatch([]crypto.VerifyItem{
+		{Signature: []byte("a"), Msg: []byte("a"), Key: key("pub")},
+		{Signature: []byte("b"), Msg: []byte("b"), Key: key("pub")},
+	})
+
+	assert.Nil(test, results)
+	assert.True(test, errors.Is(err, ErrResponseDecoding))
+}
+
+func TestAggregateBatchFailsOnKeyEncodingError(test *testing.T) {
+	invoker := replyWith(&pb.BatchAggregateResponse{Status: pb.BatchAggregateResponse_OK}, pb.Type_BATCH_AGGREGATE_RESPONSE)
+	_, err := newTestContext(invoker).AggregateBatch([]crypto.AggregateItem{{Key: badKey{}}})
+
+	assert.True(test, errors.Is(err, ErrRequestEncoding))
+}
+
+func TestAggregateMulti(test *testing.T) {
+	invoker := replyWith(&pb.AggregateResponse{Status: pb.AggregateResponse_OK, Signature: []byte("sig")}, pb.Type_AGGREGATE_RESPONSE)
+	pubs := []crypto.PublicKey{key("pub1"), key("pub2")}
+
+	sig, err := newTestContext(invoker).AggregateMulti([][]byte{[]byte("s1"), []byte("s2")}, []byte("msg"), pubs)
+	require.Nil(test, err)
+	assert.Equal(test, []byte("sig"), sig)
+
+	req := pb.AggregateRequest{}
+	require.Nil(test, proto.Unmarshal(invoker.request, &req))
+	assert.Equal(test, [][]byte{[]byte("pub1"), []byte("pub2")}, req.PubKeys)
+	assert.Equal(test, []byte("msg"), req.Digest)
+	assert.Nil(test, req.PubKey)
+	assert.Nil(test, req.Msgs)
+	assert.Nil(test, req.Proofs)
+
+	//the proofs of possession are sent along with the keys
+	pubs[0] = crypto.ProvenKey{PublicKey: pubs[0], Proof: []byte("proof1")}
+	_, err = newTestContext(invoker).AggregateDistinct([][]byte{[]byte("s1"), []byte("s2")}, [][]byte{[]byte("m1"), []byte("m2")}, pubs)
+	require.Nil(test, err)
+
+	req = pb.AggregateRequest{}
+	require.Nil(test, proto.Unmarshal(invoker.request, &req))
+	assert.Equal(test, [][]byte{[]byte("m1"), []byte("m2")}, req.Msgs)
+	assert.Equal(test, [][]byte{[]byte("pub1"), []byte("pub2")}, req.PubKeys)
+	assert.Equal(test, [][]byte{[]byte("proof1"), {}}, req.Proofs)
+	assert.Nil(test, req.Digest)
+}
+
+func TestVerifyMulti(test *testing.T) {
+	invoker := replyWith(&pb.VerifyResponse{Status: pb.VerifyResponse_OK}, pb.Type_VERIFY_RESPONSE)
+	pubs := []crypto.PublicKey{key("pub1"), key("pub2")}
+
+	require.Nil(test, newTestContext(invoker).VerifyDistinct([]byte("sig"), [][]byte{[]byte("m1"), []byte("m2")}, pubs))
+
+	req := pb.VerifyRequest{}
+	require.Nil(test, proto.Unmarshal(invoker.request, &req))
+	assert.Equal(test, [][]byte{[]byte("pub1"), []byte("pub2")}, req.PubKeys)
+	assert.Equal(test, [][]byte{[]byte("m1"), []byte("m2")}, req.Msgs)
+
+	//malformed requests are not sent
+	invoker.request = nil
+	err := newTestContext(invoker).VerifyDistinct([]byte("sig"), [][]byte{[]byte("m1")}, pubs)
+	assert.True(test, errors.Is(err, crypto.ErrMalformedRequest))
+	err = newTestContext(invoker).VerifyMulti([]byte("sig"), []byte("msg"), nil)
+	assert.True(test, errors.Is(err, crypto.ErrMalformedRequest))
+	assert.Nil(test, invoker.request)
+}
+
+func TestMultiSignatureContextSendsDeadline(test *testing.T) {
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := gocontext.WithDeadline(gocontext.Background(), deadline)
+	defer cancel()
+	pubs := []crypto.PublicKey{key("pub1"), key("pub2")}
+
+	invoker := replyWith(&pb.AggregateResponse{Status: pb.AggregateResponse_OK, Signature: []byte("sig")}, pb.Type_AGGREGATE_RESPONSE)
+	_, err := newTestContext(invoker).AggregateMultiContext(ctx, [][]byte{[]byte("s1"), []byte("s2")}, []byte("msg"), pubs)
+	require.Nil(test, err)
+
+	aggregate := pb.AggregateRequest{}
+	require.Nil(test, proto.Unmarshal(invoker.request, &aggregate))
+	assert.Equal(test, deadline.UnixNano(), aggregate.Deadline)
+
+	invoker = replyWith(&pb.VerifyResponse{Status: pb.VerifyResponse_OK}, pb.Type_VERIFY_RESPONSE)
+	require.Nil(test, newTestContext(invoker).VerifyDistinctContext(ctx, []byte("sig"), [][]byte{[]byte("m1"), []byte("m2")}, pubs))
+
+	verify := pb.VerifyRequest{}
+	require.Nil(test, proto.Unmarshal(invoker.request, &verify))
+	assert.Equal(test, deadline.UnixNano(), verify.Deadline)
+
+	//cancelled requests are not sent
+	invoker.request = nil
+	cancel()
+	_, err = newTestContext(invoker).AggregateDistinctContext(ctx, [][]byte{[]byte("s1"), []byte("s2")}, [][]byte{[]byte("m1"), []byte("m2")}, pubs)
+	assert.Equal(test, gocontext.Canceled, err)
+	err = newTestContext(invoker).VerifyMultiContext(ctx, []byte("sig"), []byte("msg"), pubs)
+	assert.Equal(test, gocontext.Canceled, err)
+	assert.Nil(test, invoker.request)
+}
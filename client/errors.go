@@ -0,0 +1,13 @@
+package client
+
+import "errors"
+
+//Errors returned by the client when a request could not be completed.
+//Cryptographic failures reported by the signer node are returned
+//as *crypto.Error instead, so these never mean "invalid signature".
+var (
+	ErrRequestEncoding  = errors.New("request could not be encoded")
+	ErrTransport        = errors.New("transport failure")
+	ErrResponseDecoding = errors.New("response could not be decoded")
+	ErrClosed           = errors.New("context is closed")
+)
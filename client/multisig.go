@@ -0,0 +1,151 @@
+package client
+
+import (
+	gocontext "context"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+)
+
+var (
+	_ crypto.MultiSignatureAggregator        = (*context)(nil)
+	_ crypto.ContextMultiSignatureAggregator = (*context)(nil)
+)
+
+//AggregateMulti asks the signer node to aggregate the signatures
+//of msg made by the keys of pubs
+func (c *context) AggregateMulti(signatures [][]byte, msg []byte, pubs []crypto.PublicKey) ([]byte, error) {
+	return c.AggregateMultiContext(gocontext.Background(), signatures, msg, pubs)
+}
+
+//AggregateMultiContext aggregates as AggregateMulti, sending the deadline
+//of ctx along with the request so the signer node can give up on it
+func (c *context) AggregateMultiContext(ctx gocontext.Context, signatures [][]byte, msg []byte, pubs []crypto.PublicKey) ([]byte, error) {
+	if err := crypto.CheckMultiSignature(signatures, pubs); err != nil {
+		return nil, err
+	}
+
+	return c.aggregateMulti(ctx, &pb.AggregateRequest{Share: signatures, Digest: msg}, pubs)
+}
+
+//AggregateDistinct asks the signer node to aggregate the signatures
+//of msgs[i] made by pubs[i]
+func (c *context) AggregateDistinct(signatures [][]byte, msgs [][]byte, pubs []crypto.PublicKey) ([]byte, error) {
+	return c.AggregateDistinctContext(gocontext.Background(), signatures, msgs, pubs)
+}
+
+//AggregateDistinctContext aggregates as AggregateDistinct, sending the
+//deadline of ctx along with the request so the signer node can give up on it
+func (c *context) AggregateDistinctContext(ctx gocontext.Context, signatures [][]byte, msgs [][]byte, pubs []crypto.PublicKey) ([]byte, error) {
+	if err := crypto.CheckDistinctSignature(signatures, msgs, pubs); err != nil {
+		return nil, err
+	}
+
+	return c.aggregateMulti(ctx, &pb.AggregateRequest{Share: signatures, Msgs: msgs}, pubs)
+}
+
+//VerifyMulti asks the signer node to verify a signature of msg
+//aggregated from signatures made by the keys of pubs
+func (c *context) VerifyMulti(signature []byte, msg []byte, pubs []crypto.PublicKey) error {
+	return c.VerifyMultiContext(gocontext.Background(), signature, msg, pubs)
+}
+
+//VerifyMultiContext verifies as VerifyMulti, sending the deadline
+//of ctx along with the request so the signer node can give up on it
+func (c *context) VerifyMultiContext(ctx gocontext.Context, signature []byte, msg []byte, pubs []crypto.PublicKey) error {
+	if err := crypto.CheckMultiSignature(nil, pubs); err != nil {
+		return err
+	}
+
+	return c.verifyMulti(ctx, &pb.VerifyRequest{Signature: signature, Msg: msg}, pubs)
+}
+
+//VerifyDistinct asks the signer node to verify a signature aggregated
+//from signatures of msgs[i] made by pubs[i]
+func (c *context) VerifyDistinct(signature []byte, msgs [][]byte, pubs []crypto.PublicKey) error {
+	return c.VerifyDistinctContext(gocontext.Background(), signature, msgs, pubs)
+}
+
+//VerifyDistinctContext verifies as VerifyDistinct, sending the deadline
+//of ctx along with the request so the signer node can give up on it
+func (c *context) VerifyDistinctContext(ctx gocontext.Context, signature []byte, msgs [][]byte, pubs []crypto.PublicKey) error {
+	if err := crypto.CheckDistinctSignature(nil, msgs, pubs); err != nil {
+		return err
+	}
+
+	return c.verifyMulti(ctx, &pb.VerifyRequest{Signature: signature, Msgs: msgs}, pubs)
+}
+
+func (c *context) aggregateMulti(ctx gocontext.Context, req *pb.AggregateRequest, pubs []crypto.PublicKey) ([]byte, error) {
+	logger.Debugf("Aggregating multi-signature Request for %v", c.scheme)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	keys, proofs, err := marshalKeys(pubs)
+	if err != nil {
+		return nil, err
+	}
+	req.Scheme = c.scheme
+	req.PubKeys = keys
+	req.Proofs = proofs
+	req.Deadline = crypto.RequestDeadline(ctx)
+
+	reply := pb.AggregateResponse{}
+	err = c.invoke(req, pb.Type_AGGREGATE_REQUEST, pb.Type_AGGREGATE_RESPONSE, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	return aggregateResult(&reply)
+}
+
+func (c *context) verifyMulti(ctx gocontext.Context, req *pb.VerifyRequest, pubs []crypto.PublicKey) error {
+	logger.Debugf("Verify multi-signature Request for %v", c.scheme)
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	keys, proofs, err := marshalKeys(pubs)
+	if err != nil {
+		return err
+	}
+	req.Scheme = c.scheme
+	req.PubKeys = keys
+	req.Proofs = proofs
+	req.Deadline = crypto.RequestDeadline(ctx)
+
+	reply := pb.VerifyResponse{}
+	err = c.invoke(req, pb.Type_VERIFY_REQUEST, pb.Type_VERIFY_RESPONSE, &reply)
+	if err != nil {
+		return err
+	}
+
+	return verifyResult(&reply)
+}
+
+//marshalKeys encodes pubs and the proofs of possession of the ones that are
+//a crypto.ProvenKey, leaving the proofs empty if none of them is
+func marshalKeys(pubs []crypto.PublicKey) (keys [][]byte, proofs [][]byte, err error) {
+	keys = make([][]byte, 0, len(pubs))
+	proofs = make([][]byte, 0, len(pubs))
+	proven := false
+
+	for _, pub := range pubs {
+		b, err := marshalKey(pub)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, b)
+
+		proof := crypto.ProofOf(pub)
+		proven = proven || proof != nil
+		proofs = append(proofs, proof)
+	}
+
+	if !proven {
+		return keys, nil, nil
+	}
+	return keys, proofs, nil
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"fmt"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+)
+
+//ListSchemes asks the signer node which schemes it serves
+func (c *cryptoClient) ListSchemes() ([]crypto.SchemeInfo, error) {
+	invoker, closer := c.client.GetContext(crypto.SchemeDiscoveryID)
+	defer closer.Close()
+
+	ctx := &context{c, crypto.SchemeDiscoveryID, invoker}
+	return ctx.listSchemes()
+}
+
+func (c *context) listSchemes() ([]crypto.SchemeInfo, error) {
+	logger.Debugf("List schemes request")
+
+	reply := pb.ListSchemesResponse{}
+	err := c.invoke(&pb.ListSchemesRequest{}, pb.Type_LIST_SCHEMES_REQUEST, pb.Type_LIST_SCHEMES_RESPONSE, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	switch reply.Status {
+	case pb.ListSchemesResponse_OK:
+		schemes := make([]crypto.SchemeInfo, 0, len(reply.Schemes))
+		for _, info := range reply.Schemes {
+			schemes = append(schemes, crypto.SchemeInfoFromProto(info))
+		}
+		return schemes, nil
+	case pb.ListSchemesResponse_ERROR:
+		return nil, responseError(reply.ErrorCode, reply.ErrorMessage, pb.ErrorCode_INTERNAL)
+	default:
+		return nil, unknownStatusError(reply.Status)
+	}
+}
+
+//ListSchemes lists the schemes served by the signer node behind factory.
+//It fails with crypto.ErrUnsupported if factory cannot list schemes.
+func ListSchemes(factory crypto.ContextFactory) ([]crypto.SchemeInfo, error) {
+	lister, ok := factory.(crypto.SchemeLister)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T cannot list schemes", crypto.ErrUnsupported, factory)
+	}
+
+	return lister.ListSchemes()
+}
+
+//RequireSchemes checks that the signer node behind factory serves every
+//scheme in names, so services can validate their configuration at startup.
+func RequireSchemes(factory crypto.ContextFactory, names ...string) error {
+	schemes, err := ListSchemes(factory)
+	if err != nil {
+		return err
+	}
+
+	served := make(map[string]bool, len(schemes))
+	for _, info := range schemes {
+		served[info.Name] = true
+	}
+
+	for _, name := range names {
+		if !served[name] {
+			return fmt.Errorf("%w: scheme %v is not served by the signer node", crypto.ErrUnsupported, name)
+		}
+	}
+
+	return nil
+}
+
+//FindScheme returns the scheme described by d among those served by
+//the signer node behind factory, so callers need not build scheme names.
+//Schemes of nodes that do not report their family are matched by name.
+func FindScheme(factory crypto.ContextFactory, d crypto.Descriptor) (crypto.SchemeInfo, error) {
+	schemes, err := ListSchemes(factory)
+	if err != nil {
+		return crypto.SchemeInfo{}, err
+	}
+
+	for _, info := range schemes {
+		if info.Family == "" && info.Name == d.Name() || info.Family != "" && info.Descriptor() == d {
+			return info, nil
+		}
+	}
+
+	return crypto.SchemeInfo{}, fmt.Errorf("%w: scheme %v is not served by the signer node", crypto.ErrUnsupported, d.Name())
+}
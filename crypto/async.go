@@ -0,0 +1,29 @@
+package crypto
+
+import (
+	"context"
+	"io"
+)
+
+//Future is the eventual result of an asynchronous operation
+type Future interface {
+	//Done is closed once the result is available
+	Done() <-chan struct{}
+	//Result waits for the operation and returns the signature it
+	//produced, if any, and its error
+	Result() ([]byte, error)
+}
+
+//AsyncSignerVerifierAggregator starts operations without waiting for them.
+//The operation fails with ctx.Err() if ctx is done before it completes.
+type AsyncSignerVerifierAggregator interface {
+	SignAsync(ctx context.Context, digest []byte, key PrivateKey) Future
+	VerifyAsync(ctx context.Context, signature []byte, msg []byte, key PublicKey) Future
+	AggregateAsync(ctx context.Context, share [][]byte, digest []byte, key PublicKey, t, n int) Future
+}
+
+//AsyncContextFactory is implemented by a ContextFactory able to keep
+//up to inFlight requests of a scheme in progress at the same time.
+type AsyncContextFactory interface {
+	GetAsyncSignerVerifierAggregator(cryptoId string, inFlight int) (AsyncSignerVerifierAggregator, io.Closer)
+}
@@ -0,0 +1,247 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+)
+
+//DefaultMaxBatchSize is how many items a batch request may carry
+//unless configured with WithMaxBatchSize
+const DefaultMaxBatchSize = 4096
+
+//SignItem is a digest to sign in a batch
+type SignItem struct {
+	Digest []byte
+	Key    PrivateKey
+}
+
+//VerifyItem is a signature to verify in a batch
+type VerifyItem struct {
+	Signature []byte
+	Msg       []byte
+	Key       PublicKey
+}
+
+//AggregateItem is a set of signature shares to aggregate in a batch
+type AggregateItem struct {
+	Shares [][]byte
+	Digest []byte
+	Key    PublicKey
+	T      int
+	N      int
+}
+
+//SignResult is the outcome of a single item of a
+//sign or aggregate batch
+type SignResult struct {
+	Signature []byte
+	Err       error
+}
+
+//BatchSignerVerifierAggregator processes many items in a single request.
+//The returned error reports a failure of the whole batch, while the
+//outcome of each item is reported at the same index of the results.
+type BatchSignerVerifierAggregator interface {
+	SignBatch(items []SignItem) ([]SignResult, error)
+	VerifyBatch(items []VerifyItem) ([]error, error)
+	AggregateBatch(items []AggregateItem) ([]SignResult, error)
+}
+
+func (h *handlerDecorator) batchSign(ctx context.Context, req *pb.BatchSignRequest) []byte {
+	if err := h.checkBatchSize(len(req.Items)); err != nil {
+		logger.Warnf("Refusing batch: %v", err)
+		return createBatchSignErrorMsg(pb.ErrorCode_MALFORMED_REQUEST, err)
+	}
+
+	logger.Debugf("Start signing batch of %v", len(req.Items))
+
+	resp := pb.BatchSignResponse{
+		Status: pb.BatchSignResponse_OK,
+		Items:  make([]*pb.SignResponse, len(req.Items)),
+	}
+
+	h.forEach(ctx, pb.Type_BATCH_SIGN_REQUEST, len(req.Items), func(i int) {
+		resp.Items[i] = h.signItem(ctx, req.Items[i])
+	}, func(i int, err error) {
+		resp.Items[i] = signError(pb.ErrorCode_INTERNAL, err)
+	})
+
+	msgBytes, err := proto.Marshal(&resp)
+
+	if err != nil {
+		logger.Warn("Error marshalling response")
+		return createBatchSignErrorMsg(pb.ErrorCode_INTERNAL, err)
+	}
+
+	return msgBytes
+}
+
+func createBatchSignErrorMsg(code pb.ErrorCode, err error) []byte {
+	resp := pb.BatchSignResponse{
+		Status:       pb.BatchSignResponse_ERROR,
+		ErrorCode:    CodeOf(err, code),
+		ErrorMessage: err.Error(),
+	}
+
+	msgBytes, _ := proto.Marshal(&resp)
+
+	return msgBytes
+}
+
+func (h *handlerDecorator) batchVerify(ctx context.Context, req *pb.BatchVerifyRequest) []byte {
+	if err := h.checkBatchSize(len(req.Items)); err != nil {
+		logger.Warnf("Refusing batch: %v", err)
+		return createBatchVerifyErrorMsg(pb.ErrorCode_MALFORMED_REQUEST, err)
+	}
+
+	logger.Debugf("Start verifying batch of %v", len(req.Items))
+
+	resp := pb.BatchVerifyResponse{
+		Status: pb.BatchVerifyResponse_OK,
+		Items:  make([]*pb.VerifyResponse, len(req.Items)),
+	}
+
+	h.forEach(ctx, pb.Type_BATCH_VERIFY_REQUEST, len(req.Items), func(i int) {
+		resp.Items[i] = h.verifyItem(ctx, req.Items[i])
+	}, func(i int, err error) {
+		resp.Items[i] = verifyError(pb.ErrorCode_INTERNAL, err)
+	})
+
+	msgBytes, err := proto.Marshal(&resp)
+
+	if err != nil {
+		logger.Warn("Error marshalling response")
+		return createBatchVerifyErrorMsg(pb.ErrorCode_INTERNAL, err)
+	}
+
+	return msgBytes
+}
+
+func createBatchVerifyErrorMsg(code pb.ErrorCode, err error) []byte {
+	resp := pb.BatchVerifyResponse{
+		Status:       pb.BatchVerifyResponse_ERROR,
+		ErrorCode:    CodeOf(err, code),
+		ErrorMessage: err.Error(),
+	}
+
+	msgBytes, _ := proto.Marshal(&resp)
+
+	return msgBytes
+}
+
+func (h *handlerDecorator) batchAggregate(ctx context.Context, req *pb.BatchAggregateRequest) []byte {
+	if err := h.checkBatchSize(len(req.Items)); err != nil {
+		logger.Warnf("Refusing batch: %v", err)
+		return createBatchAggregateErrorMsg(pb.ErrorCode_MALFORMED_REQUEST, err)
+	}
+
+	logger.Debugf("Start aggregating batch of %v", len(req.Items))
+
+	resp := pb.BatchAggregateResponse{
+		Status: pb.BatchAggregateResponse_OK,
+		Items:  make([]*pb.AggregateResponse, len(req.Items)),
+	}
+
+	h.forEach(ctx, pb.Type_BATCH_AGGREGATE_REQUEST, len(req.Items), func(i int) {
+		resp.Items[i] = h.aggregateItem(ctx, req.Items[i])
+	}, func(i int, err error) {
+		resp.Items[i] = aggregateError(pb.ErrorCode_INTERNAL, err)
+	})
+
+	msgBytes, err := proto.Marshal(&resp)
+
+	if err != nil {
+		logger.Warn("Error marshalling response")
+		return createBatchAggregateErrorMsg(pb.ErrorCode_INTERNAL, err)
+	}
+
+	return msgBytes
+}
+
+func createBatchAggregateErrorMsg(code pb.ErrorCode, err error) []byte {
+	resp := pb.BatchAggregateResponse{
+		Status:       pb.BatchAggregateResponse_ERROR,
+		ErrorCode:    CodeOf(err, code),
+		ErrorMessage: err.Error(),
+	}
+
+	msgBytes, _ := proto.Marshal(&resp)
+
+	return msgBytes
+}
+
+//batchItems returns how many items req carries if it is a batch
+func batchItems(req request) int {
+	switch req := req.(type) {
+	case *pb.BatchSignRequest:
+		return len(req.Items)
+	case *pb.BatchVerifyRequest:
+		return len(req.Items)
+	case *pb.BatchAggregateRequest:
+		return len(req.Items)
+	default:
+		return 0
+	}
+}
+
+func (h *handlerDecorator) checkBatchSize(size int) error {
+	max := h.processor.maxBatchSize
+	if max <= 0 {
+		max = DefaultMaxBatchSize
+	}
+
+	if size > max {
+		return fmt.Errorf("%w: batch of %v items exceeds the limit of %v", ErrMalformedRequest, size, max)
+	}
+
+	return nil
+}
+
+//forEach calls process for the n items of a batch of type msgType,
+//using at most the processor batch workers at the same time.
+//A panic while processing an item, or ctx being done before
+//the item is processed, is reported to failed instead.
+func (h *handlerDecorator) forEach(ctx context.Context, msgType pb.Type, n int, process func(i int), failed func(i int, err error)) {
+	workers := h.processor.workers()
+	if workers > n {
+		workers = n
+	}
+
+	next := int64(-1)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= n {
+					return
+				}
+				if err := ctx.Err(); err != nil {
+					failed(i, err)
+					continue
+				}
+				h.processItem(msgType, i, process, failed)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (h *handlerDecorator) processItem(msgType pb.Type, i int, process func(i int), failed func(i int, err error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			failed(i, h.panicError(r, msgType))
+		}
+	}()
+
+	process(i)
+}
@@ -0,0 +1,92 @@
+package crypto
+
+import (
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBatchSign(test *testing.T) {
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithBatchWorkers(3))
+
+	req := pb.BatchSignRequest{Scheme: "Mock"}
+	for i := 0; i < 20; i++ {
+		req.Items = append(req.Items, &pb.SignRequest{PrivateKeys: []byte(fmt.Sprint(i))})
+	}
+	//an item cannot carry both a key id and a key
+	req.Items[7].KeyId = "Mock_5_3"
+
+	resp := pb.BatchSignResponse{}
+	handleWith(test, p, &req, pb.Type_BATCH_SIGN_REQUEST, &resp)
+	require.Equal(test, pb.BatchSignResponse_OK, resp.Status)
+	require.Len(test, resp.Items, 20)
+
+	for i, item := range resp.Items {
+		if i == 7 {
+			assert.Equal(test, pb.SignResponse_ERROR, item.Status)
+			assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, item.ErrorCode)
+			continue
+		}
+		assert.Equal(test, pb.SignResponse_OK, item.Status)
+		assert.Equal(test, []byte(fmt.Sprint(i)), item.Signature)
+	}
+}
+
+func TestBatchVerify(test *testing.T) {
+	h := handlerDecorator{mockSignerHandler{}, &SignerProcessor{}}
+
+	req, _ := proto.Marshal(&pb.BatchVerifyRequest{Items: []*pb.VerifyRequest{
+		{Signature: []byte("sig"), Msg: []byte("msg")},
+		{Msg: []byte("msg")},
+	}})
+	respBytes, respType := h.Handle(req, int32(pb.Type_BATCH_VERIFY_REQUEST))
+	require.Equal(test, int32(pb.Type_BATCH_VERIFY_RESPONSE), respType)
+
+	resp := pb.BatchVerifyResponse{}
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	require.Len(test, resp.Items, 2)
+	assert.Equal(test, pb.VerifyResponse_OK, resp.Items[0].Status)
+	assert.Equal(test, pb.VerifyResponse_ERROR, resp.Items[1].Status)
+	assert.Equal(test, pb.ErrorCode_INVALID_SIGNATURE, resp.Items[1].ErrorCode)
+}
+
+func TestBatchTooLarge(test *testing.T) {
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithMaxBatchSize(2))
+
+	req := pb.BatchAggregateRequest{Items: []*pb.AggregateRequest{{}, {}, {}}}
+	resp := pb.BatchAggregateResponse{}
+	handleWith(test, p, &req, pb.Type_BATCH_AGGREGATE_REQUEST, &resp)
+
+	assert.Equal(test, pb.BatchAggregateResponse_ERROR, resp.Status)
+	assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, resp.ErrorCode)
+}
+
+func TestBatchUnsupportedOperation(test *testing.T) {
+	h := handlerDecorator{signOnlyHandler{}, &SignerProcessor{}}
+
+	req, _ := proto.Marshal(&pb.BatchAggregateRequest{Items: []*pb.AggregateRequest{{}}})
+	_, respType := h.Handle(req, int32(pb.Type_BATCH_AGGREGATE_REQUEST))
+
+	assert.Equal(test, int32(pb.Type_UNSUPPORTED_RESPONSE), respType)
+}
+
+func TestBatchRecoversFromPanic(test *testing.T) {
+	p := &SignerProcessor{}
+	h := handlerDecorator{panicSignerHandler{value: "boom"}, p}
+
+	req, _ := proto.Marshal(&pb.BatchAggregateRequest{Items: []*pb.AggregateRequest{{}, {}, {}}})
+	respBytes, respType := h.Handle(req, int32(pb.Type_BATCH_AGGREGATE_REQUEST))
+	require.Equal(test, int32(pb.Type_BATCH_AGGREGATE_RESPONSE), respType)
+
+	resp := pb.BatchAggregateResponse{}
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	require.Equal(test, pb.BatchAggregateResponse_OK, resp.Status)
+	for _, item := range resp.Items {
+		assert.Equal(test, pb.AggregateResponse_ERROR, item.Status)
+		assert.Equal(test, pb.ErrorCode_INTERNAL, item.ErrorCode)
+	}
+	assert.Equal(test, uint64(3), p.RecoveredPanics())
+}
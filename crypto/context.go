@@ -0,0 +1,102 @@
+package crypto
+
+import (
+	"context"
+	"time"
+)
+
+//ContextSigner is a Signer that can be cancelled through ctx
+type ContextSigner interface {
+	SignContext(ctx context.Context, digest []byte, key PrivateKey) (signature []byte, err error)
+}
+
+//ContextVerifier is a Verifier that can be cancelled through ctx
+type ContextVerifier interface {
+	VerifyContext(ctx context.Context, signature []byte, msg []byte, key PublicKey) error
+}
+
+//ContextAggregator is an Aggregator that can be cancelled through ctx
+type ContextAggregator interface {
+	AggregateContext(ctx context.Context, share [][]byte, digest []byte, key PublicKey, t, n int) (signature []byte, err error)
+}
+
+//ContextKeyShareGenerator is a KeyShareGenerator that can be cancelled through ctx
+type ContextKeyShareGenerator interface {
+	GenContext(ctx context.Context, n int, t int) (PublicKey, PrivateKeyList, error)
+}
+
+//SignContext signs with s, honouring ctx if s is a ContextSigner.
+//Otherwise ctx is only checked before signing.
+func SignContext(ctx context.Context, s Signer, digest []byte, key PrivateKey) ([]byte, error) {
+	if cs, ok := s.(ContextSigner); ok {
+		return cs.SignContext(ctx, digest, key)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.Sign(digest, key)
+}
+
+//VerifyContext verifies with v, honouring ctx if v is a ContextVerifier.
+//Otherwise ctx is only checked before verifying.
+func VerifyContext(ctx context.Context, v Verifier, signature []byte, msg []byte, key PublicKey) error {
+	if cv, ok := v.(ContextVerifier); ok {
+		return cv.VerifyContext(ctx, signature, msg, key)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return v.Verify(signature, msg, key)
+}
+
+//AggregateContext aggregates with a, honouring ctx if a is a ContextAggregator.
+//Otherwise ctx is only checked before aggregating.
+func AggregateContext(ctx context.Context, a Aggregator, share [][]byte, digest []byte, key PublicKey, t, n int) ([]byte, error) {
+	if ca, ok := a.(ContextAggregator); ok {
+		return ca.AggregateContext(ctx, share, digest, key, t, n)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return a.Aggregate(share, digest, key, t, n)
+}
+
+//GenContext generates keys with g, honouring ctx if g is a ContextKeyShareGenerator.
+//Otherwise ctx is only checked before generating.
+func GenContext(ctx context.Context, g KeyShareGenerator, n int, t int) (PublicKey, PrivateKeyList, error) {
+	if cg, ok := g.(ContextKeyShareGenerator); ok {
+		return cg.GenContext(ctx, n, t)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return g.Gen(n, t)
+}
+
+//RequestDeadline encodes the deadline of ctx for the deadline
+//field of a request, in nanoseconds since the epoch.
+//It returns 0 if ctx has no deadline.
+func RequestDeadline(ctx context.Context) int64 {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	return deadline.UnixNano()
+}
+
+//withRequestDeadline returns a context bounded by the deadline
+//field of a request, if it has one
+func withRequestDeadline(parent context.Context, deadline int64) (context.Context, context.CancelFunc) {
+	if deadline == 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithDeadline(parent, time.Unix(0, deadline))
+}
@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"context"
+	"github.com/golang/protobuf/proto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+//deadlineSignerHandler signs with the deadline of the context it was given
+type deadlineSignerHandler struct {
+	mockSignerHandler
+}
+
+func (m deadlineSignerHandler) SignContext(ctx context.Context, digest []byte, key PrivateKey) ([]byte, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return []byte("none"), nil
+	}
+	return []byte(deadline.UTC().Format(time.RFC3339Nano)), nil
+}
+
+func TestSignContextChecksContext(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := SignContext(ctx, mockSignerHandler{}, []byte("msg"), mockKey("key"))
+	assert.Equal(test, context.Canceled, err)
+
+	_, _, err = GenContext(ctx, mockSignerHandler{}, 3, 2)
+	assert.Equal(test, context.Canceled, err)
+
+	err = VerifyContext(context.Background(), mockSignerHandler{}, []byte("sig"), []byte("msg"), mockKey("key"))
+	assert.Nil(test, err)
+}
+
+func TestRequestDeadline(test *testing.T) {
+	assert.Equal(test, int64(0), RequestDeadline(context.Background()))
+
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	encoded := RequestDeadline(ctx)
+	assert.Equal(test, deadline.UnixNano(), encoded)
+
+	decoded, cancel := withRequestDeadline(context.Background(), encoded)
+	defer cancel()
+	got, ok := decoded.Deadline()
+	require.True(test, ok)
+	assert.True(test, deadline.Equal(got))
+}
+
+func TestHandlerPassesRequestDeadline(test *testing.T) {
+	h := handlerDecorator{deadlineSignerHandler{}, &SignerProcessor{}}
+	deadline := time.Now().Add(time.Minute)
+
+	for _, c := range []struct {
+		deadline int64
+		expected string
+	}{
+		{0, "none"},
+		{deadline.UnixNano(), deadline.UTC().Format(time.RFC3339Nano)},
+	} {
+		req, _ := proto.Marshal(&pb.SignRequest{Scheme: "Mock", Digest: []byte("msg"), PrivateKeys: []byte("key"), Deadline: c.deadline})
+		respBytes, _ := h.Handle(req, int32(pb.Type_SIGN_REQUEST))
+
+		resp := pb.SignResponse{}
+		require.Nil(test, proto.Unmarshal(respBytes, &resp))
+		require.Equal(test, pb.SignResponse_OK, resp.Status)
+		assert.Equal(test, c.expected, string(resp.Signature))
+	}
+}
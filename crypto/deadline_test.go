@@ -0,0 +1,181 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/protobuf/proto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+//blockingSignerHandler signs only once release is closed,
+//ignoring any deadline
+type blockingSignerHandler struct {
+	mockSignerHandler
+	release chan struct{}
+	calls   *int32
+}
+
+func (m blockingSignerHandler) Sign(digest []byte, key PrivateKey) ([]byte, error) {
+	atomic.AddInt32(m.calls, 1)
+	<-m.release
+	return []byte("late"), nil
+}
+
+func newBlockingSignerHandler() blockingSignerHandler {
+	return blockingSignerHandler{release: make(chan struct{}), calls: new(int32)}
+}
+
+func signWithin(test *testing.T, h *handlerDecorator, deadline int64) *pb.SignResponse {
+	req, _ := proto.Marshal(&pb.SignRequest{Scheme: "Mock", Digest: []byte("msg"), PrivateKeys: []byte("key"), Deadline: deadline})
+	respBytes, respType := h.Handle(req, int32(pb.Type_SIGN_REQUEST))
+	require.Equal(test, int32(pb.Type_SIGN_RESPONSE), respType)
+
+	resp := pb.SignResponse{}
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	return &resp
+}
+
+func TestHandlerAbortsAtRequestDeadline(test *testing.T) {
+	handler := newBlockingSignerHandler()
+	defer close(handler.release)
+	h := &handlerDecorator{handler, &SignerProcessor{}}
+
+	start := time.Now()
+	resp := signWithin(test, h, time.Now().Add(20*time.Millisecond).UnixNano())
+
+	assert.Equal(test, pb.SignResponse_ERROR, resp.Status)
+	assert.Equal(test, pb.ErrorCode_DEADLINE_EXCEEDED, resp.ErrorCode)
+	assert.True(test, time.Since(start) < time.Second)
+}
+
+func TestHandlerRefusesExpiredRequest(test *testing.T) {
+	handler := newBlockingSignerHandler()
+	defer close(handler.release)
+	h := &handlerDecorator{handler, &SignerProcessor{}}
+
+	resp := signWithin(test, h, time.Now().Add(-time.Second).UnixNano())
+
+	assert.Equal(test, pb.ErrorCode_DEADLINE_EXCEEDED, resp.ErrorCode)
+	assert.Equal(test, int32(0), atomic.LoadInt32(handler.calls))
+}
+
+func TestHandlerAppliesOperationTimeout(test *testing.T) {
+	handler := newBlockingSignerHandler()
+	defer close(handler.release)
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithTimeout(SignOperation, 20*time.Millisecond))
+	h := &handlerDecorator{handler, p}
+
+	resp := signWithin(test, h, 0)
+
+	assert.Equal(test, pb.ErrorCode_DEADLINE_EXCEEDED, resp.ErrorCode)
+}
+
+func TestHandlerTimeoutOnlyAppliesToItsOperation(test *testing.T) {
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithTimeout(AggregateOperation, time.Nanosecond))
+	h := &handlerDecorator{mockSignerHandler{}, p}
+
+	resp := signWithin(test, h, 0)
+
+	assert.Equal(test, pb.SignResponse_OK, resp.Status)
+}
+
+func TestBatchTimeoutScalesWithRounds(test *testing.T) {
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithTimeout(SignOperation, time.Hour), WithBatchWorkers(4))
+
+	ctx, cancel := p.requestContext(pb.Type_SIGN_REQUEST, &pb.SignRequest{})
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(test, ok)
+	assert.WithinDuration(test, time.Now().Add(time.Hour), deadline, time.Minute)
+
+	//10 items processed by 4 workers take 3 rounds
+	batch := &pb.BatchSignRequest{Items: make([]*pb.SignRequest, 10)}
+	ctx, cancel = p.requestContext(pb.Type_BATCH_SIGN_REQUEST, batch)
+	defer cancel()
+	deadline, ok = ctx.Deadline()
+	require.True(test, ok)
+	assert.WithinDuration(test, time.Now().Add(3*time.Hour), deadline, time.Minute)
+}
+
+//contextSignerHandler signs once ctx is done, giving up as asked
+type contextSignerHandler struct {
+	mockSignerHandler
+}
+
+func (m contextSignerHandler) SignContext(ctx context.Context, digest []byte, key PrivateKey) ([]byte, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestHandlerLimitsAbandonedRequests(test *testing.T) {
+	handler := newBlockingSignerHandler()
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithMaxAbandoned(1))
+	h := &handlerDecorator{handler, p}
+
+	resp := signWithin(test, h, time.Now().Add(10*time.Millisecond).UnixNano())
+	assert.Equal(test, pb.ErrorCode_DEADLINE_EXCEEDED, resp.ErrorCode)
+	assert.Equal(test, int64(1), p.AbandonedRequests())
+
+	//the handler still runs, so further requests are refused at once
+	resp = signWithin(test, h, time.Now().Add(time.Minute).UnixNano())
+	assert.Equal(test, pb.ErrorCode_OVERLOADED, resp.ErrorCode)
+	assert.Equal(test, int32(1), atomic.LoadInt32(handler.calls))
+
+	close(handler.release)
+	require.Eventually(test, func() bool { return p.AbandonedRequests() == 0 }, time.Second, time.Millisecond)
+
+	resp = signWithin(test, h, time.Now().Add(time.Minute).UnixNano())
+	assert.Equal(test, pb.SignResponse_OK, resp.Status)
+}
+
+func TestHandlerPassesDeadlineToHandler(test *testing.T) {
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithMaxAbandoned(1))
+	h := &handlerDecorator{contextSignerHandler{}, p}
+
+	for i := 0; i < 3; i++ {
+		resp := signWithin(test, h, time.Now().Add(10*time.Millisecond).UnixNano())
+		assert.Equal(test, pb.ErrorCode_DEADLINE_EXCEEDED, resp.ErrorCode)
+		require.Eventually(test, func() bool { return p.AbandonedRequests() == 0 }, time.Second, time.Millisecond)
+	}
+}
+
+func TestHandlerDeadlineWithinBatch(test *testing.T) {
+	h := &handlerDecorator{mockSignerHandler{}, &SignerProcessor{}}
+	expired := time.Now().Add(-time.Second).UnixNano()
+
+	req, _ := proto.Marshal(&pb.BatchSignRequest{Scheme: "Mock", Items: []*pb.SignRequest{
+		{Digest: []byte("msg"), PrivateKeys: []byte("key")},
+		{Digest: []byte("msg"), PrivateKeys: []byte("key"), Deadline: expired},
+	}})
+	respBytes, _ := h.Handle(req, int32(pb.Type_BATCH_SIGN_REQUEST))
+
+	resp := pb.BatchSignResponse{}
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	require.Equal(test, pb.BatchSignResponse_OK, resp.Status)
+	assert.Equal(test, pb.SignResponse_OK, resp.Items[0].Status)
+	assert.Equal(test, pb.ErrorCode_DEADLINE_EXCEEDED, resp.Items[1].ErrorCode)
+}
+
+func TestDeadlineExceededError(test *testing.T) {
+	err := NewError(pb.ErrorCode_DEADLINE_EXCEEDED, "too late")
+
+	assert.True(test, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(test, pb.ErrorCode_DEADLINE_EXCEEDED, CodeOf(context.DeadlineExceeded, pb.ErrorCode_INTERNAL))
+}
+
+func TestListSchemesRefusesExpiredRequest(test *testing.T) {
+	h := discoveryHandler{&SignerProcessor{}}
+
+	req, _ := proto.Marshal(&pb.ListSchemesRequest{Deadline: time.Now().Add(-time.Second).UnixNano()})
+	respBytes, _ := h.Handle(req, int32(pb.Type_LIST_SCHEMES_REQUEST))
+
+	resp := pb.ListSchemesResponse{}
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	assert.Equal(test, pb.ListSchemesResponse_ERROR, resp.Status)
+	assert.Equal(test, pb.ErrorCode_DEADLINE_EXCEEDED, resp.ErrorCode)
+}
@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+)
+
+//SchemeDiscoveryID is the handler id answering LIST_SCHEMES requests
+//in every SignerProcessor
+const SchemeDiscoveryID = "SchemeDiscovery"
+
+//discoveryHandler lists the schemes added to a SignerProcessor
+type discoveryHandler struct {
+	processor *SignerProcessor
+}
+
+func (h *discoveryHandler) Name() string {
+	return SchemeDiscoveryID
+}
+
+func (h *discoveryHandler) Handle(msg []byte, msgType int32) ([]byte, int32) {
+	if pb.Type(msgType) != pb.Type_LIST_SCHEMES_REQUEST {
+		logger.Warnf("Unknown message type %v for %v", msgType, SchemeDiscoveryID)
+		err := NewError(pb.ErrorCode_UNSUPPORTED, fmt.Sprintf("unknown message type %v", msgType))
+		return createUnsupportedMsg(SchemeDiscoveryID, pb.Type(msgType), err), int32(pb.Type_UNSUPPORTED_RESPONSE)
+	}
+
+	return h.listSchemes(msg), int32(pb.Type_LIST_SCHEMES_RESPONSE)
+}
+
+func (h *discoveryHandler) listSchemes(msg []byte) []byte {
+	req := pb.ListSchemesRequest{}
+	err := proto.Unmarshal(msg, &req)
+
+	if err != nil {
+		logger.Warn("Error unmarshalling request")
+		return createListSchemesErrorMsg(pb.ErrorCode_MALFORMED_REQUEST, err)
+	}
+
+	ctx, cancel := withRequestDeadline(context.Background(), req.Deadline)
+	defer cancel()
+
+	if err := ctx.Err(); err != nil {
+		logger.Warnf("Not listing schemes: %v", err)
+		return createListSchemesErrorMsg(pb.ErrorCode_DEADLINE_EXCEEDED, err)
+	}
+
+	resp := pb.ListSchemesResponse{Status: pb.ListSchemesResponse_OK}
+	for _, info := range h.processor.Schemes() {
+		resp.Schemes = append(resp.Schemes, info.ToProto())
+	}
+
+	msgBytes, err := proto.Marshal(&resp)
+
+	if err != nil {
+		logger.Warn("Error marshalling response")
+		return createListSchemesErrorMsg(pb.ErrorCode_INTERNAL, err)
+	}
+
+	return msgBytes
+}
+
+func createListSchemesErrorMsg(code pb.ErrorCode, err error) []byte {
+	resp := pb.ListSchemesResponse{
+		Status:       pb.ListSchemesResponse_ERROR,
+		ErrorCode:    CodeOf(err, code),
+		ErrorMessage: err.Error(),
+	}
+
+	msgBytes, _ := proto.Marshal(&resp)
+
+	return msgBytes
+}
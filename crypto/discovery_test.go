@@ -0,0 +1,65 @@
+package crypto
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+//describedHandler describes itself as a pessimistic threshold scheme
+type describedHandler struct {
+	mockSignerHandler
+}
+
+func (m describedHandler) SchemeName() string {
+	return "Described"
+}
+
+func (m describedHandler) DescribeScheme() SchemeInfo {
+	return SchemeInfo{KeySize: 1024, Threshold: true, Aggregation: PessimisticAggregation}
+}
+
+func TestDescribeScheme(test *testing.T) {
+	info := DescribeScheme(mockSignerHandler{})
+	assert.Equal(test, "Mock", info.Name)
+	assert.Equal(test, AllOperations(), info.Operations)
+	assert.True(test, info.Threshold)
+	assert.Equal(test, NormalAggregation, info.Aggregation)
+
+	info = DescribeScheme(signOnlyHandler{})
+	assert.False(test, info.Threshold)
+	assert.Equal(test, NoAggregation, info.Aggregation)
+	assert.False(test, info.Supports(AggregateOperation))
+
+	info = DescribeScheme(describedHandler{})
+	assert.Equal(test, "Described", info.Name)
+	assert.Equal(test, 1024, info.KeySize)
+	assert.Equal(test, PessimisticAggregation, info.Aggregation)
+}
+
+func TestListSchemes(test *testing.T) {
+	p := NewSignerProcessor("tcp://127.0.0.1:9000")
+	p.AddHandler(mockSignerHandler{})
+	p.AddHandler(describedHandler{})
+
+	req, _ := proto.Marshal(&pb.ListSchemesRequest{})
+	respBytes, respType := (&discoveryHandler{p}).Handle(req, int32(pb.Type_LIST_SCHEMES_REQUEST))
+	require.Equal(test, int32(pb.Type_LIST_SCHEMES_RESPONSE), respType)
+
+	resp := pb.ListSchemesResponse{}
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	require.Equal(test, pb.ListSchemesResponse_OK, resp.Status)
+	require.Len(test, resp.Schemes, 2)
+
+	assert.Equal(test, DescribeScheme(mockSignerHandler{}), SchemeInfoFromProto(resp.Schemes[0]))
+	assert.Equal(test, DescribeScheme(describedHandler{}), SchemeInfoFromProto(resp.Schemes[1]))
+}
+
+func TestListSchemesUnknownMessageType(test *testing.T) {
+	p := NewSignerProcessor("tcp://127.0.0.1:9000")
+
+	_, respType := (&discoveryHandler{p}).Handle(nil, int32(pb.Type_SIGN_REQUEST))
+	assert.Equal(test, int32(pb.Type_UNSUPPORTED_RESPONSE), respType)
+}
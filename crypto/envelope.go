@@ -0,0 +1,329 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+)
+
+//EnvelopeVersion is the version of the key envelopes written by this package
+const EnvelopeVersion = 1
+
+//envelopeMagic prefixes every key envelope so it can be told apart
+//from the raw encodings of keys written before envelopes existed
+var envelopeMagic = []byte("THSK")
+
+//KeyEnvelope wraps the encoding of a public key or of a private key share
+//with what is needed to use it: the scheme it belongs to, its threshold,
+//the index of the share and the id it is stored under.
+//A KeyEnvelope is itself a PublicKey and a PrivateKey, so it can be stored
+//in a keychain or sent in a request in place of the key it wraps.
+type KeyEnvelope struct {
+	Version uint32
+	Scheme  string
+	T       int
+	N       int
+	//Index is the 1-based index of a share, or 0 for a public key
+	Index   int
+	KeyID   string
+	Created time.Time
+	Key     []byte
+	//Fingerprint is the fingerprint of the public key,
+	//also recorded in the envelopes of its shares
+	Fingerprint string
+	//KeyTag names the keys of Scheme, the same for every way of
+	//aggregating them, so the key can be fingerprinted without
+	//knowing the schemes registered where it was generated
+	KeyTag string
+	//Proof is the proof of possession of the private key of a public key,
+	//for schemes aggregating the signatures of independent keys
+	Proof []byte
+}
+
+//NewKeyEnvelope wraps key, a public key if index is 0 and
+//the share at index otherwise, of a (t, n) key of scheme.
+//The fingerprint is only filled for public keys.
+func NewKeyEnvelope(scheme string, t, n, index int, keyID string, key encoding.BinaryMarshaler) (*KeyEnvelope, error) {
+	keyBytes, err := key.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	env := &KeyEnvelope{
+		Version: EnvelopeVersion,
+		Scheme:  scheme,
+		T:       t,
+		N:       n,
+		Index:   index,
+		KeyID:   keyID,
+		Created: time.Now().UTC(),
+		Key:     keyBytes,
+		KeyTag:  keyTag(scheme),
+	}
+
+	if index == 0 {
+		env.Fingerprint = fingerprint(env.KeyTag, keyBytes)
+	}
+
+	return env, nil
+}
+
+//SealKeys wraps the public key and shares generated for a (t, n) key
+//of scheme in envelopes, recording the public key fingerprint in all of them
+func SealKeys(scheme string, t, n int, keyID string, pub PublicKey, priv PrivateKeyList) (*KeyEnvelope, PrivateKeyList, error) {
+	pubEnv, err := NewKeyEnvelope(scheme, t, n, 0, keyID, pub)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shares := make(PrivateKeyList, len(priv))
+	for i, share := range priv {
+		env, err := NewKeyEnvelope(scheme, t, n, i+1, keyID, share)
+		if err != nil {
+			return nil, nil, err
+		}
+		env.Created = pubEnv.Created
+		env.Fingerprint = pubEnv.Fingerprint
+		shares[i] = env
+	}
+
+	return pubEnv, shares, nil
+}
+
+//IsShare reports whether the envelope wraps a private key share
+func (e *KeyEnvelope) IsShare() bool {
+	return e.Index > 0
+}
+
+func (e *KeyEnvelope) MarshalBinary() ([]byte, error) {
+	var created int64
+	if !e.Created.IsZero() {
+		created = e.Created.UnixNano()
+	}
+
+	b, err := proto.Marshal(&pb.KeyEnvelope{
+		Version: e.Version,
+		Scheme:  e.Scheme,
+		T:       uint32(e.T),
+		N:       uint32(e.N),
+		Index:   uint32(e.Index),
+		KeyId:   e.KeyID,
+		Created: created,
+		Key:     e.Key,
+
+		Fingerprint: e.Fingerprint,
+		KeyTag:      e.KeyTag,
+		Proof:       e.Proof,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]byte{}, envelopeMagic...), b...), nil
+}
+
+//IsKeyEnvelope reports whether data is the encoding of a KeyEnvelope
+//rather than a raw key
+func IsKeyEnvelope(data []byte) bool {
+	return bytes.HasPrefix(data, envelopeMagic)
+}
+
+//UnmarshalKeyEnvelope decodes a KeyEnvelope.
+//It fails with ErrBadKeyEncoding if data is not a valid envelope
+//and with ErrUnsupported if it was written by a newer version.
+func UnmarshalKeyEnvelope(data []byte) (*KeyEnvelope, error) {
+	if !IsKeyEnvelope(data) {
+		return nil, fmt.Errorf("%w: not a key envelope", ErrBadKeyEncoding)
+	}
+
+	env := pb.KeyEnvelope{}
+	if err := proto.Unmarshal(data[len(envelopeMagic):], &env); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadKeyEncoding, err)
+	}
+
+	if env.Version == 0 || env.Version > EnvelopeVersion {
+		return nil, fmt.Errorf("%w: key envelope version %v", ErrUnsupported, env.Version)
+	}
+
+	if env.Scheme == "" || len(env.Key) == 0 || env.Index > env.N || env.T > env.N {
+		return nil, fmt.Errorf("%w: incomplete key envelope", ErrBadKeyEncoding)
+	}
+
+	e := &KeyEnvelope{
+		Version: env.Version,
+		Scheme:  env.Scheme,
+		T:       int(env.T),
+		N:       int(env.N),
+		Index:   int(env.Index),
+		KeyID:   env.KeyId,
+		Key:     env.Key,
+
+		Fingerprint: env.Fingerprint,
+		KeyTag:      env.KeyTag,
+		Proof:       env.Proof,
+	}
+	if env.Created != 0 {
+		e.Created = time.Unix(0, env.Created).UTC()
+	}
+
+	return e, nil
+}
+
+//openKey returns the key encoding in data along with its envelope,
+//or a nil envelope if data is a raw key.
+//It fails if the key cannot be used by the scheme of the handler
+//or is not a share when share is set, or a public key otherwise.
+func (h *handlerDecorator) openKey(data []byte, share bool) ([]byte, *KeyEnvelope, error) {
+	if !IsKeyEnvelope(data) {
+		return data, nil, nil
+	}
+
+	env, err := UnmarshalKeyEnvelope(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !sharesKeys(env.Scheme, h.SchemeName()) {
+		return nil, nil, fmt.Errorf("%w: key of %v cannot be used with %v", ErrBadKeyEncoding, env.Scheme, h.SchemeName())
+	}
+
+	if share && !env.IsShare() {
+		return nil, nil, fmt.Errorf("%w: expected a private key share", ErrBadKeyEncoding)
+	}
+
+	if !share && env.IsShare() {
+		return nil, nil, fmt.Errorf("%w: expected a public key", ErrBadKeyEncoding)
+	}
+
+	return env.Key, env, nil
+}
+
+func (h *handlerDecorator) publicKey(data []byte) (PublicKey, *KeyEnvelope, error) {
+	keyBytes, env, err := h.openKey(data, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pub, err := h.UnmarshalPublic(keyBytes)
+	return pub, env, err
+}
+
+func (h *handlerDecorator) privateKey(data []byte) (PrivateKey, *KeyEnvelope, error) {
+	keyBytes, env, err := h.openKey(data, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	priv, err := h.UnmarshalPrivate(keyBytes)
+	return priv, env, err
+}
+
+//sharesKeys reports whether keys of scheme can be used with other
+//according to the DefaultRegistry
+func sharesKeys(scheme, other string) bool {
+	return DefaultRegistry.SharesKeys(scheme, other)
+}
+
+//storedEnvelope returns the envelope of the public key the processor
+//keychain holds under the id of env, so the threshold of the key is not
+//taken from the client. It fails if that key is not the key of env, and
+//returns env itself if the processor holds no envelope of the key.
+//stored reports whether the returned envelope is the one of the keychain.
+func (h *handlerDecorator) storedEnvelope(env *KeyEnvelope) (_ *KeyEnvelope, stored bool, err error) {
+	if env == nil || env.KeyID == "" || h.processor.keys == nil {
+		return env, false, nil
+	}
+
+	if err := validateKeyID(env.KeyID); err != nil {
+		return nil, false, fmt.Errorf("%w: invalid key id %q", ErrBadKeyEncoding, env.KeyID)
+	}
+
+	storedKey, err := h.processor.keys.LoadPublicKey(env.KeyID)
+	if errors.Is(err, os.ErrNotExist) {
+		return env, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: loading %v: %v", ErrInternal, env.KeyID, err)
+	}
+
+	storedBytes, err := storedKey.MarshalBinary()
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: loading %v: %v", ErrInternal, env.KeyID, err)
+	}
+
+	if !IsKeyEnvelope(storedBytes) {
+		if !bytes.Equal(storedBytes, env.Key) {
+			return nil, false, fmt.Errorf("%w: key does not match the stored %v", ErrBadKeyEncoding, env.KeyID)
+		}
+		return env, false, nil
+	}
+
+	storedEnv, err := UnmarshalKeyEnvelope(storedBytes)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: loading %v: %v", ErrInternal, env.KeyID, err)
+	}
+
+	if storedEnv.IsShare() || !bytes.Equal(storedEnv.Key, env.Key) {
+		return nil, false, fmt.Errorf("%w: key does not match the stored %v", ErrBadKeyEncoding, env.KeyID)
+	}
+
+	return storedEnv, true, nil
+}
+
+//thresholdOf returns the threshold an aggregate request must use.
+//Only the t and n of an envelope from the processor keychain are trusted:
+//requests for such a key may leave t and n unset, otherwise they must
+//match the ones of the key. The t and n of an envelope sent by the client
+//are not checked, so requests for it must set t and n to those of the
+//envelope rather than take them from it.
+func thresholdOf(req *pb.AggregateRequest, env *KeyEnvelope, stored bool) (t, n int, err error) {
+	t, n = int(req.T), int(req.N)
+	if env == nil {
+		return t, n, nil
+	}
+
+	if t == 0 && n == 0 {
+		if !stored {
+			return 0, 0, fmt.Errorf("%w: t and n must be given for a key not held by the signer node", ErrMalformedRequest)
+		}
+		return env.T, env.N, nil
+	}
+
+	if t != env.T || n != env.N {
+		return 0, 0, fmt.Errorf("%w: t=%v n=%v do not match the %v-of-%v key", ErrMalformedRequest, t, n, env.T, env.N)
+	}
+
+	return t, n, nil
+}
+
+//sealKeys wraps the keys generated for req in envelopes,
+//along with the proof of possession of single keys of schemes requiring them
+func (h *handlerDecorator) sealKeys(req *pb.GenerateTHSRequest, keyID string, pub PublicKey, priv PrivateKeyList) (*KeyEnvelope, PrivateKeyList, error) {
+	t, n := int(req.T), len(priv)
+	if n == 1 {
+		//schemes without a threshold generate a single key
+		t = 1
+	}
+
+	var proof []byte
+	if prover, ok := h.THSignerHandler.(PossessionProver); ok && n == 1 {
+		var err error
+		if proof, err = prover.ProvePossession(priv[0]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	pubEnv, shares, err := SealKeys(h.SchemeName(), t, n, keyID, pub, priv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pubEnv.Proof = proof
+	return pubEnv, shares, nil
+}
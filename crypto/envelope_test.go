@@ -0,0 +1,149 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestKeyEnvelopeRoundTrip(test *testing.T) {
+	env, err := NewKeyEnvelope("Mock", 3, 5, 2, "Mock_5_3", mockKey("share"))
+	require.Nil(test, err)
+
+	b, err := env.MarshalBinary()
+	require.Nil(test, err)
+	assert.True(test, IsKeyEnvelope(b))
+	assert.False(test, IsKeyEnvelope([]byte("share")))
+
+	decoded, err := UnmarshalKeyEnvelope(b)
+	require.Nil(test, err)
+	assert.Equal(test, env.Scheme, decoded.Scheme)
+	assert.Equal(test, 3, decoded.T)
+	assert.Equal(test, 5, decoded.N)
+	assert.Equal(test, 2, decoded.Index)
+	assert.Equal(test, "Mock_5_3", decoded.KeyID)
+	assert.Equal(test, []byte("share"), decoded.Key)
+	assert.True(test, decoded.IsShare())
+	assert.True(test, env.Created.Equal(decoded.Created))
+	assert.WithinDuration(test, time.Now(), decoded.Created, time.Minute)
+}
+
+func TestKeyEnvelopeRejectsInvalidEncodings(test *testing.T) {
+	_, err := UnmarshalKeyEnvelope([]byte("share"))
+	assert.True(test, errors.Is(err, ErrBadKeyEncoding))
+
+	_, err = UnmarshalKeyEnvelope(append([]byte("THSK"), 0xff, 0xff))
+	assert.True(test, errors.Is(err, ErrBadKeyEncoding))
+
+	newer := &KeyEnvelope{Version: EnvelopeVersion + 1, Scheme: "Mock", T: 1, N: 1, Key: []byte("pub")}
+	b, _ := newer.MarshalBinary()
+	_, err = UnmarshalKeyEnvelope(b)
+	assert.True(test, errors.Is(err, ErrUnsupported))
+
+	outOfRange := &KeyEnvelope{Version: EnvelopeVersion, Scheme: "Mock", T: 1, N: 1, Index: 2, Key: []byte("priv")}
+	b, _ = outOfRange.MarshalBinary()
+	_, err = UnmarshalKeyEnvelope(b)
+	assert.True(test, errors.Is(err, ErrBadKeyEncoding))
+}
+
+func aggregateWith(test *testing.T, h *handlerDecorator, req *pb.AggregateRequest) *pb.AggregateResponse {
+	resp := pb.AggregateResponse{}
+	reqBytes, _ := proto.Marshal(req)
+	respBytes, _ := h.Handle(reqBytes, int32(pb.Type_AGGREGATE_REQUEST))
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	return &resp
+}
+
+func TestAggregateValidatesEnvelope(test *testing.T) {
+	h := &handlerDecorator{mockSignerHandler{}, &SignerProcessor{}}
+
+	env, _ := NewKeyEnvelope("Mock", 3, 5, 0, "", mockKey("pub"))
+	pub, _ := env.MarshalBinary()
+
+	//t and n of a key the node does not hold must be set
+	resp := aggregateWith(test, h, &pb.AggregateRequest{Share: [][]byte{[]byte("s")}, PubKey: pub})
+	assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, resp.ErrorCode)
+
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: [][]byte{[]byte("s")}, PubKey: pub, T: 3, N: 5})
+	assert.Equal(test, pb.AggregateResponse_OK, resp.Status)
+
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: [][]byte{[]byte("s")}, PubKey: pub, T: 2, N: 5})
+	assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, resp.ErrorCode)
+
+	other, _ := NewKeyEnvelope("Other", 3, 5, 0, "", mockKey("pub"))
+	pub, _ = other.MarshalBinary()
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: [][]byte{[]byte("s")}, PubKey: pub, T: 3, N: 5})
+	assert.Equal(test, pb.ErrorCode_BAD_KEY_ENCODING, resp.ErrorCode)
+
+	share, _ := NewKeyEnvelope("Mock", 3, 5, 1, "", mockKey("priv"))
+	pub, _ = share.MarshalBinary()
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: [][]byte{[]byte("s")}, PubKey: pub, T: 3, N: 5})
+	assert.Equal(test, pb.ErrorCode_BAD_KEY_ENCODING, resp.ErrorCode)
+}
+
+//thresholdEchoHandler aggregates into the threshold it was given
+type thresholdEchoHandler struct {
+	mockSignerHandler
+}
+
+func (m thresholdEchoHandler) Aggregate(share [][]byte, digest []byte, key PublicKey, t, n int) ([]byte, error) {
+	return []byte(fmt.Sprintf("%v-of-%v", t, n)), nil
+}
+
+func TestAggregateUsesStoredThreshold(test *testing.T) {
+	stored, _ := NewKeyEnvelope("Mock", 3, 5, 0, "Mock_5_3", mockKey("pub"))
+	storedBytes, _ := stored.MarshalBinary()
+	h := &handlerDecorator{thresholdEchoHandler{}, NewSignerProcessor("tcp://127.0.0.1:9000", WithKeyStore(memKeyStore{"Mock_5_3": storedBytes}))}
+
+	resp := aggregateWith(test, h, &pb.AggregateRequest{Share: [][]byte{[]byte("s")}, PubKey: storedBytes})
+	require.Equal(test, pb.AggregateResponse_OK, resp.Status)
+	assert.Equal(test, []byte("3-of-5"), resp.Signature)
+
+	//the threshold of a key held by the node cannot be lowered by the client
+	forged, _ := NewKeyEnvelope("Mock", 1, 5, 0, "Mock_5_3", mockKey("pub"))
+	forgedBytes, _ := forged.MarshalBinary()
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: [][]byte{[]byte("s")}, PubKey: forgedBytes})
+	require.Equal(test, pb.AggregateResponse_OK, resp.Status)
+	assert.Equal(test, []byte("3-of-5"), resp.Signature)
+
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: [][]byte{[]byte("s")}, PubKey: forgedBytes, T: 1, N: 5})
+	assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, resp.ErrorCode)
+
+	//nor can another key be passed off as it
+	other, _ := NewKeyEnvelope("Mock", 1, 5, 0, "Mock_5_3", mockKey("other"))
+	otherBytes, _ := other.MarshalBinary()
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: [][]byte{[]byte("s")}, PubKey: otherBytes})
+	assert.Equal(test, pb.ErrorCode_BAD_KEY_ENCODING, resp.ErrorCode)
+
+	//the threshold of keys the node does not hold is not taken from their envelope
+	unknown, _ := NewKeyEnvelope("Mock", 2, 4, 0, "Mock_4_2", mockKey("pub"))
+	unknownBytes, _ := unknown.MarshalBinary()
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: [][]byte{[]byte("s")}, PubKey: unknownBytes})
+	assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, resp.ErrorCode)
+
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: [][]byte{[]byte("s")}, PubKey: unknownBytes, T: 1, N: 4})
+	assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, resp.ErrorCode)
+
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: [][]byte{[]byte("s")}, PubKey: unknownBytes, T: 2, N: 4})
+	require.Equal(test, pb.AggregateResponse_OK, resp.Status)
+	assert.Equal(test, []byte("2-of-4"), resp.Signature)
+}
+
+func TestSignByKeyIDValidatesEnvelope(test *testing.T) {
+	share, _ := NewKeyEnvelope("Mock", 3, 5, 2, "Mock_5_3", mockKey("share"))
+	b, _ := share.MarshalBinary()
+	store := memKeyStore{"Mock_5_3.2": b, "Mock_5_3.1": b}
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithKeyStore(store))
+
+	resp := signWith(test, p, &pb.SignRequest{Digest: []byte("msg"), KeyId: "Mock_5_3.2"})
+	require.Equal(test, pb.SignResponse_OK, resp.Status)
+	assert.Equal(test, []byte("share"), resp.Signature)
+
+	resp = signWith(test, p, &pb.SignRequest{Digest: []byte("msg"), KeyId: "Mock_5_3.1"})
+	assert.Equal(test, pb.ErrorCode_BAD_KEY_ENCODING, resp.ErrorCode)
+}
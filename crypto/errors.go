@@ -0,0 +1,102 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+)
+
+//Errors returned by handlers should wrap one of these
+//so the processor can answer with a precise pb.ErrorCode
+var (
+	ErrMalformedRequest = errors.New("malformed request")
+	ErrBadKeyEncoding   = errors.New("bad key encoding")
+	ErrNotEnoughShares  = errors.New("not enough shares")
+	ErrInvalidShare     = errors.New("invalid share")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrUnsupported      = errors.New("unsupported operation")
+	ErrInternal         = errors.New("internal error")
+	ErrKeyNotFound      = errors.New("key not found")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrKeyExists        = errors.New("key already exists")
+	ErrInvalidProof     = errors.New("invalid proof of possession")
+	ErrOverloaded       = errors.New("signer node overloaded")
+
+	//ErrDeadlineExceeded is context.DeadlineExceeded, so handlers
+	//honouring a context need not wrap the error they return
+	ErrDeadlineExceeded = context.DeadlineExceeded
+)
+
+var codeErrors = []struct {
+	code pb.ErrorCode
+	err  error
+}{
+	{pb.ErrorCode_MALFORMED_REQUEST, ErrMalformedRequest},
+	{pb.ErrorCode_BAD_KEY_ENCODING, ErrBadKeyEncoding},
+	{pb.ErrorCode_NOT_ENOUGH_SHARES, ErrNotEnoughShares},
+	{pb.ErrorCode_INVALID_SHARE, ErrInvalidShare},
+	{pb.ErrorCode_INVALID_SIGNATURE, ErrInvalidSignature},
+	{pb.ErrorCode_UNSUPPORTED, ErrUnsupported},
+	{pb.ErrorCode_INTERNAL, ErrInternal},
+	{pb.ErrorCode_KEY_NOT_FOUND, ErrKeyNotFound},
+	{pb.ErrorCode_PERMISSION_DENIED, ErrPermissionDenied},
+	{pb.ErrorCode_KEY_EXISTS, ErrKeyExists},
+	{pb.ErrorCode_INVALID_PROOF, ErrInvalidProof},
+	{pb.ErrorCode_OVERLOADED, ErrOverloaded},
+	{pb.ErrorCode_DEADLINE_EXCEEDED, ErrDeadlineExceeded},
+}
+
+func sentinelOf(code pb.ErrorCode) error {
+	for _, v := range codeErrors {
+		if v.code == code {
+			return v.err
+		}
+	}
+	return nil
+}
+
+//Error is the error carried by a protocol response.
+//It unwraps to the sentinel error matching its code,
+//so callers can use errors.Is(err, crypto.ErrInvalidShare).
+type Error struct {
+	Code    pb.ErrorCode
+	Message string
+}
+
+func NewError(code pb.ErrorCode, message string) *Error {
+	return &Error{code, message}
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	if err := sentinelOf(e.Code); err != nil {
+		return err.Error()
+	}
+
+	return e.Code.String()
+}
+
+func (e *Error) Unwrap() error {
+	return sentinelOf(e.Code)
+}
+
+//CodeOf returns the pb.ErrorCode describing err,
+//or fallback if err does not wrap any known error.
+func CodeOf(err error, fallback pb.ErrorCode) pb.ErrorCode {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+
+	for _, v := range codeErrors {
+		if errors.Is(err, v.err) {
+			return v.code
+		}
+	}
+
+	return fallback
+}
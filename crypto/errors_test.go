@@ -0,0 +1,107 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type mockKey []byte
+
+func (m mockKey) MarshalBinary() (data []byte, err error) {
+	return m, nil
+}
+
+//This mock fails to aggregate when t > n
+//and to verify when the signature is empty
+type mockSignerHandler struct{}
+
+func (m mockSignerHandler) Gen(n int, t int) (PublicKey, PrivateKeyList, error) {
+	return mockKey("pub"), PrivateKeyList{mockKey("priv")}, nil
+}
+
+func (m mockSignerHandler) Sign(digest []byte, key PrivateKey) ([]byte, error) {
+	return digest, nil
+}
+
+func (m mockSignerHandler) Verify(signature []byte, msg []byte, key PublicKey) error {
+	if len(signature) == 0 {
+		return errors.New("empty signature")
+	}
+	return nil
+}
+
+func (m mockSignerHandler) Aggregate(share [][]byte, digest []byte, key PublicKey, t, n int) ([]byte, error) {
+	if t > n {
+		return nil, fmt.Errorf("%w: t > n", ErrNotEnoughShares)
+	}
+	return digest, nil
+}
+
+func (m mockSignerHandler) SchemeName() string {
+	return "Mock"
+}
+
+func (m mockSignerHandler) UnmarshalPublic(data []byte) (PublicKey, error) {
+	return mockKey(data), nil
+}
+
+func (m mockSignerHandler) UnmarshalPrivate(data []byte) (PrivateKey, error) {
+	return mockKey(data), nil
+}
+
+func TestErrorIsSentinel(test *testing.T) {
+	err := error(NewError(pb.ErrorCode_INVALID_SHARE, "share 3 is invalid"))
+
+	assert.True(test, errors.Is(err, ErrInvalidShare))
+	assert.False(test, errors.Is(err, ErrInternal))
+	assert.Equal(test, "share 3 is invalid", err.Error())
+	assert.Equal(test, ErrBadKeyEncoding.Error(), NewError(pb.ErrorCode_BAD_KEY_ENCODING, "").Error())
+}
+
+func TestCodeOf(test *testing.T) {
+	assert.Equal(test, pb.ErrorCode_NOT_ENOUGH_SHARES, CodeOf(fmt.Errorf("%w: 2 < 3", ErrNotEnoughShares), pb.ErrorCode_INTERNAL))
+	assert.Equal(test, pb.ErrorCode_UNSUPPORTED, CodeOf(NewError(pb.ErrorCode_UNSUPPORTED, ""), pb.ErrorCode_INTERNAL))
+	assert.Equal(test, pb.ErrorCode_INTERNAL, CodeOf(errors.New("boom"), pb.ErrorCode_INTERNAL))
+}
+
+func TestHandlerAggregateErrorCode(test *testing.T) {
+	h := handlerDecorator{mockSignerHandler{}, &SignerProcessor{}}
+
+	req, _ := proto.Marshal(&pb.AggregateRequest{Scheme: "Mock", T: 6, N: 5})
+	respBytes, respType := h.Handle(req, int32(pb.Type_AGGREGATE_REQUEST))
+	require.Equal(test, int32(pb.Type_AGGREGATE_RESPONSE), respType)
+
+	resp := pb.AggregateResponse{}
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	assert.Equal(test, pb.AggregateResponse_ERROR, resp.Status)
+	assert.Equal(test, pb.ErrorCode_NOT_ENOUGH_SHARES, resp.ErrorCode)
+	assert.NotEmpty(test, resp.ErrorMessage)
+}
+
+func TestHandlerVerifyErrorCode(test *testing.T) {
+	h := handlerDecorator{mockSignerHandler{}, &SignerProcessor{}}
+
+	req, _ := proto.Marshal(&pb.VerifyRequest{Scheme: "Mock"})
+	respBytes, _ := h.Handle(req, int32(pb.Type_VERIFY_REQUEST))
+
+	resp := pb.VerifyResponse{}
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	assert.Equal(test, pb.VerifyResponse_ERROR, resp.Status)
+	assert.Equal(test, pb.ErrorCode_INVALID_SIGNATURE, resp.ErrorCode)
+}
+
+func TestHandlerMalformedRequest(test *testing.T) {
+	h := handlerDecorator{mockSignerHandler{}, &SignerProcessor{}}
+
+	respBytes, _ := h.Handle([]byte{0xff, 0xff}, int32(pb.Type_SIGN_REQUEST))
+
+	resp := pb.SignResponse{}
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	assert.Equal(test, pb.SignResponse_ERROR, resp.Status)
+	assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, resp.ErrorCode)
+}
@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+//Fingerprinter is implemented by clients able to fingerprint
+//the public keys of their scheme
+type Fingerprinter interface {
+	Fingerprint(pub PublicKey) (string, error)
+}
+
+//Fingerprint returns the fingerprint of pub, a public key of scheme.
+//It is made of the name of the keys of the scheme, such as TBLS256 for
+//every TBLS256 aggregation strategy, and of the SHA-256 of that name and
+//of the key encoding: TBLS256:9f86d0...
+//A key in an envelope has the same fingerprint as its raw encoding,
+//named by the key tag the envelope carries.
+func Fingerprint(scheme string, pub PublicKey) (string, error) {
+	keyBytes, err := pub.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+
+	if !IsKeyEnvelope(keyBytes) {
+		return fingerprint(keyTag(scheme), keyBytes), nil
+	}
+
+	env, err := UnmarshalKeyEnvelope(keyBytes)
+	if err != nil {
+		return "", err
+	}
+
+	if env.IsShare() {
+		return "", fmt.Errorf("%w: expected a public key", ErrBadKeyEncoding)
+	}
+
+	tag := env.KeyTag
+	if tag == "" {
+		//envelopes written before key tags existed
+		tag = keyTag(env.Scheme)
+	}
+
+	if scheme != "" && !sharesKeys(env.Scheme, scheme) && keyTag(scheme) != tag {
+		return "", fmt.Errorf("%w: key of %v is not a %v key", ErrBadKeyEncoding, env.Scheme, scheme)
+	}
+
+	return fingerprint(tag, env.Key), nil
+}
+
+func fingerprint(tag string, keyBytes []byte) string {
+	h := sha256.New()
+	h.Write([]byte(tag))
+	h.Write([]byte{0})
+	h.Write(keyBytes)
+
+	return tag + ":" + hex.EncodeToString(h.Sum(nil))
+}
+
+//keyTag names the keys of scheme, leaving out how it aggregates
+//as that does not change its keys.
+//Schemes that are not registered are named as registered ones would be,
+//so processes without the handlers of a scheme agree on its key tag.
+func keyTag(scheme string) string {
+	if d, ok := DefaultRegistry.Describe(scheme); ok {
+		return Descriptor{Family: d.Family, KeySize: d.KeySize}.Name()
+	}
+
+	for _, suffix := range aggregationSuffixes {
+		if tag := strings.TrimSuffix(scheme, suffix); tag != scheme && tag != "" {
+			return tag
+		}
+	}
+
+	return scheme
+}
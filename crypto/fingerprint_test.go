@@ -0,0 +1,106 @@
+package crypto
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestFingerprint(test *testing.T) {
+	fp, err := Fingerprint("Mock", mockKey("pub"))
+	require.Nil(test, err)
+	assert.True(test, strings.HasPrefix(fp, "Mock:"))
+	assert.Len(test, fp, len("Mock:")+64)
+
+	again, err := Fingerprint("Mock", mockKey("pub"))
+	require.Nil(test, err)
+	assert.Equal(test, fp, again)
+
+	other, err := Fingerprint("Mock", mockKey("other"))
+	require.Nil(test, err)
+	assert.NotEqual(test, fp, other)
+
+	//the same encoding is a different key in another scheme
+	otherScheme, err := Fingerprint("Mock2", mockKey("pub"))
+	require.Nil(test, err)
+	assert.NotEqual(test, fp[len("Mock:"):], otherScheme[len("Mock2:"):])
+}
+
+func TestFingerprintOfEnvelope(test *testing.T) {
+	raw, err := Fingerprint("Mock", mockKey("pub"))
+	require.Nil(test, err)
+
+	pubEnv, shares, err := SealKeys("Mock", 2, 3, "Mock_3_2", mockKey("pub"), PrivateKeyList{mockKey("a"), mockKey("b"), mockKey("c")})
+	require.Nil(test, err)
+	assert.Equal(test, raw, pubEnv.Fingerprint)
+
+	fp, err := Fingerprint("Mock", pubEnv)
+	require.Nil(test, err)
+	assert.Equal(test, raw, fp)
+
+	fp, err = Fingerprint("", pubEnv)
+	require.Nil(test, err)
+	assert.Equal(test, raw, fp)
+
+	for _, share := range shares {
+		b, _ := share.MarshalBinary()
+		env, err := UnmarshalKeyEnvelope(b)
+		require.Nil(test, err)
+		assert.Equal(test, raw, env.Fingerprint)
+	}
+
+	_, err = Fingerprint("Mock", shares[0])
+	assert.True(test, errors.Is(err, ErrBadKeyEncoding))
+
+	_, err = Fingerprint("Other", pubEnv)
+	assert.True(test, errors.Is(err, ErrBadKeyEncoding))
+}
+
+func TestFingerprintIgnoresAggregation(test *testing.T) {
+	Register(Descriptor{"FP", 512, NormalAggregation}, namedFactory("FP512"))
+	Register(Descriptor{"FP", 512, OptimisticAggregation}, namedFactory("FP512Optimistic"))
+
+	fp, err := Fingerprint("FP512", mockKey("pub"))
+	require.Nil(test, err)
+	assert.True(test, strings.HasPrefix(fp, "FP512:"))
+
+	optimistic, err := Fingerprint("FP512Optimistic", mockKey("pub"))
+	require.Nil(test, err)
+	assert.Equal(test, fp, optimistic)
+}
+
+func TestFingerprintUsesEnvelopeKeyTag(test *testing.T) {
+	//keys of schemes that are not registered are named as registered ones
+	fp, err := Fingerprint("Sibling256Optimistic", mockKey("pub"))
+	require.Nil(test, err)
+	assert.True(test, strings.HasPrefix(fp, "Sibling256:"))
+
+	pessimistic, err := Fingerprint("Sibling256Pessimistic", mockKey("pub"))
+	require.Nil(test, err)
+	assert.Equal(test, fp, pessimistic)
+
+	pubEnv, err := NewKeyEnvelope("Sibling256Optimistic", 2, 3, 0, "Sibling256_3_2", mockKey("pub"))
+	require.Nil(test, err)
+	assert.Equal(test, "Sibling256", pubEnv.KeyTag)
+	assert.Equal(test, fp, pubEnv.Fingerprint)
+
+	//the tag recorded in the envelope is used whatever is registered here
+	pubEnv.KeyTag = "Generated"
+	b, _ := pubEnv.MarshalBinary()
+	env, err := UnmarshalKeyEnvelope(b)
+	require.Nil(test, err)
+	assert.Equal(test, "Generated", env.KeyTag)
+
+	tagged, err := Fingerprint("", env)
+	require.Nil(test, err)
+	assert.True(test, strings.HasPrefix(tagged, "Generated:"))
+
+	tagged, err = Fingerprint("GeneratedOptimistic", env)
+	require.Nil(test, err)
+	assert.True(test, strings.HasPrefix(tagged, "Generated:"))
+
+	_, err = Fingerprint("Other", env)
+	assert.True(test, errors.Is(err, ErrBadKeyEncoding))
+}
@@ -1,77 +1,475 @@
 package crypto
 
 import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
 	"github.com/golang/protobuf/proto"
 	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+	"os"
+	"runtime/debug"
+	"sync/atomic"
 )
 
 
 type handlerDecorator struct {
 	THSignerHandler
+	processor *SignerProcessor
 }
 
-func (h *handlerDecorator) Handle(msg []byte, msgType int32) ([]byte, int32) {
+func (h *handlerDecorator) Handle(msg []byte, msgType int32) (response []byte, respType int32) {
+	defer func() {
+		if r := recover(); r != nil {
+			response, respType = h.recoverPanic(r, pb.Type(msgType))
+		}
+	}()
+
+	if op, ok := operationOf(pb.Type(msgType)); ok && !Supports(h.THSignerHandler, op) {
+		logger.Warnf("Scheme %v does not support %v", h.SchemeName(), op)
+		err := NewError(pb.ErrorCode_UNSUPPORTED, fmt.Sprintf("%v does not support %v", h.SchemeName(), op))
+		return createUnsupportedMsg(h.SchemeName(), pb.Type(msgType), err), int32(pb.Type_UNSUPPORTED_RESPONSE)
+	}
+
+	req, ok := newRequest(pb.Type(msgType))
+	if !ok {
+		logger.Warnf("Unknown message type %v for scheme %v", msgType, h.SchemeName())
+		err := NewError(pb.ErrorCode_UNSUPPORTED, fmt.Sprintf("unknown message type %v", msgType))
+		return createUnsupportedMsg(h.SchemeName(), pb.Type(msgType), err), int32(pb.Type_UNSUPPORTED_RESPONSE)
+	}
+
+	if err := proto.Unmarshal(msg, req); err != nil {
+		logger.Warnf("Error unmarshalling %v", pb.Type(msgType))
+		response, responseType := createErrorMsg(h.SchemeName(), pb.Type(msgType), fmt.Errorf("%w: %v", ErrMalformedRequest, err))
+		return response, int32(responseType)
+	}
+
+	ctx, cancel := h.processor.requestContext(pb.Type(msgType), req)
+	defer cancel()
+
+	if _, ok := ctx.Deadline(); !ok {
+		return h.dispatch(ctx, req)
+	}
+
+	return h.dispatchWithin(ctx, req, pb.Type(msgType))
+}
+
+//request is a request served by the decorator
+type request interface {
+	proto.Message
+	GetDeadline() int64
+}
+
+//newRequest returns an empty request of type msgType
+func newRequest(msgType pb.Type) (request, bool) {
+	switch msgType {
+	case pb.Type_SIGN_REQUEST:
+		return &pb.SignRequest{}, true
+	case pb.Type_VERIFY_REQUEST:
+		return &pb.VerifyRequest{}, true
+	case pb.Type_AGGREGATE_REQUEST:
+		return &pb.AggregateRequest{}, true
+	case pb.Type_GENERATE_THS_REQUEST:
+		return &pb.GenerateTHSRequest{}, true
+	case pb.Type_EXPORT_SHARE_REQUEST:
+		return &pb.ExportShareRequest{}, true
+	case pb.Type_BATCH_SIGN_REQUEST:
+		return &pb.BatchSignRequest{}, true
+	case pb.Type_BATCH_VERIFY_REQUEST:
+		return &pb.BatchVerifyRequest{}, true
+	case pb.Type_BATCH_AGGREGATE_REQUEST:
+		return &pb.BatchAggregateRequest{}, true
+	default:
+		return nil, false
+	}
+}
+
+func (h *handlerDecorator) dispatch(ctx context.Context, req request) ([]byte, int32) {
 	var response []byte
 	var responseType pb.Type
-	switch pb.Type(msgType) {
-		case pb.Type_SIGN_REQUEST:
-			response,responseType =  h.sign(msg),pb.Type_SIGN_RESPONSE
-		case pb.Type_VERIFY_REQUEST:
-			response,responseType =  h.verify(msg),pb.Type_VERIFY_RESPONSE
-		case pb.Type_AGGREGATE_REQUEST:
-			response,responseType =  h.aggregate(msg),pb.Type_AGGREGATE_RESPONSE
-		case pb.Type_GENERATE_THS_REQUEST:
-			response,responseType =  h.generateTHS(msg),pb.Type_GENERATE_THS_RESPONSE
+
+	switch req := req.(type) {
+	case *pb.SignRequest:
+		response, responseType = h.sign(ctx, req), pb.Type_SIGN_RESPONSE
+	case *pb.VerifyRequest:
+		response, responseType = h.verify(ctx, req), pb.Type_VERIFY_RESPONSE
+	case *pb.AggregateRequest:
+		response, responseType = h.aggregate(ctx, req), pb.Type_AGGREGATE_RESPONSE
+	case *pb.GenerateTHSRequest:
+		response, responseType = h.generateTHS(ctx, req), pb.Type_GENERATE_THS_RESPONSE
+	case *pb.ExportShareRequest:
+		response, responseType = h.exportShare(req), pb.Type_EXPORT_SHARE_RESPONSE
+	case *pb.BatchSignRequest:
+		response, responseType = h.batchSign(ctx, req), pb.Type_BATCH_SIGN_RESPONSE
+	case *pb.BatchVerifyRequest:
+		response, responseType = h.batchVerify(ctx, req), pb.Type_BATCH_VERIFY_RESPONSE
+	case *pb.BatchAggregateRequest:
+		response, responseType = h.batchAggregate(ctx, req), pb.Type_BATCH_AGGREGATE_RESPONSE
+	}
+
+	return response, int32(responseType)
+}
+
+//States of a request served by dispatchWithin
+const (
+	dispatchRunning int32 = iota
+	dispatchFinished
+	dispatchAbandoned
+)
+
+//dispatchWithin serves req in the background and answers DEADLINE_EXCEEDED
+//as soon as ctx is done. The handler is given ctx through the Context
+//interfaces so it can give up on its own; handlers that do not keep running
+//and are counted as abandoned. Requests are refused with OVERLOADED while
+//too many of them are, so late handlers cannot pile up without bound.
+func (h *handlerDecorator) dispatchWithin(ctx context.Context, req request, msgType pb.Type) ([]byte, int32) {
+	if err := ctx.Err(); err != nil {
+		return h.deadlineExceeded(ctx, msgType)
+	}
+
+	if abandoned := h.processor.AbandonedRequests(); abandoned >= h.processor.maxAbandonedRequests() {
+		logger.Warnf("Refusing %v for scheme %v: %v abandoned requests still running", msgType, h.SchemeName(), abandoned)
+		err := fmt.Errorf("%w: %v abandoned requests still running", ErrOverloaded, abandoned)
+		response, responseType := createErrorMsg(h.SchemeName(), msgType, err)
+		return response, int32(responseType)
 	}
 
-	return response,int32(responseType)
+	type handled struct {
+		response []byte
+		respType int32
+	}
+
+	state := dispatchRunning
+	done := make(chan handled, 1)
+	go func() {
+		var r handled
+		defer func() {
+			if p := recover(); p != nil {
+				r.response, r.respType = h.recoverPanic(p, msgType)
+			}
+			if !atomic.CompareAndSwapInt32(&state, dispatchRunning, dispatchFinished) {
+				atomic.AddInt64(&h.processor.abandoned, -1)
+			}
+			done <- r
+		}()
+
+		r.response, r.respType = h.dispatch(ctx, req)
+	}()
+
+	select {
+	case r := <-done:
+		return r.response, r.respType
+	case <-ctx.Done():
+		//counted before the handler can see it abandoned and uncount it
+		atomic.AddInt64(&h.processor.abandoned, 1)
+		if !atomic.CompareAndSwapInt32(&state, dispatchRunning, dispatchAbandoned) {
+			atomic.AddInt64(&h.processor.abandoned, -1)
+		}
+		return h.deadlineExceeded(ctx, msgType)
+	}
+}
+
+func (h *handlerDecorator) deadlineExceeded(ctx context.Context, msgType pb.Type) ([]byte, int32) {
+	logger.Warnf("Aborting %v for scheme %v: %v", msgType, h.SchemeName(), ctx.Err())
+
+	err := fmt.Errorf("%w: %v gave up on %v", ctx.Err(), h.SchemeName(), msgType)
+	response, responseType := createErrorMsg(h.SchemeName(), msgType, err)
+
+	return response, int32(responseType)
+}
+
+//recoverPanic logs and counts a panic raised while handling a request
+//of type msgType and builds the error response sent in its place.
+func (h *handlerDecorator) recoverPanic(r interface{}, msgType pb.Type) ([]byte, int32) {
+	response, responseType := createErrorMsg(h.SchemeName(), msgType, h.panicError(r, msgType))
+
+	return response, int32(responseType)
+}
+
+//panicError logs and counts a panic raised while handling
+//a request of type msgType and returns the error to report.
+func (h *handlerDecorator) panicError(r interface{}, msgType pb.Type) error {
+	atomic.AddUint64(&h.processor.panics, 1)
+	logger.Errorf("Recovered from panic in scheme %v handling %v: %v\n%s",
+		h.SchemeName(), msgType, r, debug.Stack())
+
+	code := pb.ErrorCode_INTERNAL
+	if err, ok := r.(error); ok && errors.Is(err, ErrUnsupported) {
+		code = pb.ErrorCode_UNSUPPORTED
+	}
+
+	return NewError(code, fmt.Sprintf("%v failed while handling %v", h.SchemeName(), msgType))
+}
+
+//createErrorMsg builds the error response matching a request of type msgType
+func createErrorMsg(scheme string, msgType pb.Type, err error) ([]byte, pb.Type) {
+	switch msgType {
+	case pb.Type_SIGN_REQUEST:
+		return createsSignTHSErrorMsg(pb.ErrorCode_INTERNAL, err), pb.Type_SIGN_RESPONSE
+	case pb.Type_VERIFY_REQUEST:
+		return createsVerifyTHSErrorMsg(pb.ErrorCode_INTERNAL, err), pb.Type_VERIFY_RESPONSE
+	case pb.Type_AGGREGATE_REQUEST:
+		return createAggregateTHSErrorMsg(pb.ErrorCode_INTERNAL, err), pb.Type_AGGREGATE_RESPONSE
+	case pb.Type_GENERATE_THS_REQUEST:
+		return createGenTHSErrorMsg(pb.ErrorCode_INTERNAL, err), pb.Type_GENERATE_THS_RESPONSE
+	case pb.Type_EXPORT_SHARE_REQUEST:
+		return createExportShareErrorMsg(pb.ErrorCode_INTERNAL, err), pb.Type_EXPORT_SHARE_RESPONSE
+	case pb.Type_BATCH_SIGN_REQUEST:
+		return createBatchSignErrorMsg(pb.ErrorCode_INTERNAL, err), pb.Type_BATCH_SIGN_RESPONSE
+	case pb.Type_BATCH_VERIFY_REQUEST:
+		return createBatchVerifyErrorMsg(pb.ErrorCode_INTERNAL, err), pb.Type_BATCH_VERIFY_RESPONSE
+	case pb.Type_BATCH_AGGREGATE_REQUEST:
+		return createBatchAggregateErrorMsg(pb.ErrorCode_INTERNAL, err), pb.Type_BATCH_AGGREGATE_RESPONSE
+	default:
+		return createUnsupportedMsg(scheme, msgType, err), pb.Type_UNSUPPORTED_RESPONSE
+	}
+}
+
+//createUnsupportedMsg answers a request of type msgType that the scheme cannot serve
+func createUnsupportedMsg(scheme string, msgType pb.Type, err error) []byte {
+	resp := pb.UnsupportedResponse{
+		Scheme:       scheme,
+		RequestType:  msgType,
+		ErrorCode:    CodeOf(err, pb.ErrorCode_UNSUPPORTED),
+		ErrorMessage: err.Error(),
+	}
+
+	msgBytes, _ := proto.Marshal(&resp)
+
+	return msgBytes
 }
 
 func (h *handlerDecorator) Name() string {
 	return h.SchemeName()
 }
 
-func (h *handlerDecorator) generateTHS(msg []byte) []byte {
+func (h *handlerDecorator) generateTHS(ctx context.Context, req *pb.GenerateTHSRequest) []byte {
 	logger.Debugf("Generating THS keys")
-	req := pb.GenerateTHSRequest{}
-	err := proto.Unmarshal(msg, &req)
+
+	keyID, release, err := h.storedKeyID(req)
 
 	if err != nil {
-		logger.Warn("Ignoring message gen THS message")
-		return createGenTHSErrorMsg()
+		logger.Warnf("Refusing to store keys: %v", err)
+		return createGenTHSErrorMsg(pb.ErrorCode_MALFORMED_REQUEST, err)
 	}
 
-	pub, priv := h.Gen(int(req.N), int(req.T))
+	defer release()
 
-	pubBytes, err := pub.MarshalBinary()
+	pub, priv, err := GenContext(ctx, h.THSignerHandler, int(req.N), int(req.T))
 
 	if err != nil {
-		logger.Warn("Error marshalling pubkey")
-		return createGenTHSErrorMsg()
+		logger.Warnf("Error generating keys: %v", err)
+		return createGenTHSErrorMsg(pb.ErrorCode_INTERNAL, err)
 	}
 
-	privBytes, err := priv.MarshalBinary()
+	pubEnv, priv, err := h.sealKeys(req, keyID, pub, priv)
+
+	if err != nil {
+		logger.Warnf("Error sealing keys: %v", err)
+		return createGenTHSErrorMsg(pb.ErrorCode_INTERNAL, err)
+	}
+
+	logger.Debugf("Generated key %v", pubEnv.Fingerprint)
+
+	pubBytes, err := pubEnv.MarshalBinary()
+
+	if err != nil {
+		logger.Warn("Error marshalling pubkey")
+		return createGenTHSErrorMsg(pb.ErrorCode_INTERNAL, err)
+	}
 
 	resp := pb.GenerateTHSResponse{
-		Status:      pb.GenerateTHSResponse_OK,
-		PublicKey:   pubBytes,
-		PrivateKeys: privBytes,
+		Status:    pb.GenerateTHSResponse_OK,
+		PublicKey: pubBytes,
+	}
+
+	if req.Store {
+		if err := ctx.Err(); err != nil {
+			logger.Warnf("Not storing keys %v: %v", keyID, err)
+			return createGenTHSErrorMsg(pb.ErrorCode_INTERNAL, err)
+		}
+
+		err = h.storeKeys(keyID, int(req.N), pubEnv, priv)
+
+		if err != nil {
+			logger.Warnf("Error storing keys %v: %v", keyID, err)
+			return createGenTHSErrorMsg(pb.ErrorCode_INTERNAL, err)
+		}
+
+		logger.Infof("Stored %v shares of %v (%v)", len(priv), keyID, pubEnv.Fingerprint)
+		resp.KeyId = keyID
+	} else {
+		resp.PrivateKeys, err = priv.MarshalBinary()
+
+		if err != nil {
+			logger.Warn("Error marshalling private keys")
+			return createGenTHSErrorMsg(pb.ErrorCode_INTERNAL, err)
+		}
 	}
 
 	msgBytes, err := proto.Marshal(&resp)
 
 	if err != nil {
 		logger.Warn("Error marshalling answer")
-		return createGenTHSErrorMsg()
+		return createGenTHSErrorMsg(pb.ErrorCode_INTERNAL, err)
 	}
 
 	logger.Debugf("Finished Generating THS keys")
 	return msgBytes
 }
 
-func createGenTHSErrorMsg() []byte {
+//storedKeyID returns the id under which the keys asked by req
+//are stored, or an empty id if they are returned to the caller.
+//The names of the keys are reserved until release is called, so they
+//are neither stored keys nor written by another generation meanwhile.
+func (h *handlerDecorator) storedKeyID(req *pb.GenerateTHSRequest) (keyID string, release func(), err error) {
+	if !req.Store {
+		if req.KeyId != "" {
+			return "", nil, fmt.Errorf("%w: key id given without storing the keys", ErrMalformedRequest)
+		}
+		return "", func() {}, nil
+	}
+
+	if h.processor.keys == nil {
+		return "", nil, fmt.Errorf("%w: signer node has no keychain", ErrUnsupported)
+	}
+
+	keyID = req.KeyId
+	if keyID == "" {
+		keyID, err = newKeyID(h.SchemeName(), int(req.N), int(req.T))
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	if err := validateKeyID(keyID); err != nil {
+		return "", nil, err
+	}
+
+	release, err = h.processor.reserveKeys(keyNames(keyID, int(req.N)))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return keyID, release, nil
+}
+
+//keyNames are the names the public key and the n shares
+//of the keys stored under keyID are written to
+func keyNames(keyID string, n int) []string {
+	if n < 1 {
+		//schemes without a threshold generate a single key
+		n = 1
+	}
+
+	names := []string{keyID}
+	for i := 1; i <= n; i++ {
+		names = append(names, string(ShareKeyID(KeyID(keyID), i)))
+	}
+	return names
+}
+
+//reserveKeys reserves names for a key generation,
+//failing if any of them is reserved or names a stored key
+func (self *SignerProcessor) reserveKeys(names []string) (release func(), err error) {
+	self.reserving.Lock()
+	defer self.reserving.Unlock()
+
+	for _, name := range names {
+		if self.reserved[name] {
+			return nil, fmt.Errorf("%w: %v is being generated", ErrKeyExists, name)
+		}
+
+		if err := self.keyNameFree(name); err != nil {
+			return nil, err
+		}
+	}
+
+	if self.reserved == nil {
+		self.reserved = make(map[string]bool)
+	}
+	for _, name := range names {
+		self.reserved[name] = true
+	}
+
+	return func() {
+		self.reserving.Lock()
+		defer self.reserving.Unlock()
+
+		for _, name := range names {
+			delete(self.reserved, name)
+		}
+	}, nil
+}
+
+//keyNameFree fails unless no public or private key is stored under name
+func (self *SignerProcessor) keyNameFree(name string) error {
+	_, err := self.keys.LoadPublicKey(name)
+	if err == nil {
+		return fmt.Errorf("%w: %v", ErrKeyExists, name)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: checking %v: %v", ErrInternal, name, err)
+	}
+
+	_, err = self.keys.LoadPrivateKey(name)
+	if err == nil {
+		return fmt.Errorf("%w: %v", ErrKeyExists, name)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: checking %v: %v", ErrInternal, name, err)
+	}
+
+	return nil
+}
+
+//storeKeys stores every share in the processor keychain, under the names
+//reserved for n shares. The public key is stored last so it is only present
+//once all shares are, and the shares are deleted if it cannot be stored.
+func (h *handlerDecorator) storeKeys(keyID string, n int, pub PublicKey, priv PrivateKeyList) (err error) {
+	if len(priv) > len(keyNames(keyID, n))-1 {
+		return fmt.Errorf("%w: generated %v shares instead of %v", ErrInternal, len(priv), n)
+	}
+
+	stored := make([]string, 0, len(priv))
+	defer func() {
+		if err != nil {
+			h.deleteKeys(stored)
+		}
+	}()
+
+	for i, share := range priv {
+		name := string(ShareKeyID(KeyID(keyID), i+1))
+		if err := h.processor.keys.StorePrivateKey(name, share); err != nil {
+			return err
+		}
+		stored = append(stored, name)
+	}
+
+	return h.processor.keys.StorePublicKey(keyID, pub)
+}
+
+//deleteKeys removes the shares stored by a failed key generation,
+//if the keychain can delete keys
+func (h *handlerDecorator) deleteKeys(names []string) {
+	deleter, ok := h.processor.keys.(KeyDeleter)
+	if !ok {
+		logger.Warnf("Cannot delete the shares %v of a failed key generation", names)
+		return
+	}
+
+	for _, name := range names {
+		if err := deleter.Delete(name); err != nil {
+			logger.Warnf("Error deleting share %v of a failed key generation: %v", name, err)
+		}
+	}
+}
+
+func createGenTHSErrorMsg(code pb.ErrorCode, err error) []byte {
 	resp := pb.GenerateTHSResponse{
-		Status: pb.GenerateTHSResponse_ERROR,
+		Status:       pb.GenerateTHSResponse_ERROR,
+		ErrorCode:    CodeOf(err, code),
+		ErrorMessage: err.Error(),
 	}
 
 	msgBytes, _ := proto.Marshal(&resp)
@@ -79,133 +477,296 @@ func createGenTHSErrorMsg() []byte {
 	return msgBytes
 }
 
-func (h *handlerDecorator)  aggregate(msg []byte) []byte {
-	req := pb.AggregateRequest{}
-	err := proto.Unmarshal(msg, &req)
+func (h *handlerDecorator) aggregate(ctx context.Context, req *pb.AggregateRequest) []byte {
 	logger.Debug("Start Aggregating")
+
+	msgBytes, err := proto.Marshal(h.aggregateItem(ctx, req))
+
+	if err != nil {
+		logger.Warn("Error marshalling answer")
+		return createAggregateTHSErrorMsg(pb.ErrorCode_INTERNAL, err)
+	}
+
+	logger.Debug("End Aggregating")
+
+	return msgBytes
+}
+
+//aggregateItem aggregates the shares of a single request
+func (h *handlerDecorator) aggregateItem(parent context.Context, req *pb.AggregateRequest) *pb.AggregateResponse {
+	ctx, cancel := withRequestDeadline(parent, req.Deadline)
+	defer cancel()
+
+	if len(req.PubKeys) > 0 {
+		return h.aggregateMulti(ctx, req)
+	}
+
+	pubKey, env, err := h.publicKey(req.PubKey)
+
+	stored := false
+	if err == nil {
+		env, stored, err = h.storedEnvelope(env)
+	}
+
 	if err != nil {
-		logger.Warn("Error unmarshalling request")
-		return createAggregateTHSErrorMsg()
+		logger.Warnf("Error unmarshalling public key: %v", err)
+		return aggregateError(pb.ErrorCode_BAD_KEY_ENCODING, err)
 	}
 
-	pubKey := h.UnmarshalPublic(req.PubKey)
+	t, n, err := thresholdOf(req, env, stored)
+
+	if err != nil {
+		logger.Warnf("Refusing to aggregate: %v", err)
+		return aggregateError(pb.ErrorCode_MALFORMED_REQUEST, err)
+	}
 
-	sig, err := h.Aggregate(req.Share, req.Digest, pubKey, int(req.T), int(req.N))
+	sig, err := AggregateContext(ctx, h.THSignerHandler, req.Share, req.Digest, pubKey, t, n)
 
 	if err != nil {
-		logger.Warn("Error generating aggregated signature")
-		return createAggregateTHSErrorMsg()
+		logger.Warnf("Error generating aggregated signature: %v", err)
+		return aggregateError(pb.ErrorCode_INVALID_SHARE, err)
 	}
 
-	resp := pb.AggregateResponse{
+	return &pb.AggregateResponse{
 		Status:    pb.AggregateResponse_OK,
 		Signature: sig,
 	}
+}
 
-	msgBytes, err := proto.Marshal(&resp)
+func aggregateError(code pb.ErrorCode, err error) *pb.AggregateResponse {
+	return &pb.AggregateResponse{
+		Status:       pb.AggregateResponse_ERROR,
+		ErrorCode:    CodeOf(err, code),
+		ErrorMessage: err.Error(),
+	}
+}
+
+func createAggregateTHSErrorMsg(code pb.ErrorCode, err error) []byte {
+	msgBytes, _ := proto.Marshal(aggregateError(code, err))
+
+	return msgBytes
+}
+
+func (h *handlerDecorator) verify(ctx context.Context, req *pb.VerifyRequest) []byte {
+	msgBytes, err := proto.Marshal(h.verifyItem(ctx, req))
 
 	if err != nil {
-		logger.Warn("Error marshalling answer")
-		return createAggregateTHSErrorMsg()
+		logger.Warn("Error marshalling response")
+		return createsVerifyTHSErrorMsg(pb.ErrorCode_INTERNAL, err)
 	}
 
-	logger.Debug("End Aggregating")
+	return msgBytes
+}
+
+//verifyItem verifies the signature of a single request
+func (h *handlerDecorator) verifyItem(parent context.Context, req *pb.VerifyRequest) *pb.VerifyResponse {
+	ctx, cancel := withRequestDeadline(parent, req.Deadline)
+	defer cancel()
+
+	if len(req.PubKeys) > 0 {
+		return h.verifyMulti(ctx, req)
+	}
+
+	pub, _, err := h.publicKey(req.PubKey)
+
+	if err != nil {
+		logger.Warnf("Error unmarshalling public key: %v", err)
+		return verifyError(pb.ErrorCode_BAD_KEY_ENCODING, err)
+	}
+
+	err = VerifyContext(ctx, h.THSignerHandler, req.Signature, req.Msg, pub)
+
+	if err != nil {
+		logger.Debugf("Invalid Signature: %v", err)
+		return verifyError(pb.ErrorCode_INVALID_SIGNATURE, err)
+	}
+
+	return &pb.VerifyResponse{
+		Status: pb.VerifyResponse_OK,
+	}
+}
+
+func verifyError(code pb.ErrorCode, err error) *pb.VerifyResponse {
+	return &pb.VerifyResponse{
+		Status:       pb.VerifyResponse_ERROR,
+		ErrorCode:    CodeOf(err, code),
+		ErrorMessage: err.Error(),
+	}
+}
+
+func createsVerifyTHSErrorMsg(code pb.ErrorCode, err error) []byte {
+	msgBytes, _ := proto.Marshal(verifyError(code, err))
 
 	return msgBytes
 }
 
-func createAggregateTHSErrorMsg() []byte {
-	resp := pb.AggregateResponse{
-		Status: pb.AggregateResponse_ERROR,
+func (h *handlerDecorator) sign(ctx context.Context, req *pb.SignRequest) []byte {
+	logger.Debug("Start Signing")
+
+	msgBytes, err := proto.Marshal(h.signItem(ctx, req))
+
+	if err != nil {
+		logger.Warn("Error marshalling msgBytes")
+		return createsSignTHSErrorMsg(pb.ErrorCode_INTERNAL, err)
 	}
 
-	msgBytes, _ := proto.Marshal(&resp)
+	logger.Debug("End signing")
 
 	return msgBytes
 }
 
-func (h *handlerDecorator) verify(msg []byte) []byte {
-	req := pb.VerifyRequest{}
-	err := proto.Unmarshal(msg, &req)
+//signItem signs the digest of a single request
+func (h *handlerDecorator) signItem(parent context.Context, req *pb.SignRequest) *pb.SignResponse {
+	ctx, cancel := withRequestDeadline(parent, req.Deadline)
+	defer cancel()
+
+	priv, err := h.signingKey(req)
 
 	if err != nil {
-		logger.Warn("Error marshalling pubkey")
-		return createsVerifyTHSErrorMsg()
+		logger.Warnf("Error loading private key: %v", err)
+		return signError(pb.ErrorCode_BAD_KEY_ENCODING, err)
 	}
 
-	pub := h.UnmarshalPublic(req.PubKey)
+	data, err := SignContext(ctx, h.THSignerHandler, req.Digest, priv)
 
-	err = h.Verify(req.Signature, req.Msg, pub)
+	if err != nil {
+		logger.Warnf("Error signing: %v", err)
+		return signError(pb.ErrorCode_INTERNAL, err)
+	}
 
+	return &pb.SignResponse{
+		Status:    pb.SignResponse_OK,
+		Signature: data,
+	}
+}
+
+//signingKey returns the private key a sign request asks for,
+//either loaded from the processor key store or sent in the request.
+func (h *handlerDecorator) signingKey(req *pb.SignRequest) (PrivateKey, error) {
+	if req.KeyId == "" {
+		if h.processor.rejectRawKeys {
+			return nil, fmt.Errorf("%w: raw private keys are disabled, use a key id", ErrPermissionDenied)
+		}
+		priv, _, err := h.privateKey(req.PrivateKeys)
+		return priv, err
+	}
+
+	if len(req.PrivateKeys) > 0 {
+		return nil, fmt.Errorf("%w: both a key id and a private key were sent", ErrMalformedRequest)
+	}
+
+	if err := validateKeyID(req.KeyId); err != nil {
+		return nil, err
+	}
+
+	keyBytes, err := h.loadPrivateKey(req.KeyId)
 	if err != nil {
-		logger.Debugf("Invalid Signature: %v",err)
-		return createsVerifyTHSErrorMsg()
+		return nil, err
 	}
 
-	resp := pb.VerifyResponse{
-		Status: pb.VerifyResponse_OK,
+	priv, env, err := h.privateKey(keyBytes)
+	if err != nil {
+		return nil, err
 	}
 
-	msgBytes, err := proto.Marshal(&resp)
+	if env != nil && !holdsShare(req.KeyId, env) {
+		return nil, fmt.Errorf("%w: %v holds share %v of %v", ErrBadKeyEncoding, req.KeyId, env.Index, env.KeyID)
+	}
+
+	return priv, nil
+}
+
+//holdsShare reports whether the share in env may be stored under id:
+//under the id of its key, as keygen gives each node its own share,
+//or under its share id, as a node generating the keys stores them all
+func holdsShare(id string, env *KeyEnvelope) bool {
+	return env.KeyID == "" || env.KeyID == id || string(ShareKeyID(KeyID(env.KeyID), env.Index)) == id
+}
+
+//loadPrivateKey reads the encoding of private key id from the processor keychain
+func (h *handlerDecorator) loadPrivateKey(id string) ([]byte, error) {
+	if h.processor.keys == nil {
+		return nil, fmt.Errorf("%w: signer node has no keychain", ErrKeyNotFound)
+	}
 
+	key, err := h.processor.keys.LoadPrivateKey(id)
 	if err != nil {
-		logger.Warn("Error marshalling response")
-		return createsVerifyTHSErrorMsg()
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %v", ErrKeyNotFound, id)
+		}
+		return nil, fmt.Errorf("%w: loading %v: %v", ErrInternal, id, err)
 	}
 
-	return msgBytes
+	keyBytes, err := key.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("%w: loading %v: %v", ErrInternal, id, err)
+	}
+
+	return keyBytes, nil
 }
 
-func createsVerifyTHSErrorMsg() []byte {
-	resp := pb.VerifyResponse{
-		Status: pb.VerifyResponse_ERROR,
+func signError(code pb.ErrorCode, err error) *pb.SignResponse {
+	return &pb.SignResponse{
+		Status:       pb.SignResponse_ERROR,
+		ErrorCode:    CodeOf(err, code),
+		ErrorMessage: err.Error(),
 	}
+}
 
-	msgBytes, _ := proto.Marshal(&resp)
+func createsSignTHSErrorMsg(code pb.ErrorCode, err error) []byte {
+	msgBytes, _ := proto.Marshal(signError(code, err))
 
 	return msgBytes
 }
 
-func (h *handlerDecorator)   sign(msg []byte) []byte {
-	req := pb.SignRequest{}
-	err := proto.Unmarshal(msg, &req)
-
-	logger.Debug("Start Signing")
+func (h *handlerDecorator) exportShare(req *pb.ExportShareRequest) []byte {
+	if len(h.processor.exportToken) == 0 {
+		logger.Warnf("Refused to export share %v of %v: export is disabled", req.Index, req.KeyId)
+		return createExportShareErrorMsg(pb.ErrorCode_PERMISSION_DENIED, fmt.Errorf("%w: share export is disabled", ErrPermissionDenied))
+	}
 
-	if err != nil {
-		logger.Warn("Ignoring sign message")
-		return createsSignTHSErrorMsg()
+	if subtle.ConstantTimeCompare(req.Token, h.processor.exportToken) != 1 {
+		logger.Warnf("Refused to export share %v of %v: invalid token", req.Index, req.KeyId)
+		return createExportShareErrorMsg(pb.ErrorCode_PERMISSION_DENIED, fmt.Errorf("%w: invalid export token", ErrPermissionDenied))
 	}
 
-	priv := h.UnmarshalPrivate(req.PrivateKeys)
+	if err := validateKeyID(req.KeyId); err != nil || req.Index == 0 {
+		return createExportShareErrorMsg(pb.ErrorCode_MALFORMED_REQUEST, fmt.Errorf("%w: invalid share %v of %q", ErrMalformedRequest, req.Index, req.KeyId))
+	}
 
-	data, err := h.Sign(req.Digest, priv)
+	keyBytes, err := h.loadPrivateKey(string(ShareKeyID(KeyID(req.KeyId), int(req.Index))))
 
 	if err != nil {
-		logger.Warn("Error marshalling pubkey")
-		return createsSignTHSErrorMsg()
+		logger.Warnf("Error loading share %v of %v: %v", req.Index, req.KeyId, err)
+		return createExportShareErrorMsg(pb.ErrorCode_INTERNAL, err)
 	}
 
-	resp := pb.SignResponse{
-		Status:    pb.SignResponse_OK,
-		Signature: data,
+	resp := pb.ExportShareResponse{
+		Status:     pb.ExportShareResponse_OK,
+		PrivateKey: keyBytes,
 	}
 
 	msgBytes, err := proto.Marshal(&resp)
 
 	if err != nil {
-		logger.Warn("Error marshalling msgBytes")
-		return createsSignTHSErrorMsg()
+		logger.Warn("Error marshalling response")
+		return createExportShareErrorMsg(pb.ErrorCode_INTERNAL, err)
 	}
 
-	logger.Debug("End signing")
+	if env, err := UnmarshalKeyEnvelope(keyBytes); err == nil {
+		logger.Infof("Exported share %v of %v (%v)", req.Index, req.KeyId, env.Fingerprint)
+	} else {
+		logger.Infof("Exported share %v of %v", req.Index, req.KeyId)
+	}
 
 	return msgBytes
 }
 
-func createsSignTHSErrorMsg() []byte {
-	resp := pb.SignResponse{
-		Status: pb.SignResponse_ERROR,
+func createExportShareErrorMsg(code pb.ErrorCode, err error) []byte {
+	resp := pb.ExportShareResponse{
+		Status:       pb.ExportShareResponse_ERROR,
+		ErrorCode:    CodeOf(err, code),
+		ErrorMessage: err.Error(),
 	}
 
 	msgBytes, _ := proto.Marshal(&resp)
@@ -0,0 +1,85 @@
+package crypto
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+//KeyStore is where a SignerProcessor loads the keys named by requests.
+//keychain.KeyChain satisfies it.
+type KeyStore interface {
+	LoadPrivateKey(name string) (PrivateKey, error)
+	LoadPublicKey(name string) (PublicKey, error)
+	StorePublicKey(name string, pub PublicKey) error
+	StorePrivateKey(name string, priv PrivateKey) error
+}
+
+//KeyDeleter is implemented by a KeyStore able to remove keys, so the
+//shares stored by a failed key generation are not left behind.
+//keychain.KeyChain satisfies it.
+type KeyDeleter interface {
+	Delete(name string) error
+}
+
+//KeyID references a key held by the signer node.
+//It can be given to a remote Signer instead of the private key,
+//so the key never leaves the node.
+type KeyID string
+
+//MarshalBinary always fails: a KeyID is only a reference and
+//cannot stand in for the key bytes.
+func (id KeyID) MarshalBinary() ([]byte, error) {
+	return nil, fmt.Errorf("%w: key %v is a reference held by the signer node", ErrUnsupported, string(id))
+}
+
+//ShareKeyID references share index (starting at 1)
+//of the keys the signer node generated and stored under id
+func ShareKeyID(id KeyID, index int) KeyID {
+	return KeyID(fmt.Sprintf("%v.%v", string(id), index))
+}
+
+//StoredKeyGenerator is implemented by a KeyShareGenerator able to
+//keep the generated shares in the signer node keychain.
+//Only the public key and the id of the stored keys are returned;
+//an empty id lets the signer node choose one.
+type StoredKeyGenerator interface {
+	GenStored(n int, t int, id KeyID) (PublicKey, KeyID, error)
+}
+
+//ShareExporter is implemented by a KeyShareGenerator able to export
+//a share stored by the signer node, given the node export token.
+type ShareExporter interface {
+	ExportShare(id KeyID, index int, token []byte) (PrivateKey, error)
+}
+
+//ContextStoredKeyGenerator is a StoredKeyGenerator that can be cancelled through ctx
+type ContextStoredKeyGenerator interface {
+	GenStoredContext(ctx context.Context, n int, t int, id KeyID) (PublicKey, KeyID, error)
+}
+
+//ContextShareExporter is a ShareExporter that can be cancelled through ctx
+type ContextShareExporter interface {
+	ExportShareContext(ctx context.Context, id KeyID, index int, token []byte) (PrivateKey, error)
+}
+
+//newKeyID returns a fresh id for keys of scheme
+func newKeyID(scheme string, n, t int) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInternal, err)
+	}
+	return fmt.Sprintf("%v_%v_%v_%v", scheme, n, t, hex.EncodeToString(b)), nil
+}
+
+//validateKeyID rejects ids that could name something
+//other than a key of the store, such as a previous version
+//of a key in a keychain, named id@version
+func validateKeyID(id string) error {
+	if id == "" || strings.ContainsAny(id, `/\@`) || strings.Contains(id, "..") {
+		return fmt.Errorf("%w: invalid key id %q", ErrMalformedRequest, id)
+	}
+	return nil
+}
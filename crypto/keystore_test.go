@@ -0,0 +1,270 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+//memKeyStore keeps private keys in memory
+type memKeyStore map[string][]byte
+
+func (m memKeyStore) LoadPrivateKey(name string) (PrivateKey, error) {
+	k, ok := m[name]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return mockKey(k), nil
+}
+
+func (m memKeyStore) LoadPublicKey(name string) (PublicKey, error) {
+	return m.LoadPrivateKey(name)
+}
+
+func (m memKeyStore) StorePublicKey(name string, pub PublicKey) error {
+	return m.StorePrivateKey(name, pub)
+}
+
+func (m memKeyStore) StorePrivateKey(name string, priv PrivateKey) error {
+	b, err := priv.MarshalBinary()
+	m[name] = b
+	return err
+}
+
+//keyEchoHandler signs by returning the key it was given
+type keyEchoHandler struct {
+	mockSignerHandler
+}
+
+func (m keyEchoHandler) Sign(digest []byte, key PrivateKey) ([]byte, error) {
+	return key.MarshalBinary()
+}
+
+func signWith(test *testing.T, p *SignerProcessor, req *pb.SignRequest) *pb.SignResponse {
+	h := handlerDecorator{keyEchoHandler{}, p}
+
+	reqBytes, _ := proto.Marshal(req)
+	respBytes, respType := h.Handle(reqBytes, int32(pb.Type_SIGN_REQUEST))
+	require.Equal(test, int32(pb.Type_SIGN_RESPONSE), respType)
+
+	resp := pb.SignResponse{}
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	return &resp
+}
+
+func TestSignByKeyID(test *testing.T) {
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithKeyStore(memKeyStore{"Mock_5_3": []byte("share")}))
+
+	resp := signWith(test, p, &pb.SignRequest{Scheme: "Mock", Digest: []byte("msg"), KeyId: "Mock_5_3"})
+	assert.Equal(test, pb.SignResponse_OK, resp.Status)
+	assert.Equal(test, []byte("share"), resp.Signature)
+
+	resp = signWith(test, p, &pb.SignRequest{Scheme: "Mock", Digest: []byte("msg"), KeyId: "Mock_7_4"})
+	assert.Equal(test, pb.SignResponse_ERROR, resp.Status)
+	assert.Equal(test, pb.ErrorCode_KEY_NOT_FOUND, resp.ErrorCode)
+}
+
+func TestSignBySealedKeyID(test *testing.T) {
+	_, shares, err := SealKeys("Mock", 2, 3, "Mock_3_2", mockKey("pub"), PrivateKeyList{mockKey("s1"), mockKey("s2"), mockKey("s3")})
+	require.Nil(test, err)
+
+	//each node holds its own share under the name of the key, as keygen stores them
+	for i, share := range shares {
+		store := memKeyStore{}
+		require.Nil(test, store.StorePrivateKey("Mock_3_2", share))
+		p := NewSignerProcessor("tcp://127.0.0.1:9000", WithKeyStore(store))
+
+		resp := signWith(test, p, &pb.SignRequest{Scheme: "Mock", Digest: []byte("msg"), KeyId: "Mock_3_2"})
+		require.Equal(test, pb.SignResponse_OK, resp.Status, resp.ErrorMessage)
+		assert.Equal(test, []byte(fmt.Sprintf("s%v", i+1)), resp.Signature)
+	}
+
+	//a node holding every share stores them under their share ids
+	store := memKeyStore{}
+	require.Nil(test, store.StorePrivateKey("Mock_3_2.2", shares[1]))
+	require.Nil(test, store.StorePrivateKey("Mock_3_2.3", shares[0]))
+	require.Nil(test, store.StorePrivateKey("Other_3_2", shares[0]))
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithKeyStore(store))
+
+	resp := signWith(test, p, &pb.SignRequest{Scheme: "Mock", Digest: []byte("msg"), KeyId: "Mock_3_2.2"})
+	require.Equal(test, pb.SignResponse_OK, resp.Status, resp.ErrorMessage)
+	assert.Equal(test, []byte("s2"), resp.Signature)
+
+	//shares stored under the name of another key or share are refused
+	resp = signWith(test, p, &pb.SignRequest{Scheme: "Mock", Digest: []byte("msg"), KeyId: "Mock_3_2.3"})
+	assert.Equal(test, pb.ErrorCode_BAD_KEY_ENCODING, resp.ErrorCode)
+	resp = signWith(test, p, &pb.SignRequest{Scheme: "Mock", Digest: []byte("msg"), KeyId: "Other_3_2"})
+	assert.Equal(test, pb.ErrorCode_BAD_KEY_ENCODING, resp.ErrorCode)
+}
+
+func TestSignByKeyIDRejectsInvalidRequests(test *testing.T) {
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithKeyStore(memKeyStore{}))
+
+	resp := signWith(test, p, &pb.SignRequest{KeyId: "../priv_Mock_5_3"})
+	assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, resp.ErrorCode)
+
+	//names of previous versions of keys in a keychain
+	resp = signWith(test, p, &pb.SignRequest{KeyId: "Mock_5_3@1"})
+	assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, resp.ErrorCode)
+
+	resp = signWith(test, p, &pb.SignRequest{KeyId: "Mock_5_3", PrivateKeys: []byte("share")})
+	assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, resp.ErrorCode)
+
+	resp = signWith(test, NewSignerProcessor("tcp://127.0.0.1:9000"), &pb.SignRequest{KeyId: "Mock_5_3"})
+	assert.Equal(test, pb.ErrorCode_KEY_NOT_FOUND, resp.ErrorCode)
+}
+
+func TestRawKeysDisabled(test *testing.T) {
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithRawKeys(false))
+
+	resp := signWith(test, p, &pb.SignRequest{Digest: []byte("msg"), PrivateKeys: []byte("share")})
+	assert.Equal(test, pb.SignResponse_ERROR, resp.Status)
+	assert.Equal(test, pb.ErrorCode_PERMISSION_DENIED, resp.ErrorCode)
+
+	resp = signWith(test, &SignerProcessor{}, &pb.SignRequest{Digest: []byte("msg"), PrivateKeys: []byte("share")})
+	assert.Equal(test, pb.SignResponse_OK, resp.Status)
+}
+
+func TestKeyIDCannotBeMarshalled(test *testing.T) {
+	_, err := KeyID("Mock_5_3").MarshalBinary()
+
+	assert.True(test, errors.Is(err, ErrUnsupported))
+}
+
+func handleWith(test *testing.T, p *SignerProcessor, req proto.Message, reqType pb.Type, resp proto.Message) {
+	h := handlerDecorator{keyEchoHandler{}, p}
+
+	reqBytes, _ := proto.Marshal(req)
+	respBytes, _ := h.Handle(reqBytes, int32(reqType))
+	require.Nil(test, proto.Unmarshal(respBytes, resp))
+}
+
+func TestGenerateStoredKeys(test *testing.T) {
+	store := memKeyStore{}
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithKeyStore(store))
+
+	resp := pb.GenerateTHSResponse{}
+	handleWith(test, p, &pb.GenerateTHSRequest{Scheme: "Mock", N: 1, T: 1, Store: true}, pb.Type_GENERATE_THS_REQUEST, &resp)
+	require.Equal(test, pb.GenerateTHSResponse_OK, resp.Status)
+	require.NotEmpty(test, resp.KeyId)
+	assert.Empty(test, resp.PrivateKeys)
+	assert.Equal(test, resp.PublicKey, store[resp.KeyId])
+
+	pub, err := UnmarshalKeyEnvelope(resp.PublicKey)
+	require.Nil(test, err)
+	assert.Equal(test, []byte("pub"), pub.Key)
+	assert.Equal(test, resp.KeyId, pub.KeyID)
+
+	share, err := UnmarshalKeyEnvelope(store[string(ShareKeyID(KeyID(resp.KeyId), 1))])
+	require.Nil(test, err)
+	assert.Equal(test, []byte("priv"), share.Key)
+	assert.Equal(test, 1, share.Index)
+
+	//the stored share can be used to sign
+	sign := signWith(test, p, &pb.SignRequest{Digest: []byte("msg"), KeyId: string(ShareKeyID(KeyID(resp.KeyId), 1))})
+	assert.Equal(test, []byte("priv"), sign.Signature)
+}
+
+func TestGenerateStoredKeysRefusesExistingKey(test *testing.T) {
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithKeyStore(memKeyStore{"Mock_1_1": []byte("pub")}))
+
+	resp := pb.GenerateTHSResponse{}
+	handleWith(test, p, &pb.GenerateTHSRequest{N: 1, T: 1, Store: true, KeyId: "Mock_1_1"}, pb.Type_GENERATE_THS_REQUEST, &resp)
+	assert.Equal(test, pb.GenerateTHSResponse_ERROR, resp.Status)
+	assert.Equal(test, pb.ErrorCode_KEY_EXISTS, resp.ErrorCode)
+}
+
+func TestGenerateStoredKeysRefusesExistingShare(test *testing.T) {
+	store := memKeyStore{"foo.1": []byte("left over")}
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithKeyStore(store))
+
+	resp := pb.GenerateTHSResponse{}
+	handleWith(test, p, &pb.GenerateTHSRequest{N: 1, T: 1, Store: true, KeyId: "foo"}, pb.Type_GENERATE_THS_REQUEST, &resp)
+	assert.Equal(test, pb.ErrorCode_KEY_EXISTS, resp.ErrorCode)
+	assert.Equal(test, []byte("left over"), store["foo.1"])
+	assert.NotContains(test, store, "foo")
+}
+
+func TestReserveKeysConflicts(test *testing.T) {
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithKeyStore(memKeyStore{}))
+
+	release, err := p.reserveKeys(keyNames("foo", 3))
+	require.Nil(test, err)
+
+	_, err = p.reserveKeys(keyNames("foo", 1))
+	assert.True(test, errors.Is(err, ErrKeyExists))
+
+	//foo.2 is a share of the key being generated
+	_, err = p.reserveKeys(keyNames("foo.2", 1))
+	assert.True(test, errors.Is(err, ErrKeyExists))
+
+	release()
+	_, err = p.reserveKeys(keyNames("foo", 1))
+	assert.Nil(test, err)
+}
+
+//failingPubStore fails to store public keys
+type failingPubStore struct {
+	memKeyStore
+}
+
+func (m failingPubStore) StorePublicKey(name string, pub PublicKey) error {
+	return errors.New("disk full")
+}
+
+func (m failingPubStore) Delete(name string) error {
+	delete(m.memKeyStore, name)
+	return nil
+}
+
+func TestGenerateStoredKeysRollsBackShares(test *testing.T) {
+	store := memKeyStore{}
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithKeyStore(failingPubStore{store}))
+
+	resp := pb.GenerateTHSResponse{}
+	handleWith(test, p, &pb.GenerateTHSRequest{N: 1, T: 1, Store: true, KeyId: "foo"}, pb.Type_GENERATE_THS_REQUEST, &resp)
+	assert.Equal(test, pb.GenerateTHSResponse_ERROR, resp.Status)
+	assert.Empty(test, store)
+
+	//the names are released once the generation fails
+	_, err := p.reserveKeys(keyNames("foo", 1))
+	assert.Nil(test, err)
+}
+
+func TestGenerateStoredKeysWithoutKeychain(test *testing.T) {
+	p := NewSignerProcessor("tcp://127.0.0.1:9000")
+
+	resp := pb.GenerateTHSResponse{}
+	handleWith(test, p, &pb.GenerateTHSRequest{N: 1, T: 1, Store: true}, pb.Type_GENERATE_THS_REQUEST, &resp)
+	assert.Equal(test, pb.ErrorCode_UNSUPPORTED, resp.ErrorCode)
+
+	handleWith(test, p, &pb.GenerateTHSRequest{N: 1, T: 1, KeyId: "Mock_1_1"}, pb.Type_GENERATE_THS_REQUEST, &resp)
+	assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, resp.ErrorCode)
+}
+
+func TestExportShare(test *testing.T) {
+	store := memKeyStore{"Mock_1_1.1": []byte("priv")}
+	token := []byte("secret")
+
+	resp := pb.ExportShareResponse{}
+	disabled := NewSignerProcessor("tcp://127.0.0.1:9000", WithKeyStore(store))
+	handleWith(test, disabled, &pb.ExportShareRequest{KeyId: "Mock_1_1", Index: 1, Token: token}, pb.Type_EXPORT_SHARE_REQUEST, &resp)
+	assert.Equal(test, pb.ErrorCode_PERMISSION_DENIED, resp.ErrorCode)
+
+	p := NewSignerProcessor("tcp://127.0.0.1:9000", WithKeyStore(store), WithExportToken(token))
+	handleWith(test, p, &pb.ExportShareRequest{KeyId: "Mock_1_1", Index: 1, Token: []byte("guess")}, pb.Type_EXPORT_SHARE_REQUEST, &resp)
+	assert.Equal(test, pb.ErrorCode_PERMISSION_DENIED, resp.ErrorCode)
+
+	handleWith(test, p, &pb.ExportShareRequest{KeyId: "Mock_1_1", Index: 2, Token: token}, pb.Type_EXPORT_SHARE_REQUEST, &resp)
+	assert.Equal(test, pb.ErrorCode_KEY_NOT_FOUND, resp.ErrorCode)
+
+	resp = pb.ExportShareResponse{}
+	handleWith(test, p, &pb.ExportShareRequest{KeyId: "Mock_1_1", Index: 1, Token: token}, pb.Type_EXPORT_SHARE_REQUEST, &resp)
+	require.Equal(test, pb.ExportShareResponse_OK, resp.Status)
+	assert.Equal(test, []byte("priv"), resp.PrivateKey)
+}
@@ -0,0 +1,104 @@
+package crypto
+
+import "fmt"
+
+//LegacyKeyShareGenerator is the KeyShareGenerator interface
+//used before key generation could report errors.
+type LegacyKeyShareGenerator interface {
+	Gen(n int, t int) (PublicKey, PrivateKeyList)
+}
+
+//LegacyTHSignerHandler is the THSignerHandler interface
+//used before key generation and decoding could report errors.
+type LegacyTHSignerHandler interface {
+	LegacyKeyShareGenerator
+	SignerVerifierAggregator
+	SchemeName() string
+	UnmarshalPublic(data []byte) PublicKey
+	UnmarshalPrivate(data []byte) PrivateKey
+}
+
+//AdaptLegacyKeyShareGenerator lets a generator written against the old
+//interface be used as a KeyShareGenerator. Panics and nil keys are
+//reported as errors.
+func AdaptLegacyKeyShareGenerator(gen LegacyKeyShareGenerator) KeyShareGenerator {
+	return legacyKeyShareGenerator{gen}
+}
+
+//AdaptLegacyHandler lets a handler written against the old interface
+//be added to a SignerProcessor while it is migrated. Panics and nil keys
+//are reported as errors.
+func AdaptLegacyHandler(handler LegacyTHSignerHandler) THSignerHandler {
+	return legacyHandler{handler, legacyKeyShareGenerator{handler}}
+}
+
+type legacyKeyShareGenerator struct {
+	gen LegacyKeyShareGenerator
+}
+
+func (l legacyKeyShareGenerator) Gen(n int, t int) (pub PublicKey, priv PrivateKeyList, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			pub, priv, err = nil, nil, fmt.Errorf("%w: key generation panicked: %v", ErrInternal, r)
+		}
+	}()
+
+	pub, priv = l.gen.Gen(n, t)
+
+	if pub == nil || priv == nil {
+		return nil, nil, fmt.Errorf("%w: key generation returned no keys", ErrInternal)
+	}
+
+	return pub, priv, nil
+}
+
+type legacyHandler struct {
+	handler LegacyTHSignerHandler
+	legacyKeyShareGenerator
+}
+
+func (l legacyHandler) Sign(digest []byte, key PrivateKey) ([]byte, error) {
+	return l.handler.Sign(digest, key)
+}
+
+func (l legacyHandler) Verify(signature []byte, msg []byte, key PublicKey) error {
+	return l.handler.Verify(signature, msg, key)
+}
+
+func (l legacyHandler) Aggregate(share [][]byte, digest []byte, key PublicKey, t, n int) ([]byte, error) {
+	return l.handler.Aggregate(share, digest, key, t, n)
+}
+
+func (l legacyHandler) SchemeName() string {
+	return l.handler.SchemeName()
+}
+
+func (l legacyHandler) UnmarshalPublic(data []byte) (pub PublicKey, err error) {
+	defer recoverKeyEncoding(&err)
+
+	pub = l.handler.UnmarshalPublic(data)
+
+	if pub == nil {
+		return nil, fmt.Errorf("%w: public key could not be decoded", ErrBadKeyEncoding)
+	}
+
+	return pub, nil
+}
+
+func (l legacyHandler) UnmarshalPrivate(data []byte) (priv PrivateKey, err error) {
+	defer recoverKeyEncoding(&err)
+
+	priv = l.handler.UnmarshalPrivate(data)
+
+	if priv == nil {
+		return nil, fmt.Errorf("%w: private key could not be decoded", ErrBadKeyEncoding)
+	}
+
+	return priv, nil
+}
+
+func recoverKeyEncoding(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("%w: %v", ErrBadKeyEncoding, r)
+	}
+}
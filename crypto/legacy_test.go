@@ -0,0 +1,61 @@
+package crypto
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+//legacyMockHandler behaves like the handlers written before
+//Gen and Unmarshal could return errors
+type legacyMockHandler struct {
+	mockSignerHandler
+}
+
+func (m legacyMockHandler) Gen(n int, t int) (PublicKey, PrivateKeyList) {
+	if t > n {
+		panic("invalid threshold")
+	}
+	return mockKey("pub"), PrivateKeyList{mockKey("priv")}
+}
+
+func (m legacyMockHandler) UnmarshalPublic(data []byte) PublicKey {
+	if len(data) == 0 {
+		return nil
+	}
+	return mockKey(data)
+}
+
+func (m legacyMockHandler) UnmarshalPrivate(data []byte) PrivateKey {
+	if len(data) == 0 {
+		panic("empty key")
+	}
+	return mockKey(data)
+}
+
+func TestAdaptLegacyHandler(test *testing.T) {
+	h := AdaptLegacyHandler(legacyMockHandler{})
+
+	assert.Equal(test, "Mock", h.SchemeName())
+
+	pub, priv, err := h.Gen(5, 3)
+	assert.Nil(test, err)
+	assert.Equal(test, mockKey("pub"), pub)
+	assert.Len(test, priv, 1)
+
+	_, _, err = h.Gen(3, 5)
+	assert.True(test, errors.Is(err, ErrInternal))
+
+	_, err = h.UnmarshalPublic(nil)
+	assert.True(test, errors.Is(err, ErrBadKeyEncoding))
+
+	_, err = h.UnmarshalPrivate(nil)
+	assert.True(test, errors.Is(err, ErrBadKeyEncoding))
+
+	key, err := h.UnmarshalPrivate([]byte("priv"))
+	assert.Nil(test, err)
+
+	sig, err := h.Sign([]byte("msg"), key)
+	assert.Nil(test, err)
+	assert.Equal(test, []byte("msg"), sig)
+}
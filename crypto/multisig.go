@@ -0,0 +1,205 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+)
+
+//MultiSignatureAggregator is implemented by handlers of schemes able to
+//aggregate the signatures of independent keys into one, such as BLS,
+//and by the remote contexts of such schemes.
+//Requests carrying several public keys are served through it, once
+//the keys proved their possession if the handler is a PossessionProver.
+//Remote contexts send the proofs of the keys that are a ProvenKey.
+type MultiSignatureAggregator interface {
+	//AggregateMulti aggregates the signatures of msg made by the keys of pubs,
+	//one signature by key in the same order
+	AggregateMulti(signatures [][]byte, msg []byte, pubs []PublicKey) (signature []byte, err error)
+	//VerifyMulti verifies a signature of msg aggregated by AggregateMulti
+	VerifyMulti(signature []byte, msg []byte, pubs []PublicKey) error
+	//AggregateDistinct aggregates the signatures of msgs[i] made by pubs[i].
+	//The messages must be distinct.
+	AggregateDistinct(signatures [][]byte, msgs [][]byte, pubs []PublicKey) (signature []byte, err error)
+	//VerifyDistinct verifies a signature aggregated by AggregateDistinct
+	VerifyDistinct(signature []byte, msgs [][]byte, pubs []PublicKey) error
+}
+
+//ContextMultiSignatureAggregator is a MultiSignatureAggregator
+//that can be cancelled through ctx
+type ContextMultiSignatureAggregator interface {
+	AggregateMultiContext(ctx context.Context, signatures [][]byte, msg []byte, pubs []PublicKey) (signature []byte, err error)
+	VerifyMultiContext(ctx context.Context, signature []byte, msg []byte, pubs []PublicKey) error
+	AggregateDistinctContext(ctx context.Context, signatures [][]byte, msgs [][]byte, pubs []PublicKey) (signature []byte, err error)
+	VerifyDistinctContext(ctx context.Context, signature []byte, msgs [][]byte, pubs []PublicKey) error
+}
+
+//CheckMultiSignature checks that a signature of a message by several keys
+//has keys, and a signature by key unless signatures is nil as they are
+//already aggregated
+func CheckMultiSignature(signatures [][]byte, pubs []PublicKey) error {
+	if len(pubs) == 0 {
+		return fmt.Errorf("%w: no public keys", ErrMalformedRequest)
+	}
+
+	if signatures != nil && len(signatures) != len(pubs) {
+		return fmt.Errorf("%w: %v signatures for %v public keys", ErrMalformedRequest, len(signatures), len(pubs))
+	}
+
+	return nil
+}
+
+//CheckDistinctSignature checks that a signature of distinct messages
+//by several keys also has a message by key
+func CheckDistinctSignature(signatures [][]byte, msgs [][]byte, pubs []PublicKey) error {
+	if err := CheckMultiSignature(signatures, pubs); err != nil {
+		return err
+	}
+
+	if len(msgs) != len(pubs) {
+		return fmt.Errorf("%w: %v messages for %v public keys", ErrMalformedRequest, len(msgs), len(pubs))
+	}
+
+	return nil
+}
+
+//multiSignatureAggregator returns the handler as a MultiSignatureAggregator
+func (h *handlerDecorator) multiSignatureAggregator() (MultiSignatureAggregator, error) {
+	m, ok := h.THSignerHandler.(MultiSignatureAggregator)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v does not aggregate signatures of independent keys", ErrUnsupported, h.SchemeName())
+	}
+	return m, nil
+}
+
+//publicKeys decodes the public keys of a multi-signature request,
+//along with the proofs of possession stored in their envelopes
+func (h *handlerDecorator) publicKeys(data [][]byte) ([]PublicKey, [][]byte, error) {
+	pubs := make([]PublicKey, 0, len(data))
+	proofs := make([][]byte, 0, len(data))
+	for i, keyBytes := range data {
+		pub, env, err := h.publicKey(keyBytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("public key %v: %w", i, err)
+		}
+		pubs = append(pubs, pub)
+
+		var proof []byte
+		if env != nil {
+			proof = env.Proof
+		}
+		proofs = append(proofs, proof)
+	}
+	return pubs, proofs, nil
+}
+
+//verifyMulti verifies a signature aggregated from the keys of req.PubKeys,
+//over req.Msg or over req.Msgs if they are set
+func (h *handlerDecorator) verifyMulti(ctx context.Context, req *pb.VerifyRequest) *pb.VerifyResponse {
+	m, err := h.multiSignatureAggregator()
+	if err != nil {
+		return verifyError(pb.ErrorCode_UNSUPPORTED, err)
+	}
+
+	if len(req.PubKey) > 0 || (len(req.Msgs) > 0 && len(req.Msg) > 0) {
+		return verifyError(pb.ErrorCode_MALFORMED_REQUEST, fmt.Errorf("%w: ambiguous multi-signature verification", ErrMalformedRequest))
+	}
+
+	pubs, stored, err := h.publicKeys(req.PubKeys)
+	if err != nil {
+		logger.Warnf("Error unmarshalling public keys: %v", err)
+		return verifyError(pb.ErrorCode_BAD_KEY_ENCODING, err)
+	}
+
+	if err := h.checkPossession(pubs, req.Proofs, stored); err != nil {
+		logger.Warnf("Refusing to verify: %v", err)
+		return verifyError(pb.ErrorCode_INVALID_PROOF, err)
+	}
+
+	if len(req.Msgs) > 0 {
+		err = CheckDistinctSignature(nil, req.Msgs, pubs)
+	} else {
+		err = CheckMultiSignature(nil, pubs)
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		return verifyError(pb.ErrorCode_MALFORMED_REQUEST, err)
+	}
+
+	if len(req.Msgs) > 0 {
+		err = m.VerifyDistinct(req.Signature, req.Msgs, pubs)
+	} else {
+		err = m.VerifyMulti(req.Signature, req.Msg, pubs)
+	}
+
+	if err != nil {
+		logger.Debugf("Invalid multi-signature: %v", err)
+		return verifyError(pb.ErrorCode_INVALID_SIGNATURE, err)
+	}
+
+	return &pb.VerifyResponse{
+		Status: pb.VerifyResponse_OK,
+	}
+}
+
+//aggregateMulti aggregates the signatures of the keys of req.PubKeys,
+//over req.Digest or over req.Msgs if they are set
+func (h *handlerDecorator) aggregateMulti(ctx context.Context, req *pb.AggregateRequest) *pb.AggregateResponse {
+	m, err := h.multiSignatureAggregator()
+	if err != nil {
+		return aggregateError(pb.ErrorCode_UNSUPPORTED, err)
+	}
+
+	if len(req.PubKey) > 0 || (len(req.Msgs) > 0 && len(req.Digest) > 0) {
+		return aggregateError(pb.ErrorCode_MALFORMED_REQUEST, fmt.Errorf("%w: ambiguous multi-signature aggregation", ErrMalformedRequest))
+	}
+
+	pubs, stored, err := h.publicKeys(req.PubKeys)
+	if err != nil {
+		logger.Warnf("Error unmarshalling public keys: %v", err)
+		return aggregateError(pb.ErrorCode_BAD_KEY_ENCODING, err)
+	}
+
+	if err := h.checkPossession(pubs, req.Proofs, stored); err != nil {
+		logger.Warnf("Refusing to aggregate: %v", err)
+		return aggregateError(pb.ErrorCode_INVALID_PROOF, err)
+	}
+
+	//shares are never aggregated already
+	shares := req.Share
+	if shares == nil {
+		shares = [][]byte{}
+	}
+
+	if len(req.Msgs) > 0 {
+		err = CheckDistinctSignature(shares, req.Msgs, pubs)
+	} else {
+		err = CheckMultiSignature(shares, pubs)
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		logger.Warnf("Refusing to aggregate: %v", err)
+		return aggregateError(pb.ErrorCode_MALFORMED_REQUEST, err)
+	}
+
+	var sig []byte
+	if len(req.Msgs) > 0 {
+		sig, err = m.AggregateDistinct(shares, req.Msgs, pubs)
+	} else {
+		sig, err = m.AggregateMulti(shares, req.Digest, pubs)
+	}
+
+	if err != nil {
+		logger.Warnf("Error generating aggregated signature: %v", err)
+		return aggregateError(pb.ErrorCode_INVALID_SHARE, err)
+	}
+
+	return &pb.AggregateResponse{
+		Status:    pb.AggregateResponse_OK,
+		Signature: sig,
+	}
+}
@@ -0,0 +1,121 @@
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"github.com/golang/protobuf/proto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+//This mock aggregates signatures by concatenating them,
+//and aggregates signatures of distinct messages into "distinct"
+type multiSignerHandler struct {
+	mockSignerHandler
+}
+
+func (m multiSignerHandler) AggregateMulti(signatures [][]byte, msg []byte, pubs []PublicKey) ([]byte, error) {
+	return bytes.Join(signatures, nil), nil
+}
+
+func (m multiSignerHandler) VerifyMulti(signature []byte, msg []byte, pubs []PublicKey) error {
+	if len(signature) == 0 {
+		return errors.New("empty signature")
+	}
+	return nil
+}
+
+func (m multiSignerHandler) AggregateDistinct(signatures [][]byte, msgs [][]byte, pubs []PublicKey) ([]byte, error) {
+	return []byte("distinct"), nil
+}
+
+func (m multiSignerHandler) VerifyDistinct(signature []byte, msgs [][]byte, pubs []PublicKey) error {
+	if string(signature) != "distinct" {
+		return errors.New("not a signature of distinct messages")
+	}
+	return nil
+}
+
+func verifyWith(test *testing.T, h *handlerDecorator, req *pb.VerifyRequest) *pb.VerifyResponse {
+	resp := pb.VerifyResponse{}
+	reqBytes, _ := proto.Marshal(req)
+	respBytes, _ := h.Handle(reqBytes, int32(pb.Type_VERIFY_REQUEST))
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	return &resp
+}
+
+func TestAggregateMultiSignature(test *testing.T) {
+	h := &handlerDecorator{multiSignerHandler{}, &SignerProcessor{}}
+	pubs := [][]byte{[]byte("pub1"), []byte("pub2")}
+	shares := [][]byte{[]byte("s1"), []byte("s2")}
+
+	resp := aggregateWith(test, h, &pb.AggregateRequest{Share: shares, Digest: []byte("msg"), PubKeys: pubs})
+	require.Equal(test, pb.AggregateResponse_OK, resp.Status)
+	assert.Equal(test, []byte("s1s2"), resp.Signature)
+
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: shares, Msgs: [][]byte{[]byte("m1"), []byte("m2")}, PubKeys: pubs})
+	require.Equal(test, pb.AggregateResponse_OK, resp.Status)
+	assert.Equal(test, []byte("distinct"), resp.Signature)
+
+	//a signature by key, a message by key
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: shares[:1], Digest: []byte("msg"), PubKeys: pubs})
+	assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, resp.ErrorCode)
+
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: shares, Msgs: [][]byte{[]byte("m1")}, PubKeys: pubs})
+	assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, resp.ErrorCode)
+
+	//either a single key or several
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: shares, Digest: []byte("msg"), PubKey: []byte("pub"), PubKeys: pubs})
+	assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, resp.ErrorCode)
+
+	//the keys of other schemes are refused
+	env, _ := NewKeyEnvelope("Other", 1, 1, 0, "", mockKey("pub"))
+	other, _ := env.MarshalBinary()
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: shares, Digest: []byte("msg"), PubKeys: [][]byte{pubs[0], other}})
+	assert.Equal(test, pb.ErrorCode_BAD_KEY_ENCODING, resp.ErrorCode)
+}
+
+func TestVerifyMultiSignature(test *testing.T) {
+	h := &handlerDecorator{multiSignerHandler{}, &SignerProcessor{}}
+	pubs := [][]byte{[]byte("pub1"), []byte("pub2")}
+
+	resp := verifyWith(test, h, &pb.VerifyRequest{Signature: []byte("s1s2"), Msg: []byte("msg"), PubKeys: pubs})
+	assert.Equal(test, pb.VerifyResponse_OK, resp.Status)
+
+	resp = verifyWith(test, h, &pb.VerifyRequest{Signature: []byte("distinct"), Msgs: [][]byte{[]byte("m1"), []byte("m2")}, PubKeys: pubs})
+	assert.Equal(test, pb.VerifyResponse_OK, resp.Status)
+
+	resp = verifyWith(test, h, &pb.VerifyRequest{Signature: []byte("s1s2"), Msgs: [][]byte{[]byte("m1"), []byte("m2")}, PubKeys: pubs})
+	assert.Equal(test, pb.ErrorCode_INVALID_SIGNATURE, resp.ErrorCode)
+
+	resp = verifyWith(test, h, &pb.VerifyRequest{Signature: []byte("distinct"), Msgs: [][]byte{[]byte("m1")}, PubKeys: pubs})
+	assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, resp.ErrorCode)
+
+	resp = verifyWith(test, h, &pb.VerifyRequest{Signature: []byte("distinct"), Msg: []byte("msg"), Msgs: [][]byte{[]byte("m1"), []byte("m2")}, PubKeys: pubs})
+	assert.Equal(test, pb.ErrorCode_MALFORMED_REQUEST, resp.ErrorCode)
+}
+
+func TestMultiSignatureUnsupported(test *testing.T) {
+	h := &handlerDecorator{mockSignerHandler{}, &SignerProcessor{}}
+	pubs := [][]byte{[]byte("pub1"), []byte("pub2")}
+
+	resp := aggregateWith(test, h, &pb.AggregateRequest{Share: [][]byte{[]byte("s1"), []byte("s2")}, Digest: []byte("msg"), PubKeys: pubs})
+	assert.Equal(test, pb.ErrorCode_UNSUPPORTED, resp.ErrorCode)
+
+	verified := verifyWith(test, h, &pb.VerifyRequest{Signature: []byte("s1s2"), Msg: []byte("msg"), PubKeys: pubs})
+	assert.Equal(test, pb.ErrorCode_UNSUPPORTED, verified.ErrorCode)
+}
+
+func TestCheckMultiSignature(test *testing.T) {
+	pubs := []PublicKey{mockKey("pub1"), mockKey("pub2")}
+
+	assert.Nil(test, CheckMultiSignature(nil, pubs))
+	assert.Nil(test, CheckMultiSignature([][]byte{{1}, {2}}, pubs))
+	assert.True(test, errors.Is(CheckMultiSignature([][]byte{{1}}, pubs), ErrMalformedRequest))
+	assert.True(test, errors.Is(CheckMultiSignature(nil, nil), ErrMalformedRequest))
+
+	assert.Nil(test, CheckDistinctSignature(nil, [][]byte{{1}, {2}}, pubs))
+	assert.True(test, errors.Is(CheckDistinctSignature(nil, nil, pubs), ErrMalformedRequest))
+}
@@ -0,0 +1,77 @@
+package crypto
+
+import (
+	"fmt"
+
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+)
+
+//Operation identifies one of the requests a handler can serve
+type Operation int
+
+const (
+	SignOperation Operation = iota + 1
+	VerifyOperation
+	AggregateOperation
+	GenerateOperation
+)
+
+var operationNames = map[Operation]string{
+	SignOperation:      "sign",
+	VerifyOperation:    "verify",
+	AggregateOperation: "aggregate",
+	GenerateOperation:  "generate",
+}
+
+func (op Operation) String() string {
+	if name, ok := operationNames[op]; ok {
+		return name
+	}
+	return fmt.Sprintf("Operation(%d)", int(op))
+}
+
+//AllOperations returns every operation a handler may serve
+func AllOperations() []Operation {
+	return []Operation{SignOperation, VerifyOperation, AggregateOperation, GenerateOperation}
+}
+
+//OperationSupporter is optionally implemented by a THSignerHandler
+//to declare the operations it serves. Handlers that do not implement
+//it are assumed to serve all of them.
+type OperationSupporter interface {
+	SupportedOperations() []Operation
+}
+
+//SupportedOperations returns the operations served by handler
+func SupportedOperations(handler interface{}) []Operation {
+	if s, ok := handler.(OperationSupporter); ok {
+		return s.SupportedOperations()
+	}
+	return AllOperations()
+}
+
+//Supports reports whether handler serves op
+func Supports(handler interface{}, op Operation) bool {
+	for _, v := range SupportedOperations(handler) {
+		if v == op {
+			return true
+		}
+	}
+	return false
+}
+
+//operationOf maps a request type to the operation it asks for
+func operationOf(msgType pb.Type) (Operation, bool) {
+	switch msgType {
+	case pb.Type_SIGN_REQUEST, pb.Type_BATCH_SIGN_REQUEST:
+		return SignOperation, true
+	case pb.Type_VERIFY_REQUEST, pb.Type_BATCH_VERIFY_REQUEST:
+		return VerifyOperation, true
+	case pb.Type_AGGREGATE_REQUEST, pb.Type_BATCH_AGGREGATE_REQUEST:
+		return AggregateOperation, true
+	case pb.Type_GENERATE_THS_REQUEST:
+		return GenerateOperation, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,50 @@
+package crypto
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+//signOnlyHandler only serves sign requests
+type signOnlyHandler struct {
+	mockSignerHandler
+}
+
+func (m signOnlyHandler) SupportedOperations() []Operation {
+	return []Operation{SignOperation}
+}
+
+func TestSupports(test *testing.T) {
+	assert.True(test, Supports(mockSignerHandler{}, AggregateOperation))
+	assert.True(test, Supports(signOnlyHandler{}, SignOperation))
+	assert.False(test, Supports(signOnlyHandler{}, AggregateOperation))
+}
+
+func TestHandlerUnknownMessageType(test *testing.T) {
+	h := handlerDecorator{mockSignerHandler{}, &SignerProcessor{}}
+
+	respBytes, respType := h.Handle(nil, 12345)
+	require.Equal(test, int32(pb.Type_UNSUPPORTED_RESPONSE), respType)
+
+	resp := pb.UnsupportedResponse{}
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	assert.Equal(test, "Mock", resp.Scheme)
+	assert.Equal(test, pb.Type(12345), resp.RequestType)
+	assert.Equal(test, pb.ErrorCode_UNSUPPORTED, resp.ErrorCode)
+}
+
+func TestHandlerUnsupportedOperation(test *testing.T) {
+	h := handlerDecorator{signOnlyHandler{}, &SignerProcessor{}}
+
+	req, _ := proto.Marshal(&pb.AggregateRequest{Scheme: "Mock"})
+	respBytes, respType := h.Handle(req, int32(pb.Type_AGGREGATE_REQUEST))
+	require.Equal(test, int32(pb.Type_UNSUPPORTED_RESPONSE), respType)
+
+	resp := pb.UnsupportedResponse{}
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	assert.Equal(test, pb.Type_AGGREGATE_REQUEST, resp.RequestType)
+	assert.Equal(test, pb.ErrorCode_UNSUPPORTED, resp.ErrorCode)
+}
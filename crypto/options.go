@@ -0,0 +1,71 @@
+package crypto
+
+import "time"
+
+//ProcessorOption configures a SignerProcessor
+type ProcessorOption func(*SignerProcessor)
+
+//WithKeyStore lets requests name keys held in store
+//instead of sending the key bytes
+func WithKeyStore(store KeyStore) ProcessorOption {
+	return func(p *SignerProcessor) {
+		p.keys = store
+	}
+}
+
+//WithRawKeys allows or rejects sign requests carrying the private key bytes.
+//Raw keys are allowed by default.
+func WithRawKeys(allowed bool) ProcessorOption {
+	return func(p *SignerProcessor) {
+		p.rejectRawKeys = !allowed
+	}
+}
+
+//WithExportToken allows shares stored by the processor to be exported
+//by requests presenting token. Export is disabled by default.
+func WithExportToken(token []byte) ProcessorOption {
+	return func(p *SignerProcessor) {
+		p.exportToken = token
+	}
+}
+
+//WithBatchWorkers sets how many items of a batch are processed concurrently.
+//It defaults to the number of CPUs.
+func WithBatchWorkers(workers int) ProcessorOption {
+	return func(p *SignerProcessor) {
+		p.batchWorkers = workers
+	}
+}
+
+//WithMaxBatchSize sets how many items a batch request may carry.
+//It defaults to DefaultMaxBatchSize.
+func WithMaxBatchSize(size int) ProcessorOption {
+	return func(p *SignerProcessor) {
+		p.maxBatchSize = size
+	}
+}
+
+//WithMaxAbandoned sets how many requests given up at their deadline may
+//still run in the background, in handlers not honouring their context.
+//Once reached, requests with a deadline are refused with OVERLOADED until
+//some of them return. It defaults to DefaultMaxAbandoned.
+func WithMaxAbandoned(max int) ProcessorOption {
+	return func(p *SignerProcessor) {
+		p.maxAbandoned = max
+	}
+}
+
+//WithTimeout bounds how long the processor works on requests for op
+//that do not carry an earlier deadline. Requests fail with
+//DEADLINE_EXCEEDED once it elapses. There is no timeout by default.
+//Batch requests for op are given timeout for every round of items the
+//batch workers process: a batch of 10 items processed by 4 workers
+//has 3 times timeout to complete.
+func WithTimeout(op Operation, timeout time.Duration) ProcessorOption {
+	return func(p *SignerProcessor) {
+		if p.timeouts == nil {
+			p.timeouts = make(map[Operation]time.Duration)
+		}
+		p.timeouts[op] = timeout
+	}
+}
@@ -28,15 +28,26 @@ const _ = proto.ProtoPackageIsVersion4
 type Type int32
 
 const (
-	Type_DEFAULT               Type = 0
-	Type_SIGN_REQUEST          Type = 100
-	Type_SIGN_RESPONSE         Type = 101
-	Type_VERIFY_REQUEST        Type = 200
-	Type_VERIFY_RESPONSE       Type = 201
-	Type_AGGREGATE_REQUEST     Type = 300
-	Type_AGGREGATE_RESPONSE    Type = 301
-	Type_GENERATE_THS_REQUEST  Type = 400
-	Type_GENERATE_THS_RESPONSE Type = 401
+	Type_DEFAULT                  Type = 0
+	Type_SIGN_REQUEST             Type = 100
+	Type_SIGN_RESPONSE            Type = 101
+	Type_BATCH_SIGN_REQUEST       Type = 110
+	Type_BATCH_SIGN_RESPONSE      Type = 111
+	Type_VERIFY_REQUEST           Type = 200
+	Type_VERIFY_RESPONSE          Type = 201
+	Type_BATCH_VERIFY_REQUEST     Type = 210
+	Type_BATCH_VERIFY_RESPONSE    Type = 211
+	Type_AGGREGATE_REQUEST        Type = 300
+	Type_AGGREGATE_RESPONSE       Type = 301
+	Type_BATCH_AGGREGATE_REQUEST  Type = 310
+	Type_BATCH_AGGREGATE_RESPONSE Type = 311
+	Type_GENERATE_THS_REQUEST     Type = 400
+	Type_GENERATE_THS_RESPONSE    Type = 401
+	Type_EXPORT_SHARE_REQUEST     Type = 500
+	Type_EXPORT_SHARE_RESPONSE    Type = 501
+	Type_LIST_SCHEMES_REQUEST     Type = 600
+	Type_LIST_SCHEMES_RESPONSE    Type = 601
+	Type_UNSUPPORTED_RESPONSE     Type = 900
 )
 
 // Enum value maps for Type.
@@ -45,23 +56,45 @@ var (
 		0:   "DEFAULT",
 		100: "SIGN_REQUEST",
 		101: "SIGN_RESPONSE",
+		110: "BATCH_SIGN_REQUEST",
+		111: "BATCH_SIGN_RESPONSE",
 		200: "VERIFY_REQUEST",
 		201: "VERIFY_RESPONSE",
+		210: "BATCH_VERIFY_REQUEST",
+		211: "BATCH_VERIFY_RESPONSE",
 		300: "AGGREGATE_REQUEST",
 		301: "AGGREGATE_RESPONSE",
+		310: "BATCH_AGGREGATE_REQUEST",
+		311: "BATCH_AGGREGATE_RESPONSE",
 		400: "GENERATE_THS_REQUEST",
 		401: "GENERATE_THS_RESPONSE",
+		500: "EXPORT_SHARE_REQUEST",
+		501: "EXPORT_SHARE_RESPONSE",
+		600: "LIST_SCHEMES_REQUEST",
+		601: "LIST_SCHEMES_RESPONSE",
+		900: "UNSUPPORTED_RESPONSE",
 	}
 	Type_value = map[string]int32{
-		"DEFAULT":               0,
-		"SIGN_REQUEST":          100,
-		"SIGN_RESPONSE":         101,
-		"VERIFY_REQUEST":        200,
-		"VERIFY_RESPONSE":       201,
-		"AGGREGATE_REQUEST":     300,
-		"AGGREGATE_RESPONSE":    301,
-		"GENERATE_THS_REQUEST":  400,
-		"GENERATE_THS_RESPONSE": 401,
+		"DEFAULT":                  0,
+		"SIGN_REQUEST":             100,
+		"SIGN_RESPONSE":            101,
+		"BATCH_SIGN_REQUEST":       110,
+		"BATCH_SIGN_RESPONSE":      111,
+		"VERIFY_REQUEST":           200,
+		"VERIFY_RESPONSE":          201,
+		"BATCH_VERIFY_REQUEST":     210,
+		"BATCH_VERIFY_RESPONSE":    211,
+		"AGGREGATE_REQUEST":        300,
+		"AGGREGATE_RESPONSE":       301,
+		"BATCH_AGGREGATE_REQUEST":  310,
+		"BATCH_AGGREGATE_RESPONSE": 311,
+		"GENERATE_THS_REQUEST":     400,
+		"GENERATE_THS_RESPONSE":    401,
+		"EXPORT_SHARE_REQUEST":     500,
+		"EXPORT_SHARE_RESPONSE":    501,
+		"LIST_SCHEMES_REQUEST":     600,
+		"LIST_SCHEMES_RESPONSE":    601,
+		"UNSUPPORTED_RESPONSE":     900,
 	}
 )
 
@@ -92,6 +125,195 @@ func (Type) EnumDescriptor() ([]byte, []int) {
 	return file_crypto_proto_rawDescGZIP(), []int{0}
 }
 
+type ErrorCode int32
+
+const (
+	ErrorCode_NO_ERROR          ErrorCode = 0
+	ErrorCode_MALFORMED_REQUEST ErrorCode = 1
+	ErrorCode_BAD_KEY_ENCODING  ErrorCode = 2
+	ErrorCode_NOT_ENOUGH_SHARES ErrorCode = 3
+	ErrorCode_INVALID_SHARE     ErrorCode = 4
+	ErrorCode_INVALID_SIGNATURE ErrorCode = 5
+	ErrorCode_UNSUPPORTED       ErrorCode = 6
+	ErrorCode_INTERNAL          ErrorCode = 7
+	ErrorCode_KEY_NOT_FOUND     ErrorCode = 8
+	ErrorCode_PERMISSION_DENIED ErrorCode = 9
+	ErrorCode_KEY_EXISTS        ErrorCode = 10
+	ErrorCode_DEADLINE_EXCEEDED ErrorCode = 11
+	ErrorCode_INVALID_PROOF     ErrorCode = 12
+	ErrorCode_OVERLOADED        ErrorCode = 13
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0:  "NO_ERROR",
+		1:  "MALFORMED_REQUEST",
+		2:  "BAD_KEY_ENCODING",
+		3:  "NOT_ENOUGH_SHARES",
+		4:  "INVALID_SHARE",
+		5:  "INVALID_SIGNATURE",
+		6:  "UNSUPPORTED",
+		7:  "INTERNAL",
+		8:  "KEY_NOT_FOUND",
+		9:  "PERMISSION_DENIED",
+		10: "KEY_EXISTS",
+		11: "DEADLINE_EXCEEDED",
+		12: "INVALID_PROOF",
+		13: "OVERLOADED",
+	}
+	ErrorCode_value = map[string]int32{
+		"NO_ERROR":          0,
+		"MALFORMED_REQUEST": 1,
+		"BAD_KEY_ENCODING":  2,
+		"NOT_ENOUGH_SHARES": 3,
+		"INVALID_SHARE":     4,
+		"INVALID_SIGNATURE": 5,
+		"UNSUPPORTED":       6,
+		"INTERNAL":          7,
+		"KEY_NOT_FOUND":     8,
+		"PERMISSION_DENIED": 9,
+		"KEY_EXISTS":        10,
+		"DEADLINE_EXCEEDED": 11,
+		"INVALID_PROOF":     12,
+		"OVERLOADED":        13,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_crypto_proto_enumTypes[1].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_crypto_proto_enumTypes[1]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{1}
+}
+
+type Operation int32
+
+const (
+	Operation_OPERATION_UNSET Operation = 0
+	Operation_SIGN            Operation = 1
+	Operation_VERIFY          Operation = 2
+	Operation_AGGREGATE       Operation = 3
+	Operation_GENERATE        Operation = 4
+)
+
+// Enum value maps for Operation.
+var (
+	Operation_name = map[int32]string{
+		0: "OPERATION_UNSET",
+		1: "SIGN",
+		2: "VERIFY",
+		3: "AGGREGATE",
+		4: "GENERATE",
+	}
+	Operation_value = map[string]int32{
+		"OPERATION_UNSET": 0,
+		"SIGN":            1,
+		"VERIFY":          2,
+		"AGGREGATE":       3,
+		"GENERATE":        4,
+	}
+)
+
+func (x Operation) Enum() *Operation {
+	p := new(Operation)
+	*p = x
+	return p
+}
+
+func (x Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_crypto_proto_enumTypes[2].Descriptor()
+}
+
+func (Operation) Type() protoreflect.EnumType {
+	return &file_crypto_proto_enumTypes[2]
+}
+
+func (x Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Operation.Descriptor instead.
+func (Operation) EnumDescriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{2}
+}
+
+type AggregationStrategy int32
+
+const (
+	AggregationStrategy_NO_AGGREGATION AggregationStrategy = 0
+	AggregationStrategy_NORMAL         AggregationStrategy = 1
+	AggregationStrategy_OPTIMISTIC     AggregationStrategy = 2
+	AggregationStrategy_PESSIMISTIC    AggregationStrategy = 3
+)
+
+// Enum value maps for AggregationStrategy.
+var (
+	AggregationStrategy_name = map[int32]string{
+		0: "NO_AGGREGATION",
+		1: "NORMAL",
+		2: "OPTIMISTIC",
+		3: "PESSIMISTIC",
+	}
+	AggregationStrategy_value = map[string]int32{
+		"NO_AGGREGATION": 0,
+		"NORMAL":         1,
+		"OPTIMISTIC":     2,
+		"PESSIMISTIC":    3,
+	}
+)
+
+func (x AggregationStrategy) Enum() *AggregationStrategy {
+	p := new(AggregationStrategy)
+	*p = x
+	return p
+}
+
+func (x AggregationStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AggregationStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_crypto_proto_enumTypes[3].Descriptor()
+}
+
+func (AggregationStrategy) Type() protoreflect.EnumType {
+	return &file_crypto_proto_enumTypes[3]
+}
+
+func (x AggregationStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AggregationStrategy.Descriptor instead.
+func (AggregationStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{3}
+}
+
 type GenerateTHSResponse_Status int32
 
 const (
@@ -125,11 +347,11 @@ func (x GenerateTHSResponse_Status) String() string {
 }
 
 func (GenerateTHSResponse_Status) Descriptor() protoreflect.EnumDescriptor {
-	return file_crypto_proto_enumTypes[1].Descriptor()
+	return file_crypto_proto_enumTypes[4].Descriptor()
 }
 
 func (GenerateTHSResponse_Status) Type() protoreflect.EnumType {
-	return &file_crypto_proto_enumTypes[1]
+	return &file_crypto_proto_enumTypes[4]
 }
 
 func (x GenerateTHSResponse_Status) Number() protoreflect.EnumNumber {
@@ -174,11 +396,11 @@ func (x SignResponse_Status) String() string {
 }
 
 func (SignResponse_Status) Descriptor() protoreflect.EnumDescriptor {
-	return file_crypto_proto_enumTypes[2].Descriptor()
+	return file_crypto_proto_enumTypes[5].Descriptor()
 }
 
 func (SignResponse_Status) Type() protoreflect.EnumType {
-	return &file_crypto_proto_enumTypes[2]
+	return &file_crypto_proto_enumTypes[5]
 }
 
 func (x SignResponse_Status) Number() protoreflect.EnumNumber {
@@ -223,11 +445,11 @@ func (x VerifyResponse_Status) String() string {
 }
 
 func (VerifyResponse_Status) Descriptor() protoreflect.EnumDescriptor {
-	return file_crypto_proto_enumTypes[3].Descriptor()
+	return file_crypto_proto_enumTypes[6].Descriptor()
 }
 
 func (VerifyResponse_Status) Type() protoreflect.EnumType {
-	return &file_crypto_proto_enumTypes[3]
+	return &file_crypto_proto_enumTypes[6]
 }
 
 func (x VerifyResponse_Status) Number() protoreflect.EnumNumber {
@@ -272,11 +494,11 @@ func (x AggregateResponse_Status) String() string {
 }
 
 func (AggregateResponse_Status) Descriptor() protoreflect.EnumDescriptor {
-	return file_crypto_proto_enumTypes[4].Descriptor()
+	return file_crypto_proto_enumTypes[7].Descriptor()
 }
 
 func (AggregateResponse_Status) Type() protoreflect.EnumType {
-	return &file_crypto_proto_enumTypes[4]
+	return &file_crypto_proto_enumTypes[7]
 }
 
 func (x AggregateResponse_Status) Number() protoreflect.EnumNumber {
@@ -288,14 +510,262 @@ func (AggregateResponse_Status) EnumDescriptor() ([]byte, []int) {
 	return file_crypto_proto_rawDescGZIP(), []int{7, 0}
 }
 
+type ListSchemesResponse_Status int32
+
+const (
+	ListSchemesResponse_STATUS_UNSET ListSchemesResponse_Status = 0
+	ListSchemesResponse_OK           ListSchemesResponse_Status = 1
+	ListSchemesResponse_ERROR        ListSchemesResponse_Status = 2
+)
+
+// Enum value maps for ListSchemesResponse_Status.
+var (
+	ListSchemesResponse_Status_name = map[int32]string{
+		0: "STATUS_UNSET",
+		1: "OK",
+		2: "ERROR",
+	}
+	ListSchemesResponse_Status_value = map[string]int32{
+		"STATUS_UNSET": 0,
+		"OK":           1,
+		"ERROR":        2,
+	}
+)
+
+func (x ListSchemesResponse_Status) Enum() *ListSchemesResponse_Status {
+	p := new(ListSchemesResponse_Status)
+	*p = x
+	return p
+}
+
+func (x ListSchemesResponse_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ListSchemesResponse_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_crypto_proto_enumTypes[8].Descriptor()
+}
+
+func (ListSchemesResponse_Status) Type() protoreflect.EnumType {
+	return &file_crypto_proto_enumTypes[8]
+}
+
+func (x ListSchemesResponse_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ListSchemesResponse_Status.Descriptor instead.
+func (ListSchemesResponse_Status) EnumDescriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{11, 0}
+}
+
+type ExportShareResponse_Status int32
+
+const (
+	ExportShareResponse_STATUS_UNSET ExportShareResponse_Status = 0
+	ExportShareResponse_OK           ExportShareResponse_Status = 1
+	ExportShareResponse_ERROR        ExportShareResponse_Status = 2
+)
+
+// Enum value maps for ExportShareResponse_Status.
+var (
+	ExportShareResponse_Status_name = map[int32]string{
+		0: "STATUS_UNSET",
+		1: "OK",
+		2: "ERROR",
+	}
+	ExportShareResponse_Status_value = map[string]int32{
+		"STATUS_UNSET": 0,
+		"OK":           1,
+		"ERROR":        2,
+	}
+)
+
+func (x ExportShareResponse_Status) Enum() *ExportShareResponse_Status {
+	p := new(ExportShareResponse_Status)
+	*p = x
+	return p
+}
+
+func (x ExportShareResponse_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportShareResponse_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_crypto_proto_enumTypes[9].Descriptor()
+}
+
+func (ExportShareResponse_Status) Type() protoreflect.EnumType {
+	return &file_crypto_proto_enumTypes[9]
+}
+
+func (x ExportShareResponse_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportShareResponse_Status.Descriptor instead.
+func (ExportShareResponse_Status) EnumDescriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{13, 0}
+}
+
+type BatchSignResponse_Status int32
+
+const (
+	BatchSignResponse_STATUS_UNSET BatchSignResponse_Status = 0
+	BatchSignResponse_OK           BatchSignResponse_Status = 1
+	BatchSignResponse_ERROR        BatchSignResponse_Status = 2
+)
+
+// Enum value maps for BatchSignResponse_Status.
+var (
+	BatchSignResponse_Status_name = map[int32]string{
+		0: "STATUS_UNSET",
+		1: "OK",
+		2: "ERROR",
+	}
+	BatchSignResponse_Status_value = map[string]int32{
+		"STATUS_UNSET": 0,
+		"OK":           1,
+		"ERROR":        2,
+	}
+)
+
+func (x BatchSignResponse_Status) Enum() *BatchSignResponse_Status {
+	p := new(BatchSignResponse_Status)
+	*p = x
+	return p
+}
+
+func (x BatchSignResponse_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchSignResponse_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_crypto_proto_enumTypes[10].Descriptor()
+}
+
+func (BatchSignResponse_Status) Type() protoreflect.EnumType {
+	return &file_crypto_proto_enumTypes[10]
+}
+
+func (x BatchSignResponse_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchSignResponse_Status.Descriptor instead.
+func (BatchSignResponse_Status) EnumDescriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{15, 0}
+}
+
+type BatchVerifyResponse_Status int32
+
+const (
+	BatchVerifyResponse_STATUS_UNSET BatchVerifyResponse_Status = 0
+	BatchVerifyResponse_OK           BatchVerifyResponse_Status = 1
+	BatchVerifyResponse_ERROR        BatchVerifyResponse_Status = 2
+)
+
+// Enum value maps for BatchVerifyResponse_Status.
+var (
+	BatchVerifyResponse_Status_name = map[int32]string{
+		0: "STATUS_UNSET",
+		1: "OK",
+		2: "ERROR",
+	}
+	BatchVerifyResponse_Status_value = map[string]int32{
+		"STATUS_UNSET": 0,
+		"OK":           1,
+		"ERROR":        2,
+	}
+)
+
+func (x BatchVerifyResponse_Status) Enum() *BatchVerifyResponse_Status {
+	p := new(BatchVerifyResponse_Status)
+	*p = x
+	return p
+}
+
+func (x BatchVerifyResponse_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchVerifyResponse_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_crypto_proto_enumTypes[11].Descriptor()
+}
+
+func (BatchVerifyResponse_Status) Type() protoreflect.EnumType {
+	return &file_crypto_proto_enumTypes[11]
+}
+
+func (x BatchVerifyResponse_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchVerifyResponse_Status.Descriptor instead.
+func (BatchVerifyResponse_Status) EnumDescriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{17, 0}
+}
+
+type BatchAggregateResponse_Status int32
+
+const (
+	BatchAggregateResponse_STATUS_UNSET BatchAggregateResponse_Status = 0
+	BatchAggregateResponse_OK           BatchAggregateResponse_Status = 1
+	BatchAggregateResponse_ERROR        BatchAggregateResponse_Status = 2
+)
+
+// Enum value maps for BatchAggregateResponse_Status.
+var (
+	BatchAggregateResponse_Status_name = map[int32]string{
+		0: "STATUS_UNSET",
+		1: "OK",
+		2: "ERROR",
+	}
+	BatchAggregateResponse_Status_value = map[string]int32{
+		"STATUS_UNSET": 0,
+		"OK":           1,
+		"ERROR":        2,
+	}
+)
+
+func (x BatchAggregateResponse_Status) Enum() *BatchAggregateResponse_Status {
+	p := new(BatchAggregateResponse_Status)
+	*p = x
+	return p
+}
+
+func (x BatchAggregateResponse_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchAggregateResponse_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_crypto_proto_enumTypes[12].Descriptor()
+}
+
+func (BatchAggregateResponse_Status) Type() protoreflect.EnumType {
+	return &file_crypto_proto_enumTypes[12]
+}
+
+func (x BatchAggregateResponse_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchAggregateResponse_Status.Descriptor instead.
+func (BatchAggregateResponse_Status) EnumDescriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{19, 0}
+}
+
 type GenerateTHSRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Scheme string `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
-	T      uint32 `protobuf:"varint,2,opt,name=t,proto3" json:"t,omitempty"`
-	N      uint32 `protobuf:"varint,3,opt,name=n,proto3" json:"n,omitempty"`
+	Scheme   string `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	T        uint32 `protobuf:"varint,2,opt,name=t,proto3" json:"t,omitempty"`
+	N        uint32 `protobuf:"varint,3,opt,name=n,proto3" json:"n,omitempty"`
+	Store    bool   `protobuf:"varint,4,opt,name=store,proto3" json:"store,omitempty"`
+	KeyId    string `protobuf:"bytes,5,opt,name=keyId,proto3" json:"keyId,omitempty"`
+	Deadline int64  `protobuf:"varint,6,opt,name=deadline,proto3" json:"deadline,omitempty"`
 }
 
 func (x *GenerateTHSRequest) Reset() {
@@ -351,14 +821,38 @@ func (x *GenerateTHSRequest) GetN() uint32 {
 	return 0
 }
 
-type GenerateTHSResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Status      GenerateTHSResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=GenerateTHSResponse_Status" json:"status,omitempty"`
-	PublicKey   []byte                     `protobuf:"bytes,2,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
-	PrivateKeys [][]byte                   `protobuf:"bytes,3,rep,name=privateKeys,proto3" json:"privateKeys,omitempty"`
+func (x *GenerateTHSRequest) GetStore() bool {
+	if x != nil {
+		return x.Store
+	}
+	return false
+}
+
+func (x *GenerateTHSRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *GenerateTHSRequest) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+type GenerateTHSResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status       GenerateTHSResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=GenerateTHSResponse_Status" json:"status,omitempty"`
+	PublicKey    []byte                     `protobuf:"bytes,2,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	PrivateKeys  [][]byte                   `protobuf:"bytes,3,rep,name=privateKeys,proto3" json:"privateKeys,omitempty"`
+	ErrorCode    ErrorCode                  `protobuf:"varint,4,opt,name=errorCode,proto3,enum=ErrorCode" json:"errorCode,omitempty"`
+	ErrorMessage string                     `protobuf:"bytes,5,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
+	KeyId        string                     `protobuf:"bytes,6,opt,name=keyId,proto3" json:"keyId,omitempty"`
 }
 
 func (x *GenerateTHSResponse) Reset() {
@@ -414,6 +908,27 @@ func (x *GenerateTHSResponse) GetPrivateKeys() [][]byte {
 	return nil
 }
 
+func (x *GenerateTHSResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_NO_ERROR
+}
+
+func (x *GenerateTHSResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *GenerateTHSResponse) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
 type SignRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -422,6 +937,8 @@ type SignRequest struct {
 	Scheme      string `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
 	Digest      []byte `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
 	PrivateKeys []byte `protobuf:"bytes,3,opt,name=privateKeys,proto3" json:"privateKeys,omitempty"`
+	KeyId       string `protobuf:"bytes,4,opt,name=keyId,proto3" json:"keyId,omitempty"`
+	Deadline    int64  `protobuf:"varint,5,opt,name=deadline,proto3" json:"deadline,omitempty"`
 }
 
 func (x *SignRequest) Reset() {
@@ -477,13 +994,29 @@ func (x *SignRequest) GetPrivateKeys() []byte {
 	return nil
 }
 
+func (x *SignRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *SignRequest) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
 type SignResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status    SignResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=SignResponse_Status" json:"status,omitempty"`
-	Signature []byte              `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	Status       SignResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=SignResponse_Status" json:"status,omitempty"`
+	Signature    []byte              `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	ErrorCode    ErrorCode           `protobuf:"varint,3,opt,name=errorCode,proto3,enum=ErrorCode" json:"errorCode,omitempty"`
+	ErrorMessage string              `protobuf:"bytes,4,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
 }
 
 func (x *SignResponse) Reset() {
@@ -532,15 +1065,33 @@ func (x *SignResponse) GetSignature() []byte {
 	return nil
 }
 
+func (x *SignResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_NO_ERROR
+}
+
+func (x *SignResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
 type VerifyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Scheme    string `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
-	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	Msg       []byte `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
-	PubKey    []byte `protobuf:"bytes,4,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	Scheme    string   `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	Signature []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	Msg       []byte   `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	PubKey    []byte   `protobuf:"bytes,4,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	Deadline  int64    `protobuf:"varint,5,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	PubKeys   [][]byte `protobuf:"bytes,6,rep,name=pubKeys,proto3" json:"pubKeys,omitempty"`
+	Msgs      [][]byte `protobuf:"bytes,7,rep,name=msgs,proto3" json:"msgs,omitempty"`
+	Proofs    [][]byte `protobuf:"bytes,8,rep,name=proofs,proto3" json:"proofs,omitempty"`
 }
 
 func (x *VerifyRequest) Reset() {
@@ -603,12 +1154,42 @@ func (x *VerifyRequest) GetPubKey() []byte {
 	return nil
 }
 
+func (x *VerifyRequest) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+func (x *VerifyRequest) GetPubKeys() [][]byte {
+	if x != nil {
+		return x.PubKeys
+	}
+	return nil
+}
+
+func (x *VerifyRequest) GetMsgs() [][]byte {
+	if x != nil {
+		return x.Msgs
+	}
+	return nil
+}
+
+func (x *VerifyRequest) GetProofs() [][]byte {
+	if x != nil {
+		return x.Proofs
+	}
+	return nil
+}
+
 type VerifyResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status VerifyResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=VerifyResponse_Status" json:"status,omitempty"`
+	Status       VerifyResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=VerifyResponse_Status" json:"status,omitempty"`
+	ErrorCode    ErrorCode             `protobuf:"varint,2,opt,name=errorCode,proto3,enum=ErrorCode" json:"errorCode,omitempty"`
+	ErrorMessage string                `protobuf:"bytes,3,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
 }
 
 func (x *VerifyResponse) Reset() {
@@ -650,17 +1231,35 @@ func (x *VerifyResponse) GetStatus() VerifyResponse_Status {
 	return VerifyResponse_STATUS_UNSET
 }
 
+func (x *VerifyResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_NO_ERROR
+}
+
+func (x *VerifyResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
 type AggregateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Scheme string   `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
-	Share  [][]byte `protobuf:"bytes,2,rep,name=share,proto3" json:"share,omitempty"`
-	Digest []byte   `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
-	PubKey []byte   `protobuf:"bytes,4,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
-	T      int32    `protobuf:"varint,5,opt,name=t,proto3" json:"t,omitempty"`
-	N      int32    `protobuf:"varint,6,opt,name=n,proto3" json:"n,omitempty"`
+	Scheme   string   `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	Share    [][]byte `protobuf:"bytes,2,rep,name=share,proto3" json:"share,omitempty"`
+	Digest   []byte   `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	PubKey   []byte   `protobuf:"bytes,4,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	T        int32    `protobuf:"varint,5,opt,name=t,proto3" json:"t,omitempty"`
+	N        int32    `protobuf:"varint,6,opt,name=n,proto3" json:"n,omitempty"`
+	Deadline int64    `protobuf:"varint,7,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	PubKeys  [][]byte `protobuf:"bytes,8,rep,name=pubKeys,proto3" json:"pubKeys,omitempty"`
+	Msgs     [][]byte `protobuf:"bytes,9,rep,name=msgs,proto3" json:"msgs,omitempty"`
+	Proofs   [][]byte `protobuf:"bytes,10,rep,name=proofs,proto3" json:"proofs,omitempty"`
 }
 
 func (x *AggregateRequest) Reset() {
@@ -737,13 +1336,43 @@ func (x *AggregateRequest) GetN() int32 {
 	return 0
 }
 
+func (x *AggregateRequest) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+func (x *AggregateRequest) GetPubKeys() [][]byte {
+	if x != nil {
+		return x.PubKeys
+	}
+	return nil
+}
+
+func (x *AggregateRequest) GetMsgs() [][]byte {
+	if x != nil {
+		return x.Msgs
+	}
+	return nil
+}
+
+func (x *AggregateRequest) GetProofs() [][]byte {
+	if x != nil {
+		return x.Proofs
+	}
+	return nil
+}
+
 type AggregateResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status    AggregateResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=AggregateResponse_Status" json:"status,omitempty"`
-	Signature []byte                   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	Status       AggregateResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=AggregateResponse_Status" json:"status,omitempty"`
+	Signature    []byte                   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	ErrorCode    ErrorCode                `protobuf:"varint,3,opt,name=errorCode,proto3,enum=ErrorCode" json:"errorCode,omitempty"`
+	ErrorMessage string                   `protobuf:"bytes,4,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
 }
 
 func (x *AggregateResponse) Reset() {
@@ -792,189 +1421,1441 @@ func (x *AggregateResponse) GetSignature() []byte {
 	return nil
 }
 
-var File_crypto_proto protoreflect.FileDescriptor
+func (x *AggregateResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_NO_ERROR
+}
 
-var file_crypto_proto_rawDesc = []byte{
-	0x0a, 0x0c, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x48,
-	0x0a, 0x12, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x48, 0x53, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x0c, 0x0a, 0x01,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x6e, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x6e, 0x22, 0xb9, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x6e,
-	0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x48, 0x53, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x33, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x1b, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x48, 0x53, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b,
-	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
-	0x4b, 0x65, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65,
-	0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74,
-	0x65, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x2d, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x10, 0x0a, 0x0c, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10,
-	0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52,
-	0x4f, 0x52, 0x10, 0x02, 0x22, 0x5f, 0x0a, 0x0b, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64,
-	0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67,
-	0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65,
-	0x79, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74,
-	0x65, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x89, 0x01, 0x0a, 0x0c, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
-	0x72, 0x65, 0x22, 0x2d, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x0c,
-	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x06,
-	0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10,
-	0x02, 0x22, 0x6f, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69,
-	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73,
-	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75,
-	0x62, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x4b,
-	0x65, 0x79, 0x22, 0x6f, 0x0a, 0x0e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x22, 0x2d, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10,
-	0x0a, 0x0c, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10, 0x00,
-	0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f,
-	0x52, 0x10, 0x02, 0x22, 0x8c, 0x01, 0x0a, 0x10, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52,
-	0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x16,
-	0x0a, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
-	0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x01, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x01, 0x6e, 0x22, 0x93, 0x01, 0x0a, 0x11, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65,
-	0x67, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73,
-	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
-	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x2d, 0x0a, 0x06, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e,
-	0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x01, 0x12, 0x09, 0x0a,
-	0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x02, 0x2a, 0xcb, 0x01, 0x0a, 0x04, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x10,
-	0x0a, 0x0c, 0x53, 0x49, 0x47, 0x4e, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0x64,
-	0x12, 0x11, 0x0a, 0x0d, 0x53, 0x49, 0x47, 0x4e, 0x5f, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53,
-	0x45, 0x10, 0x65, 0x12, 0x13, 0x0a, 0x0e, 0x56, 0x45, 0x52, 0x49, 0x46, 0x59, 0x5f, 0x52, 0x45,
-	0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0xc8, 0x01, 0x12, 0x14, 0x0a, 0x0f, 0x56, 0x45, 0x52, 0x49,
-	0x46, 0x59, 0x5f, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x10, 0xc9, 0x01, 0x12, 0x16,
-	0x0a, 0x11, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x45, 0x5f, 0x52, 0x45, 0x51, 0x55,
-	0x45, 0x53, 0x54, 0x10, 0xac, 0x02, 0x12, 0x17, 0x0a, 0x12, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47,
-	0x41, 0x54, 0x45, 0x5f, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x10, 0xad, 0x02, 0x12,
-	0x19, 0x0a, 0x14, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x54, 0x48, 0x53, 0x5f,
-	0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0x90, 0x03, 0x12, 0x1a, 0x0a, 0x15, 0x47, 0x45,
-	0x4e, 0x45, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x54, 0x48, 0x53, 0x5f, 0x52, 0x45, 0x53, 0x50, 0x4f,
-	0x4e, 0x53, 0x45, 0x10, 0x91, 0x03, 0x42, 0x1d, 0x0a, 0x15, 0x73, 0x61, 0x77, 0x74, 0x6f, 0x6f,
-	0x74, 0x68, 0x2e, 0x73, 0x64, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x50,
-	0x01, 0x5a, 0x02, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *AggregateResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
 }
 
-var (
-	file_crypto_proto_rawDescOnce sync.Once
-	file_crypto_proto_rawDescData = file_crypto_proto_rawDesc
-)
+type UnsupportedResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func file_crypto_proto_rawDescGZIP() []byte {
-	file_crypto_proto_rawDescOnce.Do(func() {
-		file_crypto_proto_rawDescData = protoimpl.X.CompressGZIP(file_crypto_proto_rawDescData)
-	})
-	return file_crypto_proto_rawDescData
+	Scheme       string    `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	RequestType  Type      `protobuf:"varint,2,opt,name=requestType,proto3,enum=Type" json:"requestType,omitempty"`
+	ErrorCode    ErrorCode `protobuf:"varint,3,opt,name=errorCode,proto3,enum=ErrorCode" json:"errorCode,omitempty"`
+	ErrorMessage string    `protobuf:"bytes,4,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
 }
 
-var file_crypto_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_crypto_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_crypto_proto_goTypes = []interface{}{
-	(Type)(0),                       // 0: Type
-	(GenerateTHSResponse_Status)(0), // 1: GenerateTHSResponse.Status
-	(SignResponse_Status)(0),        // 2: SignResponse.Status
-	(VerifyResponse_Status)(0),      // 3: VerifyResponse.Status
-	(AggregateResponse_Status)(0),   // 4: AggregateResponse.Status
-	(*GenerateTHSRequest)(nil),      // 5: GenerateTHSRequest
-	(*GenerateTHSResponse)(nil),     // 6: GenerateTHSResponse
-	(*SignRequest)(nil),             // 7: SignRequest
-	(*SignResponse)(nil),            // 8: SignResponse
-	(*VerifyRequest)(nil),           // 9: VerifyRequest
-	(*VerifyResponse)(nil),          // 10: VerifyResponse
-	(*AggregateRequest)(nil),        // 11: AggregateRequest
-	(*AggregateResponse)(nil),       // 12: AggregateResponse
+func (x *UnsupportedResponse) Reset() {
+	*x = UnsupportedResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-var file_crypto_proto_depIdxs = []int32{
-	1, // 0: GenerateTHSResponse.status:type_name -> GenerateTHSResponse.Status
-	2, // 1: SignResponse.status:type_name -> SignResponse.Status
-	3, // 2: VerifyResponse.status:type_name -> VerifyResponse.Status
-	4, // 3: AggregateResponse.status:type_name -> AggregateResponse.Status
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+
+func (x *UnsupportedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_crypto_proto_init() }
-func file_crypto_proto_init() {
-	if File_crypto_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_crypto_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GenerateTHSRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_crypto_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GenerateTHSResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_crypto_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+func (*UnsupportedResponse) ProtoMessage() {}
+
+func (x *UnsupportedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_crypto_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsupportedResponse.ProtoReflect.Descriptor instead.
+func (*UnsupportedResponse) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UnsupportedResponse) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+func (x *UnsupportedResponse) GetRequestType() Type {
+	if x != nil {
+		return x.RequestType
+	}
+	return Type_DEFAULT
+}
+
+func (x *UnsupportedResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_NO_ERROR
+}
+
+func (x *UnsupportedResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type SchemeInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Operations  []Operation         `protobuf:"varint,2,rep,packed,name=operations,proto3,enum=Operation" json:"operations,omitempty"`
+	KeySize     uint32              `protobuf:"varint,3,opt,name=keySize,proto3" json:"keySize,omitempty"`
+	Threshold   bool                `protobuf:"varint,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Aggregation AggregationStrategy `protobuf:"varint,5,opt,name=aggregation,proto3,enum=AggregationStrategy" json:"aggregation,omitempty"`
+	Family      string              `protobuf:"bytes,6,opt,name=family,proto3" json:"family,omitempty"`
+}
+
+func (x *SchemeInfo) Reset() {
+	*x = SchemeInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SchemeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemeInfo) ProtoMessage() {}
+
+func (x *SchemeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_crypto_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyRequest); i {
-			case 0:
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemeInfo.ProtoReflect.Descriptor instead.
+func (*SchemeInfo) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SchemeInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SchemeInfo) GetOperations() []Operation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *SchemeInfo) GetKeySize() uint32 {
+	if x != nil {
+		return x.KeySize
+	}
+	return 0
+}
+
+func (x *SchemeInfo) GetThreshold() bool {
+	if x != nil {
+		return x.Threshold
+	}
+	return false
+}
+
+func (x *SchemeInfo) GetAggregation() AggregationStrategy {
+	if x != nil {
+		return x.Aggregation
+	}
+	return AggregationStrategy_NO_AGGREGATION
+}
+
+func (x *SchemeInfo) GetFamily() string {
+	if x != nil {
+		return x.Family
+	}
+	return ""
+}
+
+type ListSchemesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deadline int64 `protobuf:"varint,1,opt,name=deadline,proto3" json:"deadline,omitempty"`
+}
+
+func (x *ListSchemesRequest) Reset() {
+	*x = ListSchemesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSchemesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchemesRequest) ProtoMessage() {}
+
+func (x *ListSchemesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchemesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchemesRequest) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListSchemesRequest) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+type ListSchemesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status       ListSchemesResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=ListSchemesResponse_Status" json:"status,omitempty"`
+	Schemes      []*SchemeInfo              `protobuf:"bytes,2,rep,name=schemes,proto3" json:"schemes,omitempty"`
+	ErrorCode    ErrorCode                  `protobuf:"varint,3,opt,name=errorCode,proto3,enum=ErrorCode" json:"errorCode,omitempty"`
+	ErrorMessage string                     `protobuf:"bytes,4,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
+}
+
+func (x *ListSchemesResponse) Reset() {
+	*x = ListSchemesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSchemesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchemesResponse) ProtoMessage() {}
+
+func (x *ListSchemesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchemesResponse.ProtoReflect.Descriptor instead.
+func (*ListSchemesResponse) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListSchemesResponse) GetStatus() ListSchemesResponse_Status {
+	if x != nil {
+		return x.Status
+	}
+	return ListSchemesResponse_STATUS_UNSET
+}
+
+func (x *ListSchemesResponse) GetSchemes() []*SchemeInfo {
+	if x != nil {
+		return x.Schemes
+	}
+	return nil
+}
+
+func (x *ListSchemesResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_NO_ERROR
+}
+
+func (x *ListSchemesResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type ExportShareRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Scheme   string `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	KeyId    string `protobuf:"bytes,2,opt,name=keyId,proto3" json:"keyId,omitempty"`
+	Index    uint32 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	Token    []byte `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+	Deadline int64  `protobuf:"varint,5,opt,name=deadline,proto3" json:"deadline,omitempty"`
+}
+
+func (x *ExportShareRequest) Reset() {
+	*x = ExportShareRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportShareRequest) ProtoMessage() {}
+
+func (x *ExportShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportShareRequest.ProtoReflect.Descriptor instead.
+func (*ExportShareRequest) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExportShareRequest) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+func (x *ExportShareRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *ExportShareRequest) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ExportShareRequest) GetToken() []byte {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *ExportShareRequest) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+type ExportShareResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status       ExportShareResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=ExportShareResponse_Status" json:"status,omitempty"`
+	PrivateKey   []byte                     `protobuf:"bytes,2,opt,name=privateKey,proto3" json:"privateKey,omitempty"`
+	ErrorCode    ErrorCode                  `protobuf:"varint,3,opt,name=errorCode,proto3,enum=ErrorCode" json:"errorCode,omitempty"`
+	ErrorMessage string                     `protobuf:"bytes,4,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
+}
+
+func (x *ExportShareResponse) Reset() {
+	*x = ExportShareResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportShareResponse) ProtoMessage() {}
+
+func (x *ExportShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportShareResponse.ProtoReflect.Descriptor instead.
+func (*ExportShareResponse) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExportShareResponse) GetStatus() ExportShareResponse_Status {
+	if x != nil {
+		return x.Status
+	}
+	return ExportShareResponse_STATUS_UNSET
+}
+
+func (x *ExportShareResponse) GetPrivateKey() []byte {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return nil
+}
+
+func (x *ExportShareResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_NO_ERROR
+}
+
+func (x *ExportShareResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type BatchSignRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Scheme   string         `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	Items    []*SignRequest `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Deadline int64          `protobuf:"varint,3,opt,name=deadline,proto3" json:"deadline,omitempty"`
+}
+
+func (x *BatchSignRequest) Reset() {
+	*x = BatchSignRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchSignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSignRequest) ProtoMessage() {}
+
+func (x *BatchSignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSignRequest.ProtoReflect.Descriptor instead.
+func (*BatchSignRequest) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BatchSignRequest) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+func (x *BatchSignRequest) GetItems() []*SignRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchSignRequest) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+type BatchSignResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status       BatchSignResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=BatchSignResponse_Status" json:"status,omitempty"`
+	Items        []*SignResponse          `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	ErrorCode    ErrorCode                `protobuf:"varint,3,opt,name=errorCode,proto3,enum=ErrorCode" json:"errorCode,omitempty"`
+	ErrorMessage string                   `protobuf:"bytes,4,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
+}
+
+func (x *BatchSignResponse) Reset() {
+	*x = BatchSignResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchSignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSignResponse) ProtoMessage() {}
+
+func (x *BatchSignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSignResponse.ProtoReflect.Descriptor instead.
+func (*BatchSignResponse) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BatchSignResponse) GetStatus() BatchSignResponse_Status {
+	if x != nil {
+		return x.Status
+	}
+	return BatchSignResponse_STATUS_UNSET
+}
+
+func (x *BatchSignResponse) GetItems() []*SignResponse {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchSignResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_NO_ERROR
+}
+
+func (x *BatchSignResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type BatchVerifyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Scheme   string           `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	Items    []*VerifyRequest `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Deadline int64            `protobuf:"varint,3,opt,name=deadline,proto3" json:"deadline,omitempty"`
+}
+
+func (x *BatchVerifyRequest) Reset() {
+	*x = BatchVerifyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchVerifyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchVerifyRequest) ProtoMessage() {}
+
+func (x *BatchVerifyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchVerifyRequest.ProtoReflect.Descriptor instead.
+func (*BatchVerifyRequest) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BatchVerifyRequest) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+func (x *BatchVerifyRequest) GetItems() []*VerifyRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchVerifyRequest) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+type BatchVerifyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status       BatchVerifyResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=BatchVerifyResponse_Status" json:"status,omitempty"`
+	Items        []*VerifyResponse          `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	ErrorCode    ErrorCode                  `protobuf:"varint,3,opt,name=errorCode,proto3,enum=ErrorCode" json:"errorCode,omitempty"`
+	ErrorMessage string                     `protobuf:"bytes,4,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
+}
+
+func (x *BatchVerifyResponse) Reset() {
+	*x = BatchVerifyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchVerifyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchVerifyResponse) ProtoMessage() {}
+
+func (x *BatchVerifyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchVerifyResponse.ProtoReflect.Descriptor instead.
+func (*BatchVerifyResponse) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BatchVerifyResponse) GetStatus() BatchVerifyResponse_Status {
+	if x != nil {
+		return x.Status
+	}
+	return BatchVerifyResponse_STATUS_UNSET
+}
+
+func (x *BatchVerifyResponse) GetItems() []*VerifyResponse {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchVerifyResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_NO_ERROR
+}
+
+func (x *BatchVerifyResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type BatchAggregateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Scheme   string              `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	Items    []*AggregateRequest `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Deadline int64               `protobuf:"varint,3,opt,name=deadline,proto3" json:"deadline,omitempty"`
+}
+
+func (x *BatchAggregateRequest) Reset() {
+	*x = BatchAggregateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchAggregateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchAggregateRequest) ProtoMessage() {}
+
+func (x *BatchAggregateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchAggregateRequest.ProtoReflect.Descriptor instead.
+func (*BatchAggregateRequest) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BatchAggregateRequest) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+func (x *BatchAggregateRequest) GetItems() []*AggregateRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchAggregateRequest) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+type BatchAggregateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status       BatchAggregateResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=BatchAggregateResponse_Status" json:"status,omitempty"`
+	Items        []*AggregateResponse          `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	ErrorCode    ErrorCode                     `protobuf:"varint,3,opt,name=errorCode,proto3,enum=ErrorCode" json:"errorCode,omitempty"`
+	ErrorMessage string                        `protobuf:"bytes,4,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
+}
+
+func (x *BatchAggregateResponse) Reset() {
+	*x = BatchAggregateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchAggregateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchAggregateResponse) ProtoMessage() {}
+
+func (x *BatchAggregateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchAggregateResponse.ProtoReflect.Descriptor instead.
+func (*BatchAggregateResponse) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BatchAggregateResponse) GetStatus() BatchAggregateResponse_Status {
+	if x != nil {
+		return x.Status
+	}
+	return BatchAggregateResponse_STATUS_UNSET
+}
+
+func (x *BatchAggregateResponse) GetItems() []*AggregateResponse {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchAggregateResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_NO_ERROR
+}
+
+func (x *BatchAggregateResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type KeyEnvelope struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version     uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Scheme      string `protobuf:"bytes,2,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	T           uint32 `protobuf:"varint,3,opt,name=t,proto3" json:"t,omitempty"`
+	N           uint32 `protobuf:"varint,4,opt,name=n,proto3" json:"n,omitempty"`
+	Index       uint32 `protobuf:"varint,5,opt,name=index,proto3" json:"index,omitempty"`
+	KeyId       string `protobuf:"bytes,6,opt,name=keyId,proto3" json:"keyId,omitempty"`
+	Created     int64  `protobuf:"varint,7,opt,name=created,proto3" json:"created,omitempty"`
+	Key         []byte `protobuf:"bytes,8,opt,name=key,proto3" json:"key,omitempty"`
+	Fingerprint string `protobuf:"bytes,9,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	KeyTag      string `protobuf:"bytes,10,opt,name=keyTag,proto3" json:"keyTag,omitempty"`
+	Proof       []byte `protobuf:"bytes,11,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *KeyEnvelope) Reset() {
+	*x = KeyEnvelope{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyEnvelope) ProtoMessage() {}
+
+func (x *KeyEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyEnvelope.ProtoReflect.Descriptor instead.
+func (*KeyEnvelope) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *KeyEnvelope) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *KeyEnvelope) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+func (x *KeyEnvelope) GetT() uint32 {
+	if x != nil {
+		return x.T
+	}
+	return 0
+}
+
+func (x *KeyEnvelope) GetN() uint32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *KeyEnvelope) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *KeyEnvelope) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *KeyEnvelope) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *KeyEnvelope) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *KeyEnvelope) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *KeyEnvelope) GetKeyTag() string {
+	if x != nil {
+		return x.KeyTag
+	}
+	return ""
+}
+
+func (x *KeyEnvelope) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+var File_crypto_proto protoreflect.FileDescriptor
+
+var file_crypto_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x90,
+	0x01, 0x0a, 0x12, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x48, 0x53, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x0c, 0x0a,
+	0x01, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e,
+	0x65, 0x22, 0x9d, 0x02, 0x0a, 0x13, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x48,
+	0x53, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x47, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x54, 0x48, 0x53, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x20, 0x0a, 0x0b,
+	0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x28,
+	0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x0a, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x6b, 0x65, 0x79, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79,
+	0x49, 0x64, 0x22, 0x2d, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x0c,
+	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x06,
+	0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10,
+	0x02, 0x22, 0x91, 0x01, 0x0a, 0x0b, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b,
+	0x65, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x61,
+	0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x61,
+	0x64, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0xd7, 0x01, 0x0a, 0x0c, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x12, 0x28, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64,
+	0x65, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x2d, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x54,
+	0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02,
+	0x4f, 0x4b, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x02, 0x22,
+	0xd1, 0x01, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62,
+	0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65,
+	0x79, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07,
+	0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x73, 0x67, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x6d, 0x73, 0x67, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x73, 0x22, 0xbd, 0x01, 0x0a, 0x0e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x28, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43,
+	0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a, 0x2e, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x22, 0x0a, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x2d, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10,
+	0x0a, 0x0c, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10, 0x00,
+	0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x10, 0x02, 0x22, 0xee, 0x01, 0x0a, 0x10, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x01, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x01, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x73, 0x67, 0x73,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x6d, 0x73, 0x67, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x73, 0x22, 0xe1, 0x01, 0x0a, 0x11, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x41, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x28, 0x0a, 0x09, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a,
+	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x2d, 0x0a, 0x06, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e,
+	0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x01, 0x12, 0x09, 0x0a,
+	0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x02, 0x22, 0xa4, 0x01, 0x0a, 0x13, 0x55, 0x6e, 0x73,
+	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x05, 0x2e,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x28, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0xd4, 0x01, 0x0a, 0x0a, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0a, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x6b, 0x65, 0x79, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x07, 0x6b, 0x65, 0x79, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x36, 0x0a, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x41, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67,
+	0x79, 0x52, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x22, 0x30, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63,
+	0x68, 0x65, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0xee, 0x01, 0x0a, 0x13, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x33, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1b, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x07, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x07, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x09,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0a, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x2d, 0x0a, 0x06, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55,
+	0x4e, 0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x01, 0x12, 0x09,
+	0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x02, 0x22, 0x8a, 0x01, 0x0a, 0x12, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6b, 0x65, 0x79, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65,
+	0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65,
+	0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0xe7, 0x01, 0x0a, 0x13, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b,
+	0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65,
+	0x4b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f,
+	0x64, 0x65, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a,
+	0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x2d, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x0c, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x06, 0x0a,
+	0x02, 0x4f, 0x4b, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x02,
+	0x22, 0x6a, 0x0a, 0x10, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x05,
+	0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x53, 0x69,
+	0x67, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0xe8, 0x01, 0x0a,
+	0x11, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x19, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x28, 0x0a, 0x09, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a, 0x2e,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x2d, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53,
+	0x45, 0x54, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05,
+	0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x02, 0x22, 0x6e, 0x0a, 0x12, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0xee, 0x01, 0x0a, 0x13, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x33, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1b, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x28, 0x0a, 0x09, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a,
+	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x2d, 0x0a, 0x06, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e,
+	0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x01, 0x12, 0x09, 0x0a,
+	0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x02, 0x22, 0x74, 0x0a, 0x15, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x69, 0x74, 0x65,
+	0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x69, 0x74, 0x65,
+	0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0xf7,
+	0x01, 0x0a, 0x16, 0x42, 0x61, 0x74, 0x63, 0x68, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x28, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x28, 0x0a, 0x09, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a,
+	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x2d, 0x0a, 0x06, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e,
+	0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x01, 0x12, 0x09, 0x0a,
+	0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x02, 0x22, 0x83, 0x02, 0x0a, 0x0b, 0x4b, 0x65, 0x79,
+	0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x01, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05,
+	0x6b, 0x65, 0x79, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79,
+	0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x20,
+	0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x54, 0x61, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6b, 0x65, 0x79, 0x54, 0x61, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x2a, 0xf9,
+	0x03, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41, 0x55,
+	0x4c, 0x54, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x49, 0x47, 0x4e, 0x5f, 0x52, 0x45, 0x51,
+	0x55, 0x45, 0x53, 0x54, 0x10, 0x64, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x49, 0x47, 0x4e, 0x5f, 0x52,
+	0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x10, 0x65, 0x12, 0x16, 0x0a, 0x12, 0x42, 0x41, 0x54,
+	0x43, 0x48, 0x5f, 0x53, 0x49, 0x47, 0x4e, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10,
+	0x6e, 0x12, 0x17, 0x0a, 0x13, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x53, 0x49, 0x47, 0x4e, 0x5f,
+	0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x10, 0x6f, 0x12, 0x13, 0x0a, 0x0e, 0x56, 0x45,
+	0x52, 0x49, 0x46, 0x59, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0xc8, 0x01, 0x12,
+	0x14, 0x0a, 0x0f, 0x56, 0x45, 0x52, 0x49, 0x46, 0x59, 0x5f, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e,
+	0x53, 0x45, 0x10, 0xc9, 0x01, 0x12, 0x19, 0x0a, 0x14, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x56,
+	0x45, 0x52, 0x49, 0x46, 0x59, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0xd2, 0x01,
+	0x12, 0x1a, 0x0a, 0x15, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x56, 0x45, 0x52, 0x49, 0x46, 0x59,
+	0x5f, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x10, 0xd3, 0x01, 0x12, 0x16, 0x0a, 0x11,
+	0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x45, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53,
+	0x54, 0x10, 0xac, 0x02, 0x12, 0x17, 0x0a, 0x12, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54,
+	0x45, 0x5f, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x10, 0xad, 0x02, 0x12, 0x1c, 0x0a,
+	0x17, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x45,
+	0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0xb6, 0x02, 0x12, 0x1d, 0x0a, 0x18, 0x42,
+	0x41, 0x54, 0x43, 0x48, 0x5f, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x45, 0x5f, 0x52,
+	0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x10, 0xb7, 0x02, 0x12, 0x19, 0x0a, 0x14, 0x47, 0x45,
+	0x4e, 0x45, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x54, 0x48, 0x53, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45,
+	0x53, 0x54, 0x10, 0x90, 0x03, 0x12, 0x1a, 0x0a, 0x15, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x41, 0x54,
+	0x45, 0x5f, 0x54, 0x48, 0x53, 0x5f, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x10, 0x91,
+	0x03, 0x12, 0x19, 0x0a, 0x14, 0x45, 0x58, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x53, 0x48, 0x41, 0x52,
+	0x45, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0xf4, 0x03, 0x12, 0x1a, 0x0a, 0x15,
+	0x45, 0x58, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x53, 0x48, 0x41, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x53,
+	0x50, 0x4f, 0x4e, 0x53, 0x45, 0x10, 0xf5, 0x03, 0x12, 0x19, 0x0a, 0x14, 0x4c, 0x49, 0x53, 0x54,
+	0x5f, 0x53, 0x43, 0x48, 0x45, 0x4d, 0x45, 0x53, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54,
+	0x10, 0xd8, 0x04, 0x12, 0x1a, 0x0a, 0x15, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x53, 0x43, 0x48, 0x45,
+	0x4d, 0x45, 0x53, 0x5f, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x10, 0xd9, 0x04, 0x12,
+	0x19, 0x0a, 0x14, 0x55, 0x4e, 0x53, 0x55, 0x50, 0x50, 0x4f, 0x52, 0x54, 0x45, 0x44, 0x5f, 0x52,
+	0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x10, 0x84, 0x07, 0x2a, 0x9a, 0x02, 0x0a, 0x09, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x4f, 0x5f, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x4d, 0x41, 0x4c, 0x46, 0x4f, 0x52,
+	0x4d, 0x45, 0x44, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0x01, 0x12, 0x14, 0x0a,
+	0x10, 0x42, 0x41, 0x44, 0x5f, 0x4b, 0x45, 0x59, 0x5f, 0x45, 0x4e, 0x43, 0x4f, 0x44, 0x49, 0x4e,
+	0x47, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x4e, 0x4f, 0x55, 0x47,
+	0x48, 0x5f, 0x53, 0x48, 0x41, 0x52, 0x45, 0x53, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x4e,
+	0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x53, 0x48, 0x41, 0x52, 0x45, 0x10, 0x04, 0x12, 0x15, 0x0a,
+	0x11, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x53, 0x49, 0x47, 0x4e, 0x41, 0x54, 0x55,
+	0x52, 0x45, 0x10, 0x05, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x55, 0x50, 0x50, 0x4f, 0x52,
+	0x54, 0x45, 0x44, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41,
+	0x4c, 0x10, 0x07, 0x12, 0x11, 0x0a, 0x0d, 0x4b, 0x45, 0x59, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46,
+	0x4f, 0x55, 0x4e, 0x44, 0x10, 0x08, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x45, 0x52, 0x4d, 0x49, 0x53,
+	0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x45, 0x4e, 0x49, 0x45, 0x44, 0x10, 0x09, 0x12, 0x0e, 0x0a,
+	0x0a, 0x4b, 0x45, 0x59, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x53, 0x10, 0x0a, 0x12, 0x15, 0x0a,
+	0x11, 0x44, 0x45, 0x41, 0x44, 0x4c, 0x49, 0x4e, 0x45, 0x5f, 0x45, 0x58, 0x43, 0x45, 0x45, 0x44,
+	0x45, 0x44, 0x10, 0x0b, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f,
+	0x50, 0x52, 0x4f, 0x4f, 0x46, 0x10, 0x0c, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x56, 0x45, 0x52, 0x4c,
+	0x4f, 0x41, 0x44, 0x45, 0x44, 0x10, 0x0d, 0x2a, 0x53, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x13, 0x0a, 0x0f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x49, 0x47,
+	0x4e, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x56, 0x45, 0x52, 0x49, 0x46, 0x59, 0x10, 0x02, 0x12,
+	0x0d, 0x0a, 0x09, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x45, 0x10, 0x03, 0x12, 0x0c,
+	0x0a, 0x08, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x41, 0x54, 0x45, 0x10, 0x04, 0x2a, 0x56, 0x0a, 0x13,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x61, 0x74,
+	0x65, 0x67, 0x79, 0x12, 0x12, 0x0a, 0x0e, 0x4e, 0x4f, 0x5f, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x4f, 0x52, 0x4d, 0x41,
+	0x4c, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x50, 0x54, 0x49, 0x4d, 0x49, 0x53, 0x54, 0x49,
+	0x43, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x45, 0x53, 0x53, 0x49, 0x4d, 0x49, 0x53, 0x54,
+	0x49, 0x43, 0x10, 0x03, 0x42, 0x1d, 0x0a, 0x15, 0x73, 0x61, 0x77, 0x74, 0x6f, 0x6f, 0x74, 0x68,
+	0x2e, 0x73, 0x64, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x50, 0x01, 0x5a,
+	0x02, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_crypto_proto_rawDescOnce sync.Once
+	file_crypto_proto_rawDescData = file_crypto_proto_rawDesc
+)
+
+func file_crypto_proto_rawDescGZIP() []byte {
+	file_crypto_proto_rawDescOnce.Do(func() {
+		file_crypto_proto_rawDescData = protoimpl.X.CompressGZIP(file_crypto_proto_rawDescData)
+	})
+	return file_crypto_proto_rawDescData
+}
+
+var file_crypto_proto_enumTypes = make([]protoimpl.EnumInfo, 13)
+var file_crypto_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_crypto_proto_goTypes = []interface{}{
+	(Type)(0),                          // 0: Type
+	(ErrorCode)(0),                     // 1: ErrorCode
+	(Operation)(0),                     // 2: Operation
+	(AggregationStrategy)(0),           // 3: AggregationStrategy
+	(GenerateTHSResponse_Status)(0),    // 4: GenerateTHSResponse.Status
+	(SignResponse_Status)(0),           // 5: SignResponse.Status
+	(VerifyResponse_Status)(0),         // 6: VerifyResponse.Status
+	(AggregateResponse_Status)(0),      // 7: AggregateResponse.Status
+	(ListSchemesResponse_Status)(0),    // 8: ListSchemesResponse.Status
+	(ExportShareResponse_Status)(0),    // 9: ExportShareResponse.Status
+	(BatchSignResponse_Status)(0),      // 10: BatchSignResponse.Status
+	(BatchVerifyResponse_Status)(0),    // 11: BatchVerifyResponse.Status
+	(BatchAggregateResponse_Status)(0), // 12: BatchAggregateResponse.Status
+	(*GenerateTHSRequest)(nil),         // 13: GenerateTHSRequest
+	(*GenerateTHSResponse)(nil),        // 14: GenerateTHSResponse
+	(*SignRequest)(nil),                // 15: SignRequest
+	(*SignResponse)(nil),               // 16: SignResponse
+	(*VerifyRequest)(nil),              // 17: VerifyRequest
+	(*VerifyResponse)(nil),             // 18: VerifyResponse
+	(*AggregateRequest)(nil),           // 19: AggregateRequest
+	(*AggregateResponse)(nil),          // 20: AggregateResponse
+	(*UnsupportedResponse)(nil),        // 21: UnsupportedResponse
+	(*SchemeInfo)(nil),                 // 22: SchemeInfo
+	(*ListSchemesRequest)(nil),         // 23: ListSchemesRequest
+	(*ListSchemesResponse)(nil),        // 24: ListSchemesResponse
+	(*ExportShareRequest)(nil),         // 25: ExportShareRequest
+	(*ExportShareResponse)(nil),        // 26: ExportShareResponse
+	(*BatchSignRequest)(nil),           // 27: BatchSignRequest
+	(*BatchSignResponse)(nil),          // 28: BatchSignResponse
+	(*BatchVerifyRequest)(nil),         // 29: BatchVerifyRequest
+	(*BatchVerifyResponse)(nil),        // 30: BatchVerifyResponse
+	(*BatchAggregateRequest)(nil),      // 31: BatchAggregateRequest
+	(*BatchAggregateResponse)(nil),     // 32: BatchAggregateResponse
+	(*KeyEnvelope)(nil),                // 33: KeyEnvelope
+}
+var file_crypto_proto_depIdxs = []int32{
+	4,  // 0: GenerateTHSResponse.status:type_name -> GenerateTHSResponse.Status
+	1,  // 1: GenerateTHSResponse.errorCode:type_name -> ErrorCode
+	5,  // 2: SignResponse.status:type_name -> SignResponse.Status
+	1,  // 3: SignResponse.errorCode:type_name -> ErrorCode
+	6,  // 4: VerifyResponse.status:type_name -> VerifyResponse.Status
+	1,  // 5: VerifyResponse.errorCode:type_name -> ErrorCode
+	7,  // 6: AggregateResponse.status:type_name -> AggregateResponse.Status
+	1,  // 7: AggregateResponse.errorCode:type_name -> ErrorCode
+	0,  // 8: UnsupportedResponse.requestType:type_name -> Type
+	1,  // 9: UnsupportedResponse.errorCode:type_name -> ErrorCode
+	2,  // 10: SchemeInfo.operations:type_name -> Operation
+	3,  // 11: SchemeInfo.aggregation:type_name -> AggregationStrategy
+	8,  // 12: ListSchemesResponse.status:type_name -> ListSchemesResponse.Status
+	22, // 13: ListSchemesResponse.schemes:type_name -> SchemeInfo
+	1,  // 14: ListSchemesResponse.errorCode:type_name -> ErrorCode
+	9,  // 15: ExportShareResponse.status:type_name -> ExportShareResponse.Status
+	1,  // 16: ExportShareResponse.errorCode:type_name -> ErrorCode
+	15, // 17: BatchSignRequest.items:type_name -> SignRequest
+	10, // 18: BatchSignResponse.status:type_name -> BatchSignResponse.Status
+	16, // 19: BatchSignResponse.items:type_name -> SignResponse
+	1,  // 20: BatchSignResponse.errorCode:type_name -> ErrorCode
+	17, // 21: BatchVerifyRequest.items:type_name -> VerifyRequest
+	11, // 22: BatchVerifyResponse.status:type_name -> BatchVerifyResponse.Status
+	18, // 23: BatchVerifyResponse.items:type_name -> VerifyResponse
+	1,  // 24: BatchVerifyResponse.errorCode:type_name -> ErrorCode
+	19, // 25: BatchAggregateRequest.items:type_name -> AggregateRequest
+	12, // 26: BatchAggregateResponse.status:type_name -> BatchAggregateResponse.Status
+	20, // 27: BatchAggregateResponse.items:type_name -> AggregateResponse
+	1,  // 28: BatchAggregateResponse.errorCode:type_name -> ErrorCode
+	29, // [29:29] is the sub-list for method output_type
+	29, // [29:29] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
+}
+
+func init() { file_crypto_proto_init() }
+func file_crypto_proto_init() {
+	if File_crypto_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_crypto_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerateTHSRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerateTHSResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyRequest); i {
+			case 0:
 				return &v.state
 			case 1:
 				return &v.sizeCache
@@ -1020,14 +2901,170 @@ func file_crypto_proto_init() {
 				return nil
 			}
 		}
+		file_crypto_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnsupportedResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SchemeInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSchemesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSchemesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportShareRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportShareResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchSignRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchSignResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchVerifyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchVerifyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchAggregateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchAggregateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyEnvelope); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_crypto_proto_rawDesc,
-			NumEnums:      5,
-			NumMessages:   8,
+			NumEnums:      13,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
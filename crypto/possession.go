@@ -0,0 +1,94 @@
+package crypto
+
+import (
+	"fmt"
+)
+
+//PossessionProver is implemented by handlers of schemes aggregating the
+//signatures of independent keys over the same message, such as BLS.
+//A key proves the possession of its private key before being aggregated,
+//otherwise a rogue key made from the keys of other signers could forge
+//their multi-signatures alone.
+type PossessionProver interface {
+	//ProvePossession returns the proof of possession of priv
+	ProvePossession(priv PrivateKey) (proof []byte, err error)
+	//VerifyPossession verifies a proof of possession of the private key of pub
+	VerifyPossession(pub PublicKey, proof []byte) error
+}
+
+//ProvenKey is a public key along with the proof of possession
+//of its private key. It is encoded as the public key alone.
+type ProvenKey struct {
+	PublicKey
+	Proof []byte
+}
+
+//ProofOf returns the proof carried by pub, if it is a ProvenKey
+//or the envelope of a key stored along with its proof
+func ProofOf(pub PublicKey) []byte {
+	if proven, ok := pub.(ProvenKey); ok {
+		return proven.Proof
+	}
+	if proven, ok := pub.(*ProvenKey); ok {
+		if proven == nil {
+			return nil
+		}
+		return proven.Proof
+	}
+	if env, ok := pub.(*KeyEnvelope); ok {
+		if env == nil {
+			return nil
+		}
+		return env.Proof
+	}
+	if pub == nil {
+		return nil
+	}
+
+	data, err := pub.MarshalBinary()
+	if err != nil || !IsKeyEnvelope(data) {
+		return nil
+	}
+
+	env, err := UnmarshalKeyEnvelope(data)
+	if err != nil {
+		return nil
+	}
+	return env.Proof
+}
+
+//checkPossession verifies that every key of a multi-signature request comes
+//with a valid proof of possession, if the scheme requires them.
+//Keys without a proof in the request are proven by the one stored
+//in their envelope, if any.
+func (h *handlerDecorator) checkPossession(pubs []PublicKey, proofs [][]byte, stored [][]byte) error {
+	prover, ok := h.THSignerHandler.(PossessionProver)
+	if !ok {
+		return nil
+	}
+
+	if len(proofs) == 0 {
+		proofs = make([][]byte, len(pubs))
+	}
+
+	if len(proofs) != len(pubs) {
+		return fmt.Errorf("%w: %v proofs for %v public keys", ErrInvalidProof, len(proofs), len(pubs))
+	}
+
+	for i, pub := range pubs {
+		proof := proofs[i]
+		if len(proof) == 0 && i < len(stored) {
+			proof = stored[i]
+		}
+
+		if len(proof) == 0 {
+			return fmt.Errorf("%w: public key %v has no proof", ErrInvalidProof, i)
+		}
+
+		if err := prover.VerifyPossession(pub, proof); err != nil {
+			return fmt.Errorf("public key %v: %w", i, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+//This mock proves the possession of a key with "pop:" followed by the key
+type provingHandler struct {
+	multiSignerHandler
+}
+
+func (m provingHandler) ProvePossession(priv PrivateKey) ([]byte, error) {
+	data, _ := priv.MarshalBinary()
+	return append([]byte("pop:"), data...), nil
+}
+
+func (m provingHandler) VerifyPossession(pub PublicKey, proof []byte) error {
+	data, _ := pub.MarshalBinary()
+	if string(proof) != "pop:"+string(data) {
+		return fmt.Errorf("%w: not a proof of %s", ErrInvalidProof, data)
+	}
+	return nil
+}
+
+func TestAggregateRequiresPossession(test *testing.T) {
+	h := &handlerDecorator{provingHandler{}, &SignerProcessor{}}
+	pubs := [][]byte{[]byte("pub1"), []byte("pub2")}
+	shares := [][]byte{[]byte("s1"), []byte("s2")}
+	proofs := [][]byte{[]byte("pop:pub1"), []byte("pop:pub2")}
+
+	resp := aggregateWith(test, h, &pb.AggregateRequest{Share: shares, Digest: []byte("msg"), PubKeys: pubs, Proofs: proofs})
+	assert.Equal(test, pb.AggregateResponse_OK, resp.Status)
+
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: shares, Digest: []byte("msg"), PubKeys: pubs})
+	assert.Equal(test, pb.ErrorCode_INVALID_PROOF, resp.ErrorCode)
+
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: shares, Digest: []byte("msg"), PubKeys: pubs, Proofs: proofs[:1]})
+	assert.Equal(test, pb.ErrorCode_INVALID_PROOF, resp.ErrorCode)
+
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: shares, Digest: []byte("msg"), PubKeys: pubs, Proofs: [][]byte{proofs[0], nil}})
+	assert.Equal(test, pb.ErrorCode_INVALID_PROOF, resp.ErrorCode)
+
+	//proofs of other keys are refused
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: shares, Msgs: [][]byte{[]byte("m1"), []byte("m2")}, PubKeys: pubs, Proofs: [][]byte{proofs[1], proofs[0]}})
+	assert.Equal(test, pb.ErrorCode_INVALID_PROOF, resp.ErrorCode)
+}
+
+func TestVerifyRequiresPossession(test *testing.T) {
+	h := &handlerDecorator{provingHandler{}, &SignerProcessor{}}
+	pubs := [][]byte{[]byte("pub1"), []byte("pub2")}
+
+	resp := verifyWith(test, h, &pb.VerifyRequest{Signature: []byte("s1s2"), Msg: []byte("msg"), PubKeys: pubs, Proofs: [][]byte{[]byte("pop:pub1"), []byte("pop:pub2")}})
+	assert.Equal(test, pb.VerifyResponse_OK, resp.Status)
+
+	resp = verifyWith(test, h, &pb.VerifyRequest{Signature: []byte("s1s2"), Msg: []byte("msg"), PubKeys: pubs, Proofs: [][]byte{[]byte("pop:pub1"), []byte("pop:pub1")}})
+	assert.Equal(test, pb.ErrorCode_INVALID_PROOF, resp.ErrorCode)
+}
+
+func TestProofOf(test *testing.T) {
+	proof := []byte("pop:pub")
+
+	assert.Equal(test, proof, ProofOf(ProvenKey{mockKey("pub"), proof}))
+	assert.Equal(test, proof, ProofOf(&ProvenKey{mockKey("pub"), proof}))
+	assert.Nil(test, ProofOf(mockKey("pub")))
+	assert.Nil(test, ProofOf((*ProvenKey)(nil)))
+
+	err := provingHandler{}.VerifyPossession(mockKey("pub"), []byte("pop:other"))
+	assert.True(test, errors.Is(err, ErrInvalidProof))
+	assert.Equal(test, pb.ErrorCode_INVALID_PROOF, CodeOf(err, pb.ErrorCode_INTERNAL))
+}
+
+func TestPossessionStoredInEnvelope(test *testing.T) {
+	h := &handlerDecorator{provingHandler{}, &SignerProcessor{}}
+
+	pubEnv, _, err := h.sealKeys(&pb.GenerateTHSRequest{T: 1, N: 1}, "Mock_1_1", mockKey("pub1"), PrivateKeyList{mockKey("pub1")})
+	require.Nil(test, err)
+	assert.Equal(test, []byte("pop:pub1"), pubEnv.Proof)
+	assert.Equal(test, []byte("pop:pub1"), ProofOf(pubEnv))
+
+	env1, _ := pubEnv.MarshalBinary()
+	assert.Equal(test, []byte("pop:pub1"), ProofOf(mockKey(env1)))
+
+	forged, _ := NewKeyEnvelope("Mock", 1, 1, 0, "Mock_1_1", mockKey("pub2"))
+	forged.Proof = []byte("pop:pub1")
+	env2, _ := forged.MarshalBinary()
+
+	shares := [][]byte{[]byte("s1"), []byte("s2")}
+
+	//keys are proven by the proofs stored with them
+	resp := aggregateWith(test, h, &pb.AggregateRequest{Share: shares[:1], Digest: []byte("msg"), PubKeys: [][]byte{env1}})
+	assert.Equal(test, pb.AggregateResponse_OK, resp.Status)
+
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: shares, Digest: []byte("msg"), PubKeys: [][]byte{env1, env2}})
+	assert.Equal(test, pb.ErrorCode_INVALID_PROOF, resp.ErrorCode)
+
+	//or by the ones sent along with them
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: shares, Digest: []byte("msg"), PubKeys: [][]byte{env1, env2}, Proofs: [][]byte{nil, []byte("pop:pub2")}})
+	assert.Equal(test, pb.AggregateResponse_OK, resp.Status)
+
+	resp = aggregateWith(test, h, &pb.AggregateRequest{Share: shares, Digest: []byte("msg"), PubKeys: [][]byte{env1, []byte("pub2")}})
+	assert.Equal(test, pb.ErrorCode_INVALID_PROOF, resp.ErrorCode)
+
+	verified := verifyWith(test, h, &pb.VerifyRequest{Signature: []byte("s1"), Msg: []byte("msg"), PubKeys: [][]byte{env1}})
+	assert.Equal(test, pb.VerifyResponse_OK, verified.Status)
+}
@@ -1,25 +1,129 @@
 package crypto
 
 import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
 	"github.com/ipfs/go-log"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
 	"github.com/jffp113/go-util/messaging/routerdealerhandlers/processor"
 )
 
 var logger = log.Logger("signer_processor")
 
 type SignerProcessor struct {
-	proc *processor.HandlerProcessor
+	proc   *processor.HandlerProcessor
+	panics uint64
+
+	lock     sync.RWMutex
+	handlers []THSignerHandler
+
+	keys          KeyStore
+	rejectRawKeys bool
+	exportToken   []byte
+
+	batchWorkers int
+	maxBatchSize int
+
+	timeouts map[Operation]time.Duration
+
+	abandoned    int64
+	maxAbandoned int
+
+	//reserved are the key names being written by key generations
+	reserving sync.Mutex
+	reserved  map[string]bool
 }
 
-func NewSignerProcessor(uri string) *SignerProcessor {
-	return &SignerProcessor{processor.NewHandlerProcessor(uri)}
+//DefaultMaxAbandoned is how many requests given up at their deadline may
+//still run in the background when WithMaxAbandoned is not given
+const DefaultMaxAbandoned = 64
+
+func NewSignerProcessor(uri string, opts ...ProcessorOption) *SignerProcessor {
+	p := &SignerProcessor{proc: processor.NewHandlerProcessor(uri)}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.proc.AddHandler(&discoveryHandler{p})
+	return p
 }
 
 func (self *SignerProcessor) AddHandler(handler THSignerHandler) {
-	self.proc.AddHandler(&handlerDecorator{handler})
+	self.lock.Lock()
+	self.handlers = append(self.handlers, handler)
+	self.lock.Unlock()
+
+	self.proc.AddHandler(&handlerDecorator{handler, self})
+}
+
+//Schemes describes every scheme added to the processor
+func (self *SignerProcessor) Schemes() []SchemeInfo {
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+
+	schemes := make([]SchemeInfo, 0, len(self.handlers))
+	for _, h := range self.handlers {
+		schemes = append(schemes, DescribeScheme(h))
+	}
+	return schemes
+}
+
+//requestContext bounds a request of type msgType by its deadline and by
+//the timeout configured for its operation, whichever comes first.
+//Batches of items get the timeout once for every round of items
+//the batch workers process.
+func (self *SignerProcessor) requestContext(msgType pb.Type, req request) (context.Context, context.CancelFunc) {
+	ctx, cancel := withRequestDeadline(context.Background(), req.GetDeadline())
+
+	op, ok := operationOf(msgType)
+	timeout := self.timeouts[op]
+	if !ok || timeout <= 0 {
+		return ctx, cancel
+	}
+
+	if items := batchItems(req); items > 0 {
+		workers := self.workers()
+		rounds := (items + workers - 1) / workers
+		timeout *= time.Duration(rounds)
+	}
+
+	ctx, cancelTimeout := context.WithTimeout(ctx, timeout)
+	return ctx, func() {
+		cancelTimeout()
+		cancel()
+	}
+}
+
+//workers returns how many items of a batch are processed concurrently
+func (self *SignerProcessor) workers() int {
+	if self.batchWorkers <= 0 {
+		return runtime.NumCPU()
+	}
+	return self.batchWorkers
+}
+
+//AbandonedRequests returns how many requests were given up at their
+//deadline while their handler is still running
+func (self *SignerProcessor) AbandonedRequests() int64 {
+	return atomic.LoadInt64(&self.abandoned)
+}
+
+func (self *SignerProcessor) maxAbandonedRequests() int64 {
+	if self.maxAbandoned <= 0 {
+		return DefaultMaxAbandoned
+	}
+	return int64(self.maxAbandoned)
+}
+
+//RecoveredPanics returns how many requests panicked inside
+//a handler and were answered with an error instead.
+func (self *SignerProcessor) RecoveredPanics() uint64 {
+	return atomic.LoadUint64(&self.panics)
 }
 
 func (self *SignerProcessor) Start() error {
 	return self.proc.Start()
 }
-
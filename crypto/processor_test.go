@@ -0,0 +1,59 @@
+package crypto
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+//panicSignerHandler panics with the given value on every operation
+type panicSignerHandler struct {
+	mockSignerHandler
+	value interface{}
+}
+
+func (m panicSignerHandler) Sign(digest []byte, key PrivateKey) ([]byte, error) {
+	panic(m.value)
+}
+
+func (m panicSignerHandler) Aggregate(share [][]byte, digest []byte, key PublicKey, t, n int) ([]byte, error) {
+	panic(m.value)
+}
+
+func TestHandlerRecoversFromPanic(test *testing.T) {
+	p := &SignerProcessor{}
+	h := handlerDecorator{panicSignerHandler{value: "boom"}, p}
+
+	req, _ := proto.Marshal(&pb.SignRequest{Scheme: "Mock", Digest: []byte("msg")})
+	respBytes, respType := h.Handle(req, int32(pb.Type_SIGN_REQUEST))
+	require.Equal(test, int32(pb.Type_SIGN_RESPONSE), respType)
+
+	resp := pb.SignResponse{}
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	assert.Equal(test, pb.SignResponse_ERROR, resp.Status)
+	assert.Equal(test, pb.ErrorCode_INTERNAL, resp.ErrorCode)
+	assert.Equal(test, uint64(1), p.RecoveredPanics())
+
+	//the decorator keeps serving requests after a panic
+	respBytes, _ = h.Handle(req, int32(pb.Type_SIGN_REQUEST))
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	assert.Equal(test, pb.SignResponse_ERROR, resp.Status)
+	assert.Equal(test, uint64(2), p.RecoveredPanics())
+}
+
+func TestHandlerRecoversFromUnsupportedPanic(test *testing.T) {
+	p := &SignerProcessor{}
+	h := handlerDecorator{panicSignerHandler{value: ErrUnsupported}, p}
+
+	req, _ := proto.Marshal(&pb.AggregateRequest{Scheme: "Mock"})
+	respBytes, respType := h.Handle(req, int32(pb.Type_AGGREGATE_REQUEST))
+	require.Equal(test, int32(pb.Type_AGGREGATE_RESPONSE), respType)
+
+	resp := pb.AggregateResponse{}
+	require.Nil(test, proto.Unmarshal(respBytes, &resp))
+	assert.Equal(test, pb.AggregateResponse_ERROR, resp.Status)
+	assert.Equal(test, pb.ErrorCode_UNSUPPORTED, resp.ErrorCode)
+	assert.Equal(test, uint64(1), p.RecoveredPanics())
+}
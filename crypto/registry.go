@@ -0,0 +1,189 @@
+package crypto
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+//Descriptor identifies a scheme by its structure rather than its name
+type Descriptor struct {
+	Family      string
+	KeySize     int
+	Aggregation AggregationStrategy
+}
+
+var aggregationSuffixes = map[AggregationStrategy]string{
+	OptimisticAggregation:  "Optimistic",
+	PessimisticAggregation: "Pessimistic",
+}
+
+//Name returns the scheme name handlers of d are known by,
+//such as TBLS256, TRSA1024Optimistic or RSA2048
+func (d Descriptor) Name() string {
+	name := d.Family
+	if d.KeySize > 0 {
+		name += strconv.Itoa(d.KeySize)
+	}
+	return name + aggregationSuffixes[d.Aggregation]
+}
+
+//SharesKeysWith reports whether keys generated for d can be used with other.
+//Schemes of the same family and key size only differ in how they aggregate.
+func (d Descriptor) SharesKeysWith(other Descriptor) bool {
+	return d.Family == other.Family && d.KeySize == other.KeySize
+}
+
+func (d Descriptor) String() string {
+	s := fmt.Sprintf("%v: %v family", d.Name(), d.Family)
+	if d.KeySize > 0 {
+		s += fmt.Sprintf(", %v bit keys", d.KeySize)
+	}
+	if d.Aggregation != NoAggregation {
+		s += fmt.Sprintf(", %v aggregation", d.Aggregation)
+	}
+	return s
+}
+
+//HandlerFactory builds a new handler of a registered scheme
+type HandlerFactory func() THSignerHandler
+
+type registration struct {
+	descriptor Descriptor
+	factory    HandlerFactory
+}
+
+//Registry maps scheme names and descriptors to the handlers serving them
+type Registry struct {
+	lock    sync.RWMutex
+	schemes map[string]registration
+}
+
+func NewRegistry() *Registry {
+	return &Registry{schemes: make(map[string]registration)}
+}
+
+//Register adds the scheme described by d, served by handlers built by factory.
+//The handlers must be named after d.
+func (r *Registry) Register(d Descriptor, factory HandlerFactory) error {
+	name := d.Name()
+	if d.Family == "" || factory == nil {
+		return fmt.Errorf("incomplete registration of scheme %q", name)
+	}
+
+	if handlerName := factory().SchemeName(); handlerName != name {
+		return fmt.Errorf("factory of scheme %v builds handlers of %v", name, handlerName)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.schemes[name]; ok {
+		return fmt.Errorf("scheme %v is already registered", name)
+	}
+
+	r.schemes[name] = registration{d, factory}
+	return nil
+}
+
+//Lookup returns a new handler of the scheme registered under name
+func (r *Registry) Lookup(name string) (THSignerHandler, error) {
+	r.lock.RLock()
+	reg, ok := r.schemes[name]
+	r.lock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown scheme %v", ErrUnsupported, name)
+	}
+
+	return reg.factory(), nil
+}
+
+//LookupDescriptor returns a new handler of the scheme described by d
+func (r *Registry) LookupDescriptor(d Descriptor) (THSignerHandler, error) {
+	return r.Lookup(d.Name())
+}
+
+//Describe returns the descriptor of the scheme registered under name
+func (r *Registry) Describe(name string) (Descriptor, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	reg, ok := r.schemes[name]
+	return reg.descriptor, ok
+}
+
+//SharesKeys reports whether keys of scheme can be used with other,
+//that is if they are the same scheme or registered schemes that
+//only differ in how they aggregate
+func (r *Registry) SharesKeys(scheme, other string) bool {
+	if scheme == other {
+		return true
+	}
+
+	d, ok := r.Describe(scheme)
+	if !ok {
+		return false
+	}
+
+	o, ok := r.Describe(other)
+	return ok && d.SharesKeysWith(o)
+}
+
+//Descriptors lists the registered schemes sorted by name
+func (r *Registry) Descriptors() []Descriptor {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	descriptors := make([]Descriptor, 0, len(r.schemes))
+	for _, reg := range r.schemes {
+		descriptors = append(descriptors, reg.descriptor)
+	}
+
+	sort.Slice(descriptors, func(i, j int) bool {
+		return descriptors[i].Name() < descriptors[j].Name()
+	})
+	return descriptors
+}
+
+//Handlers returns a new handler of every registered scheme, sorted by name
+func (r *Registry) Handlers() []THSignerHandler {
+	descriptors := r.Descriptors()
+
+	handlers := make([]THSignerHandler, 0, len(descriptors))
+	for _, d := range descriptors {
+		if h, err := r.LookupDescriptor(d); err == nil {
+			handlers = append(handlers, h)
+		}
+	}
+	return handlers
+}
+
+//DefaultRegistry holds the schemes registered by handler packages
+//when they are imported
+var DefaultRegistry = NewRegistry()
+
+//Register adds a scheme to the DefaultRegistry.
+//It is meant to be called from the init function of handler packages
+//and panics if the scheme cannot be registered.
+func Register(d Descriptor, factory HandlerFactory) {
+	if err := DefaultRegistry.Register(d, factory); err != nil {
+		panic(err)
+	}
+}
+
+//Lookup returns a new handler of the scheme registered under name in the DefaultRegistry
+func Lookup(name string) (THSignerHandler, error) {
+	return DefaultRegistry.Lookup(name)
+}
+
+//LookupDescriptor returns a new handler of the scheme described by d in the DefaultRegistry
+func LookupDescriptor(d Descriptor) (THSignerHandler, error) {
+	return DefaultRegistry.LookupDescriptor(d)
+}
+
+//Descriptors lists the schemes of the DefaultRegistry sorted by name
+func Descriptors() []Descriptor {
+	return DefaultRegistry.Descriptors()
+}
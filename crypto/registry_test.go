@@ -0,0 +1,87 @@
+package crypto
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+//namedHandler is a mock handler of any scheme
+type namedHandler struct {
+	mockSignerHandler
+	name string
+}
+
+func (m namedHandler) SchemeName() string {
+	return m.name
+}
+
+func namedFactory(name string) HandlerFactory {
+	return func() THSignerHandler {
+		return namedHandler{name: name}
+	}
+}
+
+func TestDescriptorName(test *testing.T) {
+	assert.Equal(test, "TBLS256", Descriptor{"TBLS", 256, NormalAggregation}.Name())
+	assert.Equal(test, "TRSA1024Optimistic", Descriptor{"TRSA", 1024, OptimisticAggregation}.Name())
+	assert.Equal(test, "TBLS256Pessimistic", Descriptor{"TBLS", 256, PessimisticAggregation}.Name())
+	assert.Equal(test, "RSA2048", Descriptor{"RSA", 2048, NoAggregation}.Name())
+	assert.Equal(test, "Mock", Descriptor{Family: "Mock"}.Name())
+
+	assert.True(test, Descriptor{"TRSA", 1024, OptimisticAggregation}.SharesKeysWith(Descriptor{"TRSA", 1024, NormalAggregation}))
+	assert.False(test, Descriptor{"TRSA", 1024, NormalAggregation}.SharesKeysWith(Descriptor{"TRSA", 2048, NormalAggregation}))
+}
+
+func TestRegistryLookup(test *testing.T) {
+	r := NewRegistry()
+	optimistic := Descriptor{"TBLS", 256, OptimisticAggregation}
+	normal := Descriptor{"TBLS", 256, NormalAggregation}
+
+	require.Nil(test, r.Register(optimistic, namedFactory("TBLS256Optimistic")))
+	require.Nil(test, r.Register(normal, namedFactory("TBLS256")))
+
+	h, err := r.Lookup("TBLS256Optimistic")
+	require.Nil(test, err)
+	assert.Equal(test, "TBLS256Optimistic", h.SchemeName())
+
+	h, err = r.LookupDescriptor(normal)
+	require.Nil(test, err)
+	assert.Equal(test, "TBLS256", h.SchemeName())
+
+	d, ok := r.Describe("TBLS256Optimistic")
+	assert.True(test, ok)
+	assert.Equal(test, optimistic, d)
+
+	_, err = r.Lookup("TBLS512")
+	assert.True(test, errors.Is(err, ErrUnsupported))
+
+	assert.Equal(test, []Descriptor{normal, optimistic}, r.Descriptors())
+
+	handlers := r.Handlers()
+	require.Len(test, handlers, 2)
+	assert.Equal(test, "TBLS256", handlers[0].SchemeName())
+
+	assert.True(test, r.SharesKeys("TBLS256", "TBLS256Optimistic"))
+	assert.True(test, r.SharesKeys("Unregistered", "Unregistered"))
+	assert.False(test, r.SharesKeys("TBLS256", "TBLS512"))
+}
+
+func TestRegistryRejectsInvalidRegistrations(test *testing.T) {
+	r := NewRegistry()
+	d := Descriptor{"TBLS", 256, NormalAggregation}
+
+	require.Nil(test, r.Register(d, namedFactory("TBLS256")))
+	assert.NotNil(test, r.Register(d, namedFactory("TBLS256")))
+	assert.NotNil(test, r.Register(Descriptor{"TRSA", 1024, NormalAggregation}, namedFactory("TRSA2048")))
+	assert.NotNil(test, r.Register(Descriptor{KeySize: 256}, namedFactory("256")))
+	assert.NotNil(test, r.Register(Descriptor{Family: "BLS"}, nil))
+}
+
+func TestSchemeInfoDescriptor(test *testing.T) {
+	info := SchemeInfo{Name: "TRSA2048Pessimistic", Operations: AllOperations(), Family: "TRSA", KeySize: 2048, Threshold: true, Aggregation: PessimisticAggregation}
+
+	assert.Equal(test, Descriptor{"TRSA", 2048, PessimisticAggregation}, info.Descriptor())
+	assert.Equal(test, info, SchemeInfoFromProto(info.ToProto()))
+}
@@ -0,0 +1,131 @@
+package crypto
+
+import (
+	"fmt"
+
+	"github.com/jffp113/CryptoProviderSDK/crypto/pb"
+)
+
+//AggregationStrategy describes how a threshold scheme
+//combines signature shares
+type AggregationStrategy int
+
+const (
+	NoAggregation AggregationStrategy = iota
+	NormalAggregation
+	OptimisticAggregation
+	PessimisticAggregation
+)
+
+var aggregationNames = map[AggregationStrategy]string{
+	NoAggregation:          "none",
+	NormalAggregation:      "normal",
+	OptimisticAggregation:  "optimistic",
+	PessimisticAggregation: "pessimistic",
+}
+
+func (a AggregationStrategy) String() string {
+	if name, ok := aggregationNames[a]; ok {
+		return name
+	}
+	return fmt.Sprintf("AggregationStrategy(%d)", int(a))
+}
+
+//SchemeInfo describes a scheme served by a signer node
+type SchemeInfo struct {
+	Name        string
+	Operations  []Operation
+	Family      string
+	KeySize     int
+	Threshold   bool
+	Aggregation AggregationStrategy
+}
+
+//Supports reports whether the scheme serves op
+func (info SchemeInfo) Supports(op Operation) bool {
+	for _, v := range info.Operations {
+		if v == op {
+			return true
+		}
+	}
+	return false
+}
+
+//Descriptor returns the registry descriptor of the scheme
+func (info SchemeInfo) Descriptor() Descriptor {
+	return Descriptor{
+		Family:      info.Family,
+		KeySize:     info.KeySize,
+		Aggregation: info.Aggregation,
+	}
+}
+
+//SchemeDescriber is optionally implemented by a THSignerHandler
+//to describe its scheme to clients listing the schemes of a node.
+type SchemeDescriber interface {
+	DescribeScheme() SchemeInfo
+}
+
+//SchemeLister is implemented by a ContextFactory able to
+//list the schemes registered in the signer node.
+type SchemeLister interface {
+	ListSchemes() ([]SchemeInfo, error)
+}
+
+//DescribeScheme returns the SchemeInfo of handler.
+//Name and operations are filled from the handler when not described,
+//and handlers that do not implement SchemeDescriber are assumed to be
+//threshold schemes with normal aggregation if they aggregate at all.
+func DescribeScheme(handler THSignerHandler) SchemeInfo {
+	var info SchemeInfo
+	if d, ok := handler.(SchemeDescriber); ok {
+		info = d.DescribeScheme()
+	} else if Supports(handler, AggregateOperation) {
+		info.Threshold = true
+		info.Aggregation = NormalAggregation
+	}
+
+	if info.Name == "" {
+		info.Name = handler.SchemeName()
+	}
+
+	if info.Operations == nil {
+		info.Operations = SupportedOperations(handler)
+	}
+
+	return info
+}
+
+//ToProto converts info to its protocol representation
+func (info SchemeInfo) ToProto() *pb.SchemeInfo {
+	ops := make([]pb.Operation, 0, len(info.Operations))
+	for _, op := range info.Operations {
+		ops = append(ops, pb.Operation(op))
+	}
+
+	return &pb.SchemeInfo{
+		Name:        info.Name,
+		Operations:  ops,
+		Family:      info.Family,
+		KeySize:     uint32(info.KeySize),
+		Threshold:   info.Threshold,
+		Aggregation: pb.AggregationStrategy(info.Aggregation),
+	}
+}
+
+//SchemeInfoFromProto converts a protocol scheme description to a SchemeInfo
+func SchemeInfoFromProto(info *pb.SchemeInfo) SchemeInfo {
+	ops := make([]Operation, 0, len(info.Operations))
+	for _, op := range info.Operations {
+		ops = append(ops, Operation(op))
+	}
+
+	return SchemeInfo{
+		Name:        info.Name,
+		Operations:  ops,
+		Family:      info.Family,
+		KeySize:     int(info.KeySize),
+		Threshold:   info.Threshold,
+		Aggregation: AggregationStrategy(info.Aggregation),
+	}
+}
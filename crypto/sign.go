@@ -33,19 +33,19 @@ func (priv PrivateKeyList) MarshalBinary() (data [][]byte, err error) {
 }
 
 type KeyGenerator interface {
-	Gen() (PublicKey, PrivateKey)
+	Gen() (PublicKey, PrivateKey, error)
 }
 
 type KeyShareGenerator interface {
-	Gen(n int, t int) (PublicKey, PrivateKeyList)
+	Gen(n int, t int) (PublicKey, PrivateKeyList, error)
 }
 
 type THSignerHandler interface {
 	KeyShareGenerator
 	SignerVerifierAggregator
 	SchemeName() string
-	UnmarshalPublic(data []byte) PublicKey
-	UnmarshalPrivate(data []byte) PrivateKey
+	UnmarshalPublic(data []byte) (PublicKey, error)
+	UnmarshalPrivate(data []byte) (PrivateKey, error)
 }
 
 type SignerVerifier interface {
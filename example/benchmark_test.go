@@ -5,6 +5,8 @@ import (
 	"github.com/jffp113/CryptoProviderSDK/client"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"github.com/jffp113/CryptoProviderSDK/example/handlers/bls"
+	"github.com/jffp113/CryptoProviderSDK/example/handlers/ecdsa"
+	"github.com/jffp113/CryptoProviderSDK/example/handlers/ed25519"
 	"github.com/jffp113/CryptoProviderSDK/example/handlers/rsa"
 	"github.com/jffp113/CryptoProviderSDK/example/handlers/tbls"
 	"github.com/jffp113/CryptoProviderSDK/example/handlers/trsa"
@@ -54,6 +56,23 @@ var rsa2048Pub crypto.PublicKey
 var rsa3072Priv crypto.PrivateKeyList
 var rsa3072Pub crypto.PublicKey
 
+var rsa4096Priv crypto.PrivateKeyList
+var rsa4096Pub crypto.PublicKey
+
+//Ed25519 material
+var ed25519Priv crypto.PrivateKeyList
+var ed25519Pub crypto.PublicKey
+
+//ECDSA material
+var ecdsa256Priv crypto.PrivateKeyList
+var ecdsa256Pub crypto.PublicKey
+
+var ecdsa384Priv crypto.PrivateKeyList
+var ecdsa384Pub crypto.PublicKey
+
+var ecdsa521Priv crypto.PrivateKeyList
+var ecdsa521Pub crypto.PublicKey
+
 //Remote primitives
 var cryptoProvider crypto.ContextFactory
 
@@ -65,17 +84,24 @@ func initTest() {
 	once.Do(func() {
 		cryptoProvider,_ = client.NewCryptoFactory(URI)
 
-		tbls256Pub,tbls256Priv = tbls.NewTBLS256KeyGenerator().Gen(N,T)
+		tbls256Pub,tbls256Priv,_ = tbls.NewTBLS256KeyGenerator().Gen(N,T)
+
+		bls256Pub,bls256Priv,_ = bls.NewBLSKeyGenerator256().Gen(N,T)
+
+		trsa1024Pub,trsa1024Priv,_ = trsa.NewTRSAKeyGenerator(1024).Gen(N,T)
+		trsa2048Pub,trsa2048Priv,_ = trsa.NewTRSAKeyGenerator(2048).Gen(N,T)
+		trsa3072Pub,trsa3072Priv,_ = trsa.NewTRSAKeyGenerator(3072).Gen(N,T)
 
-		bls256Pub,bls256Priv = bls.NewBLSKeyGenerator256().Gen(N,T)
+		rsa1024Pub,rsa1024Priv,_ = rsa.NewRSAKeyGenerator(1024).Gen(N,T)
+		rsa2048Pub,rsa2048Priv,_ = rsa.NewRSAKeyGenerator(2048).Gen(N,T)
+		rsa3072Pub,rsa3072Priv,_ = rsa.NewRSAKeyGenerator(3072).Gen(N,T)
+		rsa4096Pub,rsa4096Priv,_ = rsa.NewRSAKeyGenerator(4096).Gen(N,T)
 
-		trsa1024Pub,trsa1024Priv = trsa.NewTRSAKeyGenerator(1024).Gen(N,T)
-		trsa2048Pub,trsa2048Priv = trsa.NewTRSAKeyGenerator(2048).Gen(N,T)
-		trsa3072Pub,trsa3072Priv = trsa.NewTRSAKeyGenerator(3072).Gen(N,T)
+		ed25519Pub,ed25519Priv,_ = ed25519.NewEd25519KeyGenerator().Gen(N,T)
 
-		rsa1024Pub,rsa1024Priv = rsa.NewRSAKeyGenerator(1024).Gen(N,T)
-		rsa2048Pub,rsa2048Priv = rsa.NewRSAKeyGenerator(2048).Gen(N,T)
-		rsa3072Pub,rsa3072Priv = rsa.NewRSAKeyGenerator(3072).Gen(N,T)
+		ecdsa256Pub,ecdsa256Priv,_ = ecdsa.NewECDSAKeyGenerator(256).Gen(N,T)
+		ecdsa384Pub,ecdsa384Priv,_ = ecdsa.NewECDSAKeyGenerator(384).Gen(N,T)
+		ecdsa521Pub,ecdsa521Priv,_ = ecdsa.NewECDSAKeyGenerator(521).Gen(N,T)
 
 
 	})
@@ -281,6 +307,154 @@ func BenchmarkRSA3072LocalVerify(b *testing.B) {
 	benchmarkVerifyNonThreshold(b,trsa,rsa3072Pub,rsa3072Priv)
 }
 
+//4096
+func BenchmarkRSA4096LocalGen(b *testing.B) {
+	initTest()
+	keygen := rsa.NewRSAKeyGenerator(4096)
+	benchmarkGen(b,keygen)
+}
+
+func BenchmarkRSA4096LocalSign(b *testing.B) {
+	initTest()
+	rsa := rsa.NewRSA(4096)
+	benchmarkSign(b,rsa,rsa4096Priv)
+}
+
+func BenchmarkRSA4096LocalVerify(b *testing.B) {
+	initTest()
+	rsa := rsa.NewRSA(4096)
+	benchmarkVerifyNonThreshold(b,rsa,rsa4096Pub,rsa4096Priv)
+}
+
+/****************
+ * RSAPSS Benchmark
+ ****************/
+
+//RSAPSS keys are RSA keys
+
+//2048
+func BenchmarkRSAPSS2048LocalSign(b *testing.B) {
+	initTest()
+	rsa := rsa.NewRSAPSS(2048)
+	benchmarkSign(b,rsa,rsa2048Priv)
+}
+
+func BenchmarkRSAPSS2048LocalVerify(b *testing.B) {
+	initTest()
+	rsa := rsa.NewRSAPSS(2048)
+	benchmarkVerifyNonThreshold(b,rsa,rsa2048Pub,rsa2048Priv)
+}
+
+//3072
+func BenchmarkRSAPSS3072LocalSign(b *testing.B) {
+	initTest()
+	rsa := rsa.NewRSAPSS(3072)
+	benchmarkSign(b,rsa,rsa3072Priv)
+}
+
+func BenchmarkRSAPSS3072LocalVerify(b *testing.B) {
+	initTest()
+	rsa := rsa.NewRSAPSS(3072)
+	benchmarkVerifyNonThreshold(b,rsa,rsa3072Pub,rsa3072Priv)
+}
+
+//4096
+func BenchmarkRSAPSS4096LocalSign(b *testing.B) {
+	initTest()
+	rsa := rsa.NewRSAPSS(4096)
+	benchmarkSign(b,rsa,rsa4096Priv)
+}
+
+func BenchmarkRSAPSS4096LocalVerify(b *testing.B) {
+	initTest()
+	rsa := rsa.NewRSAPSS(4096)
+	benchmarkVerifyNonThreshold(b,rsa,rsa4096Pub,rsa4096Priv)
+}
+
+
+
+/****************
+ * Ed25519 Benchmark
+ ****************/
+
+func BenchmarkEd25519LocalGen(b *testing.B) {
+	initTest()
+	keygen := ed25519.NewEd25519KeyGenerator()
+	benchmarkGen(b,keygen)
+}
+
+func BenchmarkEd25519LocalSign(b *testing.B) {
+	initTest()
+	ed25519 := ed25519.NewEd25519()
+	benchmarkSign(b,ed25519,ed25519Priv)
+}
+
+func BenchmarkEd25519LocalVerify(b *testing.B) {
+	initTest()
+	ed25519 := ed25519.NewEd25519()
+	benchmarkVerifyNonThreshold(b,ed25519,ed25519Pub,ed25519Priv)
+}
+
+/****************
+ * ECDSA Benchmark
+ ****************/
+
+//ECDSA256
+func BenchmarkECDSA256LocalGen(b *testing.B) {
+	initTest()
+	keygen := ecdsa.NewECDSAKeyGenerator(256)
+	benchmarkGen(b,keygen)
+}
+
+func BenchmarkECDSA256LocalSign(b *testing.B) {
+	initTest()
+	ecdsa := ecdsa.NewECDSA(256,ecdsa.DER)
+	benchmarkSign(b,ecdsa,ecdsa256Priv)
+}
+
+func BenchmarkECDSA256LocalVerify(b *testing.B) {
+	initTest()
+	ecdsa := ecdsa.NewECDSA(256,ecdsa.DER)
+	benchmarkVerifyNonThreshold(b,ecdsa,ecdsa256Pub,ecdsa256Priv)
+}
+
+//ECDSA384
+func BenchmarkECDSA384LocalGen(b *testing.B) {
+	initTest()
+	keygen := ecdsa.NewECDSAKeyGenerator(384)
+	benchmarkGen(b,keygen)
+}
+
+func BenchmarkECDSA384LocalSign(b *testing.B) {
+	initTest()
+	ecdsa := ecdsa.NewECDSA(384,ecdsa.DER)
+	benchmarkSign(b,ecdsa,ecdsa384Priv)
+}
+
+func BenchmarkECDSA384LocalVerify(b *testing.B) {
+	initTest()
+	ecdsa := ecdsa.NewECDSA(384,ecdsa.DER)
+	benchmarkVerifyNonThreshold(b,ecdsa,ecdsa384Pub,ecdsa384Priv)
+}
+
+//ECDSA521
+func BenchmarkECDSA521LocalGen(b *testing.B) {
+	initTest()
+	keygen := ecdsa.NewECDSAKeyGenerator(521)
+	benchmarkGen(b,keygen)
+}
+
+func BenchmarkECDSA521LocalSign(b *testing.B) {
+	initTest()
+	ecdsa := ecdsa.NewECDSA(521,ecdsa.DER)
+	benchmarkSign(b,ecdsa,ecdsa521Priv)
+}
+
+func BenchmarkECDSA521LocalVerify(b *testing.B) {
+	initTest()
+	ecdsa := ecdsa.NewECDSA(521,ecdsa.DER)
+	benchmarkVerifyNonThreshold(b,ecdsa,ecdsa521Pub,ecdsa521Priv)
+}
 
 
 /****************
@@ -484,6 +658,77 @@ func BenchmarkRSA3072RemoteVerify(b *testing.B) {
 	benchmarkVerifyNonThreshold(b,rsa,rsa3072Pub,rsa3072Priv)
 }
 
+//4096
+func BenchmarkRSA4096RemoteGen(b *testing.B) {
+	initTest()
+	keygen,close := cryptoProvider.GetKeyGenerator(rsa.RSA + fmt.Sprint(4096))
+	defer close.Close()
+	benchmarkGen(b,keygen)
+}
+
+func BenchmarkRSA4096RemoteSign(b *testing.B) {
+	initTest()
+	rsa ,close := cryptoProvider.GetSignerVerifierAggregator(rsa.RSA + fmt.Sprint(4096))
+	defer close.Close()
+	benchmarkSign(b,rsa,rsa4096Priv)
+}
+
+func BenchmarkRSA4096RemoteVerify(b *testing.B) {
+	initTest()
+	rsa ,close := cryptoProvider.GetSignerVerifierAggregator(rsa.RSA + fmt.Sprint(4096))
+	defer close.Close()
+	benchmarkVerifyNonThreshold(b,rsa,rsa4096Pub,rsa4096Priv)
+}
+
+/****************
+ * RSAPSS Benchmark
+ ****************/
+
+//2048
+func BenchmarkRSAPSS2048RemoteSign(b *testing.B) {
+	initTest()
+	rsa ,close := cryptoProvider.GetSignerVerifierAggregator(rsa.RSAPSS + fmt.Sprint(2048))
+	defer close.Close()
+	benchmarkSign(b,rsa,rsa2048Priv)
+}
+
+func BenchmarkRSAPSS2048RemoteVerify(b *testing.B) {
+	initTest()
+	rsa ,close := cryptoProvider.GetSignerVerifierAggregator(rsa.RSAPSS + fmt.Sprint(2048))
+	defer close.Close()
+	benchmarkVerifyNonThreshold(b,rsa,rsa2048Pub,rsa2048Priv)
+}
+
+//3072
+func BenchmarkRSAPSS3072RemoteSign(b *testing.B) {
+	initTest()
+	rsa ,close := cryptoProvider.GetSignerVerifierAggregator(rsa.RSAPSS + fmt.Sprint(3072))
+	defer close.Close()
+	benchmarkSign(b,rsa,rsa3072Priv)
+}
+
+func BenchmarkRSAPSS3072RemoteVerify(b *testing.B) {
+	initTest()
+	rsa ,close := cryptoProvider.GetSignerVerifierAggregator(rsa.RSAPSS + fmt.Sprint(3072))
+	defer close.Close()
+	benchmarkVerifyNonThreshold(b,rsa,rsa3072Pub,rsa3072Priv)
+}
+
+//4096
+func BenchmarkRSAPSS4096RemoteSign(b *testing.B) {
+	initTest()
+	rsa ,close := cryptoProvider.GetSignerVerifierAggregator(rsa.RSAPSS + fmt.Sprint(4096))
+	defer close.Close()
+	benchmarkSign(b,rsa,rsa4096Priv)
+}
+
+func BenchmarkRSAPSS4096RemoteVerify(b *testing.B) {
+	initTest()
+	rsa ,close := cryptoProvider.GetSignerVerifierAggregator(rsa.RSAPSS + fmt.Sprint(4096))
+	defer close.Close()
+	benchmarkVerifyNonThreshold(b,rsa,rsa4096Pub,rsa4096Priv)
+}
+
 
 /****************
  * BLS Benchmark
@@ -510,6 +755,101 @@ func BenchmarkBLS256RemoteVerify(b *testing.B) {
 	benchmarkVerifyNonThreshold(b,bls,bls256Pub,bls256Priv)
 }
 
+/****************
+ * Ed25519 Benchmark
+ ****************/
+
+func BenchmarkEd25519RemoteGen(b *testing.B) {
+	initTest()
+	keygen,close := cryptoProvider.GetKeyGenerator(ed25519.Ed25519)
+	defer close.Close()
+	benchmarkGen(b,keygen)
+}
+
+func BenchmarkEd25519RemoteSign(b *testing.B) {
+	initTest()
+	ed25519,close := cryptoProvider.GetSignerVerifierAggregator(ed25519.Ed25519)
+	defer close.Close()
+	benchmarkSign(b,ed25519,ed25519Priv)
+}
+
+func BenchmarkEd25519RemoteVerify(b *testing.B) {
+	initTest()
+	ed25519,close := cryptoProvider.GetSignerVerifierAggregator(ed25519.Ed25519)
+	defer close.Close()
+	benchmarkVerifyNonThreshold(b,ed25519,ed25519Pub,ed25519Priv)
+}
+
+/****************
+ * ECDSA Benchmark
+ ****************/
+
+//ECDSA256
+func BenchmarkECDSA256RemoteGen(b *testing.B) {
+	initTest()
+	keygen,close := cryptoProvider.GetKeyGenerator("ECDSA256")
+	defer close.Close()
+	benchmarkGen(b,keygen)
+}
+
+func BenchmarkECDSA256RemoteSign(b *testing.B) {
+	initTest()
+	ecdsa,close := cryptoProvider.GetSignerVerifierAggregator("ECDSA256")
+	defer close.Close()
+	benchmarkSign(b,ecdsa,ecdsa256Priv)
+}
+
+func BenchmarkECDSA256RemoteVerify(b *testing.B) {
+	initTest()
+	ecdsa,close := cryptoProvider.GetSignerVerifierAggregator("ECDSA256")
+	defer close.Close()
+	benchmarkVerifyNonThreshold(b,ecdsa,ecdsa256Pub,ecdsa256Priv)
+}
+
+//ECDSA384
+func BenchmarkECDSA384RemoteGen(b *testing.B) {
+	initTest()
+	keygen,close := cryptoProvider.GetKeyGenerator("ECDSA384")
+	defer close.Close()
+	benchmarkGen(b,keygen)
+}
+
+func BenchmarkECDSA384RemoteSign(b *testing.B) {
+	initTest()
+	ecdsa,close := cryptoProvider.GetSignerVerifierAggregator("ECDSA384")
+	defer close.Close()
+	benchmarkSign(b,ecdsa,ecdsa384Priv)
+}
+
+func BenchmarkECDSA384RemoteVerify(b *testing.B) {
+	initTest()
+	ecdsa,close := cryptoProvider.GetSignerVerifierAggregator("ECDSA384")
+	defer close.Close()
+	benchmarkVerifyNonThreshold(b,ecdsa,ecdsa384Pub,ecdsa384Priv)
+}
+
+//ECDSA521
+func BenchmarkECDSA521RemoteGen(b *testing.B) {
+	initTest()
+	keygen,close := cryptoProvider.GetKeyGenerator("ECDSA521")
+	defer close.Close()
+	benchmarkGen(b,keygen)
+}
+
+func BenchmarkECDSA521RemoteSign(b *testing.B) {
+	initTest()
+	ecdsa,close := cryptoProvider.GetSignerVerifierAggregator("ECDSA521")
+	defer close.Close()
+	benchmarkSign(b,ecdsa,ecdsa521Priv)
+}
+
+func BenchmarkECDSA521RemoteVerify(b *testing.B) {
+	initTest()
+	ecdsa,close := cryptoProvider.GetSignerVerifierAggregator("ECDSA521")
+	defer close.Close()
+	benchmarkVerifyNonThreshold(b,ecdsa,ecdsa521Pub,ecdsa521Priv)
+}
+
 /****************
  * Benchmark Utils
  ****************/
@@ -518,8 +858,10 @@ func benchmarkGen(b *testing.B,keygen crypto.KeyShareGenerator){
 	b.ResetTimer()
 	var pub crypto.PublicKey
 	var privList crypto.PrivateKeyList
+	var err error
 	for i := 0; i < b.N; i++ {
-		pub,privList = keygen.Gen(N,T)
+		pub,privList,err = keygen.Gen(N,T)
+		assert.Nil(b,err)
 	}
 	resultPublicKey = pub
 	resultPrivateKey = privList
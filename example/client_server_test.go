@@ -51,7 +51,8 @@ func tblsSuccessSignature(n, t int, keygen crypto.KeyShareGenerator, tbls crypto
 	var err error
 	msg := []byte("Test TBLS")
 
-	pub, shares := keygen.Gen(n, t)
+	pub, shares, err := keygen.Gen(n, t)
+	require.Nil(test, err)
 
 	sigShares := make([][]byte, 0)
 	for _, x := range shares {
@@ -89,7 +90,8 @@ func TestTBLSNotEnoughSharesServerError(test *testing.T) {
 	n := 10
 	t := n/2 + 1
 
-	pub, shares := keygen.Gen(n, t)
+	pub, shares, err := keygen.Gen(n, t)
+	require.Nil(test, err)
 
 	sigShares := make([][]byte, 0)
 	for _, x := range shares[0 : t-1] {
@@ -121,7 +123,8 @@ func TestStress(test *testing.T) {
 	n := 10
 	t := n/2 + 1
 
-	_, shares := keygen.Gen(n, t)
+	_, shares, err := keygen.Gen(n, t)
+	require.Nil(test, err)
 
 	//sigShares := make([][]byte, 0)
 	var wg sync.WaitGroup
@@ -1,7 +1,9 @@
 package bls
 
 import (
-	"errors"
+	"crypto/sha512"
+	"fmt"
+	"math/big"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/pairing"
@@ -12,6 +14,28 @@ import (
 
 const BLS = "BLS256"
 
+//Family is the registry family of the BLS schemes
+const Family = "BLS"
+
+func init() {
+	crypto.Register(crypto.Descriptor{Family: Family, KeySize: 256, Aggregation: crypto.NoAggregation}, NewBLS256Handler)
+}
+
+var keyError = fmt.Errorf("%w: invalid key", crypto.ErrBadKeyEncoding)
+
+var _ crypto.MultiSignatureAggregator = blsHandler{}
+var _ crypto.PossessionProver = blsHandler{}
+
+//possessionTag separates the proofs of possession from the signatures
+//of messages, so that a signature cannot be given as a proof
+const possessionTag = "BLS_POP_BN256_G2:"
+
+//fieldModulus is the prime p of the field of the points of bn256.G1,
+//which are the solutions of y^2 = x^3 + 3
+var fieldModulus, _ = new(big.Int).SetString("65000549695646603732796438742359905742825358107623003571877145026864184071783", 10)
+
+//blsHandler aggregates the signatures of independent keys, either over the
+//same message or over distinct ones, but is not a threshold scheme
 type blsHandler struct {
 	scheme string
 	suite pairing.Suite
@@ -21,7 +45,7 @@ func (self blsHandler) Sign(digest []byte, key crypto.PrivateKey) (signature []b
 	priv,ok := key.(kyber.Scalar)
 
 	if !ok {
-		return nil,errors.New("invalid key")
+		return nil,keyError
 	}
 	return bls.Sign(self.suite, priv, digest)
 }
@@ -30,46 +54,278 @@ func (self blsHandler) Verify(signature []byte, msg []byte, key crypto.PublicKey
 	pub,ok := key.(kyber.Point)
 
 	if !ok {
-		return errors.New("invalid key")
+		return keyError
 	}
 
 	return bls.Verify(self.suite, pub, msg, signature)
 }
 
+//Aggregate aggregates the signatures of digest made by independent keys.
+//key is the aggregation of those keys, see AggregatePublicKeys,
+//and t and n are ignored as BLS multi-signatures have no threshold.
 func (self blsHandler) Aggregate(share [][]byte, digest []byte, key crypto.PublicKey, t, n int) (signature []byte, err error) {
-	panic("Not implemented")
+	pub, ok := key.(kyber.Point)
+
+	if !ok {
+		return nil, keyError
+	}
+
+	sig, err := self.aggregateSignatures(share)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bls.Verify(self.suite, pub, digest, sig); err != nil {
+		return nil, fmt.Errorf("%w: aggregated signature is invalid: %v", crypto.ErrInvalidShare, err)
+	}
+
+	return sig, nil
+}
+
+//AggregateMulti aggregates the signatures of msg made by the keys of pubs
+//and checks the result against their aggregated key
+func (self blsHandler) AggregateMulti(signatures [][]byte, msg []byte, pubs []crypto.PublicKey) ([]byte, error) {
+	if err := crypto.CheckMultiSignature(signatures, pubs); err != nil {
+		return nil, err
+	}
+
+	key, err := AggregatePublicKeys(pubs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.Aggregate(signatures, msg, key, len(pubs), len(pubs))
+}
+
+//VerifyMulti verifies a signature of msg aggregated by AggregateMulti
+func (self blsHandler) VerifyMulti(signature []byte, msg []byte, pubs []crypto.PublicKey) error {
+	if err := crypto.CheckMultiSignature(nil, pubs); err != nil {
+		return err
+	}
+
+	key, err := AggregatePublicKeys(pubs...)
+	if err != nil {
+		return err
+	}
+
+	return self.Verify(signature, msg, key)
+}
+
+//AggregateDistinct aggregates the signatures of msgs[i] made by pubs[i]
+//and checks the result against every key and message
+func (self blsHandler) AggregateDistinct(signatures [][]byte, msgs [][]byte, pubs []crypto.PublicKey) ([]byte, error) {
+	if err := crypto.CheckDistinctSignature(signatures, msgs, pubs); err != nil {
+		return nil, err
+	}
+
+	sig, err := self.aggregateSignatures(signatures)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := self.VerifyDistinct(sig, msgs, pubs); err != nil {
+		return nil, fmt.Errorf("%w: %v", crypto.ErrInvalidShare, err)
+	}
+
+	return sig, nil
+}
+
+//VerifyDistinct verifies a signature aggregated by AggregateDistinct.
+//The messages must be distinct, as signatures of a message by several
+//keys could otherwise be verified with keys not involved in signing.
+func (self blsHandler) VerifyDistinct(signature []byte, msgs [][]byte, pubs []crypto.PublicKey) error {
+	if err := crypto.CheckDistinctSignature(nil, msgs, pubs); err != nil {
+		return err
+	}
+
+	points, err := toPoints(pubs)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(msgs))
+	for _, msg := range msgs {
+		if seen[string(msg)] {
+			return fmt.Errorf("%w: messages of an aggregated signature must be distinct", crypto.ErrMalformedRequest)
+		}
+		seen[string(msg)] = true
+	}
+
+	if err := bls.BatchVerify(self.suite, points, msgs, signature); err != nil {
+		return fmt.Errorf("%w: %v", crypto.ErrInvalidSignature, err)
+	}
+
+	return nil
+}
+
+func (self blsHandler) aggregateSignatures(signatures [][]byte) ([]byte, error) {
+	if len(signatures) == 0 {
+		return nil, fmt.Errorf("%w: no signatures to aggregate", crypto.ErrNotEnoughShares)
+	}
+
+	sig, err := bls.AggregateSignatures(self.suite, signatures...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", crypto.ErrInvalidShare, err)
+	}
+
+	return sig, nil
+}
+
+//ProvePossession signs the public key of priv, proving that
+//the key was not made from the keys of other signers
+func (self blsHandler) ProvePossession(key crypto.PrivateKey) ([]byte, error) {
+	priv, ok := key.(kyber.Scalar)
+
+	if !ok {
+		return nil, keyError
+	}
+
+	h, err := self.possessionPoint(self.suite.G2().Point().Mul(priv, nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return h.Mul(priv, h).MarshalBinary()
+}
+
+//VerifyPossession verifies a proof made by ProvePossession
+func (self blsHandler) VerifyPossession(key crypto.PublicKey, proof []byte) error {
+	if proven, ok := key.(crypto.ProvenKey); ok {
+		key = proven.PublicKey
+	}
+
+	pub, ok := key.(kyber.Point)
+
+	if !ok {
+		return keyError
+	}
+
+	h, err := self.possessionPoint(pub)
+	if err != nil {
+		return err
+	}
+
+	p := self.suite.G1().Point()
+	if err := p.UnmarshalBinary(proof); err != nil {
+		return fmt.Errorf("%w: %v", crypto.ErrInvalidProof, err)
+	}
+
+	if !self.suite.Pair(h, pub).Equal(self.suite.Pair(p, self.suite.G2().Point().Base())) {
+		return fmt.Errorf("%w: proof does not match the key", crypto.ErrInvalidProof)
+	}
+
+	return nil
+}
+
+//possessionPoint hashes pub to the point of G1 its proof of possession
+//multiplies. Signatures hash messages with SHA-256, this hash uses SHA-512
+//of the tagged key, so a signature of any message cannot be a proof.
+func (self blsHandler) possessionPoint(pub kyber.Point) (kyber.Point, error) {
+	data, err := pub.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", crypto.ErrBadKeyEncoding, err)
+	}
+
+	digest := sha512.Sum512(append([]byte(possessionTag), data...))
+	x := new(big.Int).SetBytes(digest[:])
+	x.Mod(x, fieldModulus)
+
+	//try x, x+1... until x^3 + 3 has a square root
+	for {
+		y2 := new(big.Int).Exp(x, big.NewInt(3), fieldModulus)
+		y2.Add(y2, big.NewInt(3))
+		y2.Mod(y2, fieldModulus)
+
+		if y := new(big.Int).ModSqrt(y2, fieldModulus); y != nil {
+			encoded := make([]byte, 64)
+			x.FillBytes(encoded[:32])
+			y.FillBytes(encoded[32:])
+
+			h := self.suite.G1().Point()
+			if err := h.UnmarshalBinary(encoded); err != nil {
+				return nil, err
+			}
+			return h, nil
+		}
+
+		x.Add(x, big.NewInt(1))
+		x.Mod(x, fieldModulus)
+	}
 }
 
-func (self blsHandler) Gen(n int, t int) (crypto.PublicKey, crypto.PrivateKeyList) {
+//AggregatePublicKeys returns the key verifying the signatures
+//aggregated from signatures of the same message made by pubs
+func AggregatePublicKeys(pubs ...crypto.PublicKey) (crypto.PublicKey, error) {
+	points, err := toPoints(pubs)
+	if err != nil {
+		return nil, err
+	}
+
+	return bls.AggregatePublicKeys(bn256.NewSuite(), points...), nil
+}
+
+//toPoints returns the points of pubs, which may be a crypto.ProvenKey.
+//Their proofs are verified by the processor, before aggregating.
+func toPoints(pubs []crypto.PublicKey) ([]kyber.Point, error) {
+	points := make([]kyber.Point, 0, len(pubs))
+	for _, pub := range pubs {
+		if proven, ok := pub.(crypto.ProvenKey); ok {
+			pub = proven.PublicKey
+		}
+		point, ok := pub.(kyber.Point)
+		if !ok {
+			return nil, keyError
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+func (self blsHandler) Gen(n int, t int) (crypto.PublicKey, crypto.PrivateKeyList, error) {
 	private, public := bls.NewKeyPair(self.suite, random.New())
 	return public,
-			crypto.PrivateKeyList{private}
+			crypto.PrivateKeyList{private}, nil
+}
+
+func (self blsHandler) SupportedOperations() []crypto.Operation {
+	return crypto.AllOperations()
+}
+
+func (self blsHandler) DescribeScheme() crypto.SchemeInfo {
+	return crypto.SchemeInfo{
+		Name:        self.scheme,
+		Operations:  self.SupportedOperations(),
+		Family:      Family,
+		KeySize:     256,
+		Threshold:   false,
+		Aggregation: crypto.NoAggregation,
+	}
 }
 
 func (self blsHandler) SchemeName() string {
 	return self.scheme
 }
 
-func (self blsHandler) UnmarshalPublic(data []byte) crypto.PublicKey {
+func (self blsHandler) UnmarshalPublic(data []byte) (crypto.PublicKey, error) {
 	pub := bn256.NewSuiteG2().G2().Point()
 	err := pub.UnmarshalBinary(data)
 
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("%w: %v", crypto.ErrBadKeyEncoding, err)
 	}
 
-	return  pub
+	return pub, nil
 }
 
-func (self blsHandler) UnmarshalPrivate(data []byte) crypto.PrivateKey {
+func (self blsHandler) UnmarshalPrivate(data []byte) (crypto.PrivateKey, error) {
 	privKey := bn256.NewSuiteG2().Scalar()
-	err := privKey.UnmarshalBinary(data)//priv
+	err := privKey.UnmarshalBinary(data)
 
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("%w: %v", crypto.ErrBadKeyEncoding, err)
 	}
 
-	return  privKey
+	return privKey, nil
 }
 
 func NewBLSKeyGenerator256() crypto.KeyShareGenerator {
@@ -1,15 +1,19 @@
 package bls
 
 import (
+	"errors"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
 	"testing"
 )
 
 func TestBLS(t *testing.T) {
 	msg := []byte("Hello Boneh-Lynn-Shacham")
 	s := NewBLS256Handler()
-	public,private := s.Gen(0,0)
+	public,private,err := s.Gen(0,0)
+	require.Nil(t, err)
 	sig, err := s.Sign(msg, private[0])
 	require.Nil(t, err)
 	err = s.Verify(sig,msg, public)
@@ -21,18 +25,198 @@ func TestMarshallUnmarshallKeys(t *testing.T) {
 
 	msg := []byte("Hello Boneh-Lynn-Shacham")
 	s := NewBLS256Handler()
-	public,private := s.Gen(0,0)
+	public,private,err := s.Gen(0,0)
+	require.Nil(t, err)
 
 	bytePubKey,err := public.MarshalBinary()
 	assert.Nil(t,err)
 	bytePrivKey,err := private[0].MarshalBinary()
 	assert.Nil(t,err)
 
-	public = s.UnmarshalPublic(bytePubKey)
-	private[0] = s.UnmarshalPrivate(bytePrivKey)
+	public, err = s.UnmarshalPublic(bytePubKey)
+	require.Nil(t, err)
+	private[0], err = s.UnmarshalPrivate(bytePrivKey)
+	require.Nil(t, err)
 
 	sig, err := s.Sign(msg, private[0])
 	require.Nil(t, err)
 	err = s.Verify(sig,msg, public)
 	require.Nil(t, err)
 }
+
+func TestUnmarshalInvalidKeys(t *testing.T) {
+	s := NewBLS256Handler()
+
+	_, err := s.UnmarshalPublic([]byte("not a key"))
+	assert.True(t, errors.Is(err, crypto.ErrBadKeyEncoding))
+
+	_, err = s.UnmarshalPrivate([]byte("not a key"))
+	assert.True(t, errors.Is(err, crypto.ErrBadKeyEncoding))
+}
+
+//signers generates n BLS keys and their signatures of msgs,
+//or of msgs[0] if there is a single message
+func signers(t *testing.T, n int, msgs ...[]byte) ([]crypto.PublicKey, [][]byte) {
+	s := NewBLS256Handler()
+	pubs := make([]crypto.PublicKey, 0, n)
+	sigs := make([][]byte, 0, n)
+
+	for i := 0; i < n; i++ {
+		public, private, err := s.Gen(0, 0)
+		require.Nil(t, err)
+
+		msg := msgs[0]
+		if len(msgs) > 1 {
+			msg = msgs[i]
+		}
+
+		sig, err := s.Sign(msg, private[0])
+		require.Nil(t, err)
+
+		pubs = append(pubs, public)
+		sigs = append(sigs, sig)
+	}
+
+	return pubs, sigs
+}
+
+func TestAggregateMulti(t *testing.T) {
+	msg := []byte("Hello Boneh-Lynn-Shacham")
+	s := NewBLS256()
+	m := s.(crypto.MultiSignatureAggregator)
+	pubs, sigs := signers(t, 4, msg)
+
+	sig, err := m.AggregateMulti(sigs, msg, pubs)
+	require.Nil(t, err)
+	assert.Nil(t, m.VerifyMulti(sig, msg, pubs))
+	assert.NotNil(t, m.VerifyMulti(sig, []byte("other message"), pubs))
+	assert.NotNil(t, m.VerifyMulti(sig, msg, pubs[1:]))
+
+	//the aggregated key verifies the signature as any other key
+	key, err := AggregatePublicKeys(pubs...)
+	require.Nil(t, err)
+	assert.Nil(t, s.Verify(sig, msg, key))
+
+	aggregated, err := s.Aggregate(sigs, msg, key, 0, 0)
+	require.Nil(t, err)
+	assert.Equal(t, sig, aggregated)
+
+	//a signature of another message spoils the aggregation
+	_, other := signers(t, 1, []byte("other message"))
+	sigs[2] = other[0]
+	_, err = m.AggregateMulti(sigs, msg, pubs)
+	assert.True(t, errors.Is(err, crypto.ErrInvalidShare))
+
+	_, err = m.AggregateMulti(sigs[1:], msg, pubs)
+	assert.True(t, errors.Is(err, crypto.ErrMalformedRequest))
+
+	_, err = s.Aggregate(nil, msg, key, 0, 0)
+	assert.True(t, errors.Is(err, crypto.ErrNotEnoughShares))
+}
+
+func TestAggregateDistinct(t *testing.T) {
+	msgs := [][]byte{[]byte("first"), []byte("second"), []byte("third")}
+	m := NewBLS256().(crypto.MultiSignatureAggregator)
+	pubs, sigs := signers(t, 3, msgs...)
+
+	sig, err := m.AggregateDistinct(sigs, msgs, pubs)
+	require.Nil(t, err)
+	assert.Nil(t, m.VerifyDistinct(sig, msgs, pubs))
+
+	//keys must sign their own message
+	swapped := [][]byte{msgs[1], msgs[0], msgs[2]}
+	err = m.VerifyDistinct(sig, swapped, pubs)
+	assert.True(t, errors.Is(err, crypto.ErrInvalidSignature))
+
+	_, err = m.AggregateDistinct(sigs, swapped, pubs)
+	assert.True(t, errors.Is(err, crypto.ErrInvalidShare))
+
+	//messages must be distinct
+	repeated := [][]byte{msgs[0], msgs[0], msgs[2]}
+	err = m.VerifyDistinct(sig, repeated, pubs)
+	assert.True(t, errors.Is(err, crypto.ErrMalformedRequest))
+
+	err = m.VerifyDistinct(sig, msgs[:2], pubs)
+	assert.True(t, errors.Is(err, crypto.ErrMalformedRequest))
+
+	err = m.VerifyDistinct(sig, nil, nil)
+	assert.True(t, errors.Is(err, crypto.ErrMalformedRequest))
+}
+
+func TestPossession(t *testing.T) {
+	s := NewBLS256Handler()
+	p := s.(crypto.PossessionProver)
+
+	public, private, err := s.Gen(0, 0)
+	require.Nil(t, err)
+	other, _, err := s.Gen(0, 0)
+	require.Nil(t, err)
+
+	proof, err := p.ProvePossession(private[0])
+	require.Nil(t, err)
+	assert.Nil(t, p.VerifyPossession(public, proof))
+	assert.Nil(t, p.VerifyPossession(crypto.ProvenKey{PublicKey: public, Proof: proof}, proof))
+
+	err = p.VerifyPossession(other, proof)
+	assert.True(t, errors.Is(err, crypto.ErrInvalidProof))
+
+	//a signature of the key is not a proof
+	keyBytes, err := public.MarshalBinary()
+	require.Nil(t, err)
+	sig, err := s.Sign(keyBytes, private[0])
+	require.Nil(t, err)
+	err = p.VerifyPossession(public, sig)
+	assert.True(t, errors.Is(err, crypto.ErrInvalidProof))
+
+	//nor is a signature of the tagged key, as proofs hash it differently
+	sig, err = s.Sign(append([]byte(possessionTag), keyBytes...), private[0])
+	require.Nil(t, err)
+	err = p.VerifyPossession(public, sig)
+	assert.True(t, errors.Is(err, crypto.ErrInvalidProof))
+
+	//and a proof is not a signature of the tagged key
+	assert.NotNil(t, s.Verify(proof, append([]byte(possessionTag), keyBytes...), public))
+
+	err = p.VerifyPossession(public, []byte("not a point"))
+	assert.True(t, errors.Is(err, crypto.ErrInvalidProof))
+
+	_, err = p.ProvePossession(public)
+	assert.True(t, errors.Is(err, crypto.ErrBadKeyEncoding))
+}
+
+func TestRogueKey(t *testing.T) {
+	msg := []byte("Hello Boneh-Lynn-Shacham")
+	h := NewBLS256Handler().(blsHandler)
+	victims, _ := signers(t, 1, msg)
+
+	//the rogue key cancels the key of the victim once aggregated,
+	//so its signature alone passes as a signature of both
+	x := h.suite.G2().Scalar().Pick(h.suite.RandomStream())
+	rogue := h.suite.G2().Point().Sub(h.suite.G2().Point().Mul(x, nil), victims[0].(kyber.Point))
+	forged, err := h.Sign(msg, x)
+	require.Nil(t, err)
+	require.Nil(t, h.VerifyMulti(forged, msg, []crypto.PublicKey{victims[0], rogue}))
+
+	//but its private key is unknown, so it cannot be proven
+	proof, err := h.ProvePossession(x)
+	require.Nil(t, err)
+	err = h.VerifyPossession(rogue, proof)
+	assert.True(t, errors.Is(err, crypto.ErrInvalidProof))
+}
+
+func TestAggregateSupported(t *testing.T) {
+	s := NewBLS256Handler()
+
+	assert.True(t, crypto.Supports(s, crypto.AggregateOperation))
+	assert.True(t, crypto.Supports(s, crypto.SignOperation))
+
+	info := crypto.DescribeScheme(s)
+	assert.False(t, info.Threshold)
+	assert.Equal(t, crypto.NoAggregation, info.Aggregation)
+}
+
+func TestRegistered(t *testing.T) {
+	h, err := crypto.Lookup(BLS)
+	require.Nil(t, err)
+	assert.Equal(t, BLS, h.SchemeName())
+}
@@ -0,0 +1,334 @@
+package ecdsa
+
+import (
+	gocrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"math/big"
+)
+
+//ECDSA is the family of the schemes with ASN.1 DER encoded signatures
+//and ECDSARaw the one of the schemes with r||s encoded signatures.
+//Both use the same keys, but are registered as different families
+//as the descriptor of a scheme has no room for its signature format.
+const (
+	ECDSA    = "ECDSA"
+	ECDSARaw = "ECDSARaw"
+)
+
+//KeySizes are the sizes of the NIST curves registered for ECDSA
+var KeySizes = []int{256, 384, 521}
+
+//SignatureFormat is the encoding of the signatures of a scheme
+type SignatureFormat int
+
+const (
+	//DER encodes signatures as the ASN.1 sequence of r and s, as in X.509 and TLS
+	DER SignatureFormat = iota
+	//Raw encodes signatures as r followed by s, each in the size of the curve order,
+	//as in JWS and PKCS#11
+	Raw
+)
+
+func (f SignatureFormat) family() string {
+	if f == Raw {
+		return ECDSARaw
+	}
+	return ECDSA
+}
+
+func init() {
+	for _, size := range KeySizes {
+		for _, format := range []SignatureFormat{DER, Raw} {
+			size, format := size, format
+			crypto.Register(descriptor(size, format), func() crypto.THSignerHandler {
+				return NewECDSAHandler(size, format)
+			})
+		}
+	}
+}
+
+func descriptor(keySize int, format SignatureFormat) crypto.Descriptor {
+	return crypto.Descriptor{Family: format.family(), KeySize: keySize, Aggregation: crypto.NoAggregation}
+}
+
+var keyError = fmt.Errorf("%w: invalid key", crypto.ErrBadKeyEncoding)
+
+//curve returns the NIST curve of keySize bits and the hash signing with it
+func curve(keySize int) (elliptic.Curve, gocrypto.Hash, error) {
+	switch keySize {
+	case 256:
+		return elliptic.P256(), gocrypto.SHA256, nil
+	case 384:
+		return elliptic.P384(), gocrypto.SHA384, nil
+	case 521:
+		return elliptic.P521(), gocrypto.SHA512, nil
+	default:
+		return nil, 0, fmt.Errorf("%w: no NIST curve of %v bits", crypto.ErrUnsupported, keySize)
+	}
+}
+
+//ecdsaHandler signs messages with nonces derived as specified by RFC 6979,
+//so signing a message twice with a key gives the same signature.
+//Signing is not constant time, see sign.
+type ecdsaHandler struct {
+	scheme  string
+	keySize int
+	format  SignatureFormat
+}
+
+//ecdsaPubKey is encoded in a PKIX SubjectPublicKeyInfo
+type ecdsaPubKey struct {
+	*ecdsa.PublicKey
+}
+
+func (p ecdsaPubKey) MarshalBinary() (data []byte, err error) {
+	return x509.MarshalPKIXPublicKey(p.PublicKey)
+}
+
+//ecdsaPrivateKey is encoded in a SEC1 ECPrivateKey
+type ecdsaPrivateKey struct {
+	*ecdsa.PrivateKey
+}
+
+func (p ecdsaPrivateKey) MarshalBinary() (data []byte, err error) {
+	return x509.MarshalECPrivateKey(p.PrivateKey)
+}
+
+//derSignature is the ASN.1 structure of DER encoded signatures
+type derSignature struct {
+	R, S *big.Int
+}
+
+func (self ecdsaHandler) Sign(digest []byte, key crypto.PrivateKey) (signature []byte, err error) {
+	priv, ok := key.(ecdsaPrivateKey)
+
+	if !ok {
+		return nil, keyError
+	}
+
+	c, h, err := curve(self.keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	if priv.Curve != c {
+		return nil, fmt.Errorf("%w: key is not on %v", keyError, c.Params().Name)
+	}
+
+	r, s, err := sign(c, h, priv.D, hashOf(h, digest))
+	if err != nil {
+		return nil, err
+	}
+
+	return self.encodeSignature(r, s, c.Params().N)
+}
+
+//sign returns the signature (r, s) of hashed by the key d of c,
+//deriving its nonce with h as specified by RFC 6979.
+//The scalar multiplication of the NIST curves is constant time, but
+//math/big is not: the arithmetic on d and on the nonce is blinded by a
+//random factor, which does not change the signature, so its timing is not
+//a function of d and k alone. This is not a constant-time implementation,
+//signers exposed to precise timing measurements should not rely on it.
+func sign(c elliptic.Curve, h gocrypto.Hash, d *big.Int, hashed []byte) (r, s *big.Int, err error) {
+	q := c.Params().N
+	e := bits2int(hashed, q)
+	g := newNonces(q, d, h.New, hashed)
+
+	for {
+		k := g.next()
+
+		//encoded in full so its length does not leak leading zeros
+		r, _ = c.ScalarBaseMult(int2octets(k, q))
+		r.Mod(r, q)
+		if r.Sign() == 0 {
+			continue
+		}
+
+		b, err := blindingFactor(q)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		//s = k^-1 (e + r d) = (b k)^-1 (b e + (b d) r) mod q
+		bd := new(big.Int).Mul(b, d)
+		bd.Mod(bd, q)
+		s = new(big.Int).Mul(bd, r)
+		s.Add(s, new(big.Int).Mul(b, e))
+		s.Mod(s, q)
+
+		bk := new(big.Int).Mul(b, k)
+		bk.Mod(bk, q)
+		s.Mul(s, bk.ModInverse(bk, q))
+		s.Mod(s, q)
+		if s.Sign() == 0 {
+			continue
+		}
+
+		return r, s, nil
+	}
+}
+
+//blindingFactor returns a random scalar in [1, q-1]
+func blindingFactor(q *big.Int) (*big.Int, error) {
+	b, err := rand.Int(rand.Reader, new(big.Int).Sub(q, big.NewInt(1)))
+	if err != nil {
+		return nil, err
+	}
+	return b.Add(b, big.NewInt(1)), nil
+}
+
+func (self ecdsaHandler) Verify(signature []byte, msg []byte, key crypto.PublicKey) error {
+	pub, ok := key.(ecdsaPubKey)
+
+	if !ok {
+		return keyError
+	}
+
+	c, h, err := curve(self.keySize)
+	if err != nil {
+		return err
+	}
+
+	r, s, ok := self.decodeSignature(signature, c.Params().N)
+
+	if !ok || pub.Curve != c || !ecdsa.Verify(pub.PublicKey, hashOf(h, msg), r, s) {
+		return fmt.Errorf("%w: %v verification failed", crypto.ErrInvalidSignature, self.scheme)
+	}
+
+	return nil
+}
+
+func (self ecdsaHandler) encodeSignature(r, s *big.Int, q *big.Int) ([]byte, error) {
+	if self.format == Raw {
+		return append(int2octets(r, q), int2octets(s, q)...), nil
+	}
+
+	return asn1.Marshal(derSignature{r, s})
+}
+
+func (self ecdsaHandler) decodeSignature(signature []byte, q *big.Int) (r, s *big.Int, ok bool) {
+	if self.format == Raw {
+		size := (q.BitLen() + 7) / 8
+		if len(signature) != 2*size {
+			return nil, nil, false
+		}
+		return new(big.Int).SetBytes(signature[:size]), new(big.Int).SetBytes(signature[size:]), true
+	}
+
+	var sig derSignature
+	rest, err := asn1.Unmarshal(signature, &sig)
+	if err != nil || len(rest) != 0 {
+		return nil, nil, false
+	}
+	return sig.R, sig.S, true
+}
+
+func hashOf(h gocrypto.Hash, msg []byte) []byte {
+	hash := h.New()
+	hash.Write(msg)
+	return hash.Sum(nil)
+}
+
+func (self ecdsaHandler) Aggregate(share [][]byte, digest []byte, key crypto.PublicKey, t, n int) (signature []byte, err error) {
+	return nil, fmt.Errorf("%w: %v does not aggregate signatures", crypto.ErrUnsupported, self.scheme)
+}
+
+func (self ecdsaHandler) Gen(n int, t int) (crypto.PublicKey, crypto.PrivateKeyList, error) {
+	c, _, err := curve(self.keySize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	priv, err := ecdsa.GenerateKey(c, rand.Reader)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ecdsaPubKey{&priv.PublicKey},
+		crypto.PrivateKeyList{ecdsaPrivateKey{priv}}, nil
+}
+
+//SupportedOperations reports that ecdsaHandler does not aggregate signatures
+func (self ecdsaHandler) SupportedOperations() []crypto.Operation {
+	return []crypto.Operation{crypto.SignOperation, crypto.VerifyOperation, crypto.GenerateOperation}
+}
+
+func (self ecdsaHandler) DescribeScheme() crypto.SchemeInfo {
+	return crypto.SchemeInfo{
+		Name:        self.scheme,
+		Operations:  self.SupportedOperations(),
+		Family:      self.format.family(),
+		KeySize:     self.keySize,
+		Threshold:   false,
+		Aggregation: crypto.NoAggregation,
+	}
+}
+
+func (self ecdsaHandler) SchemeName() string {
+	return self.scheme
+}
+
+//UnmarshalPublic accepts both a PKIX SubjectPublicKeyInfo
+//and an uncompressed SEC1 point of the curve of the scheme
+func (self ecdsaHandler) UnmarshalPublic(data []byte) (crypto.PublicKey, error) {
+	c, _, err := curve(self.keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	if x, y := elliptic.Unmarshal(c, data); x != nil {
+		return ecdsaPubKey{&ecdsa.PublicKey{Curve: c, X: x, Y: y}}, nil
+	}
+
+	key, err := x509.ParsePKIXPublicKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", crypto.ErrBadKeyEncoding, err)
+	}
+
+	pub, ok := key.(*ecdsa.PublicKey)
+	if !ok || pub.Curve != c {
+		return nil, fmt.Errorf("%w: not a public key of %v", keyError, c.Params().Name)
+	}
+
+	return ecdsaPubKey{pub}, nil
+}
+
+func (self ecdsaHandler) UnmarshalPrivate(data []byte) (crypto.PrivateKey, error) {
+	c, _, err := curve(self.keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	priv, err := x509.ParseECPrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", crypto.ErrBadKeyEncoding, err)
+	}
+
+	if priv.Curve != c {
+		return nil, fmt.Errorf("%w: not a private key of %v", keyError, c.Params().Name)
+	}
+
+	return ecdsaPrivateKey{priv}, nil
+}
+
+func NewECDSAKeyGenerator(keySize int) crypto.KeyShareGenerator {
+	return &ecdsaHandler{descriptor(keySize, DER).Name(), keySize, DER}
+}
+
+func NewECDSA(keySize int, format SignatureFormat) crypto.SignerVerifierAggregator {
+	return &ecdsaHandler{descriptor(keySize, format).Name(), keySize, format}
+}
+
+func NewECDSAHandler(keySize int, format SignatureFormat) crypto.THSignerHandler {
+	return &ecdsaHandler{descriptor(keySize, format).Name(), keySize, format}
+}
@@ -0,0 +1,274 @@
+package ecdsa
+
+import (
+	gocrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+//rfc6979Vectors are the P-256 and SHA-256 test vectors of RFC 6979, section A.2.5
+var rfc6979Vectors = []struct {
+	msg string
+	k   string
+	r   string
+	s   string
+}{
+	{
+		msg: "sample",
+		k:   "A6E3C57DD01ABE90086538398355DD4C3B17AA873382B0F24D6129493D8AAD60",
+		r:   "EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+		s:   "F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8",
+	},
+	{
+		msg: "test",
+		k:   "D16B6AE827F17175E040871A1C7EC3500192C4C92677336EC2537ACAEE0008E0",
+		r:   "F1ABB023518351CD71D881567B1EA663ED3EFCF6C5132B354F28D3B0B7D38367",
+		s:   "019F4113742A2B14BD25926B49C649155F267E60D3814B4C0CC84250E46F0083",
+	},
+}
+
+const rfc6979Key = "C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721"
+
+func rfc6979PrivateKey(t *testing.T) ecdsaPrivateKey {
+	d, ok := new(big.Int).SetString(rfc6979Key, 16)
+	require.True(t, ok)
+
+	priv := &ecdsa.PrivateKey{D: d}
+	priv.Curve = elliptic.P256()
+	priv.X, priv.Y = priv.Curve.ScalarBaseMult(d.Bytes())
+	return ecdsaPrivateKey{priv}
+}
+
+func TestECDSA(t *testing.T) {
+	msg := []byte("ecdsa testing")
+
+	for _, size := range KeySizes {
+		for _, format := range []SignatureFormat{DER, Raw} {
+			s := NewECDSAHandler(size, format)
+			public, private, err := s.Gen(0, 0)
+			require.Nil(t, err, s.SchemeName())
+			require.Len(t, private, 1)
+
+			sig, err := s.Sign(msg, private[0])
+			require.Nil(t, err, s.SchemeName())
+			require.Nil(t, s.Verify(sig, msg, public), s.SchemeName())
+
+			//nonces are deterministic
+			again, err := s.Sign(msg, private[0])
+			require.Nil(t, err)
+			assert.Equal(t, sig, again, s.SchemeName())
+
+			err = s.Verify(sig, []byte("other message"), public)
+			assert.True(t, errors.Is(err, crypto.ErrInvalidSignature), s.SchemeName())
+
+			err = s.Verify(sig[:10], msg, public)
+			assert.True(t, errors.Is(err, crypto.ErrInvalidSignature), s.SchemeName())
+		}
+	}
+}
+
+func TestRFC6979Nonces(t *testing.T) {
+	priv := rfc6979PrivateKey(t)
+	q := elliptic.P256().Params().N
+
+	for _, v := range rfc6979Vectors {
+		hashed := sha256.Sum256([]byte(v.msg))
+		k := newNonces(q, priv.D, sha256.New, hashed[:]).next()
+		assert.Equal(t, v.k, strings.ToUpper(hex.EncodeToString(k.Bytes())), v.msg)
+	}
+}
+
+func TestRFC6979Signatures(t *testing.T) {
+	priv := rfc6979PrivateKey(t)
+	pub := ecdsaPubKey{&priv.PublicKey}
+
+	raw := NewECDSAHandler(256, Raw)
+	der := NewECDSAHandler(256, DER)
+
+	for _, v := range rfc6979Vectors {
+		sig, err := raw.Sign([]byte(v.msg), priv)
+		require.Nil(t, err)
+		assert.Equal(t, v.r+v.s, strings.ToUpper(hex.EncodeToString(sig)), v.msg)
+		assert.Nil(t, raw.Verify(sig, []byte(v.msg), pub), v.msg)
+
+		//the DER format carries the same r and s
+		sig, err = der.Sign([]byte(v.msg), priv)
+		require.Nil(t, err)
+		var decoded derSignature
+		_, err = asn1.Unmarshal(sig, &decoded)
+		require.Nil(t, err)
+		assert.Equal(t, v.r, strings.ToUpper(hex.EncodeToString(int2octets(decoded.R, elliptic.P256().Params().N))), v.msg)
+		assert.Equal(t, v.s, strings.ToUpper(hex.EncodeToString(int2octets(decoded.S, elliptic.P256().Params().N))), v.msg)
+		assert.Nil(t, der.Verify(sig, []byte(v.msg), pub), v.msg)
+
+		//signatures of one format are not accepted by the other
+		assert.NotNil(t, raw.Verify(sig, []byte(v.msg), pub), v.msg)
+	}
+}
+
+//rfc6979CurveVectors are the SHA-256 test vectors of RFC 6979
+//for P-384, section A.2.6, and P-521, section A.2.7
+var rfc6979CurveVectors = []struct {
+	curve elliptic.Curve
+	key   string
+	x     string
+	y     string
+	msg   string
+	r     string
+	s     string
+}{
+	{
+		curve: elliptic.P384(),
+		key:   "6B9D3DAD2E1B8C1C05B19875B6659F4DE23C3B667BF297BA9AA47740787137D896D5724E4C70A825F872C9EA60D2EDF5",
+		x:     "EC3A4E415B4E19A4568618029F427FA5DA9A8BC4AE92E02E06AAE5286B300C64DEF8F0EA9055866064A254515480BC13",
+		y:     "8015D9B72D7D57244EA8EF9AC0C621896708A59367F9DFB9F54CA84B3F1C9DB1288B231C3AE0D4FE7344FD2533264720",
+		msg:   "sample",
+		r:     "21B13D1E013C7FA1392D03C5F99AF8B30C570C6F98D4EA8E354B63A21D3DAA33BDE1E888E63355D92FA2B3C36D8FB2CD",
+		s:     "F3AA443FB107745BF4BD77CB3891674632068A10CA67E3D45DB2266FA7D1FEEBEFDC63ECCD1AC42EC0CB8668A4FA0AB0",
+	},
+	{
+		curve: elliptic.P384(),
+		key:   "6B9D3DAD2E1B8C1C05B19875B6659F4DE23C3B667BF297BA9AA47740787137D896D5724E4C70A825F872C9EA60D2EDF5",
+		x:     "EC3A4E415B4E19A4568618029F427FA5DA9A8BC4AE92E02E06AAE5286B300C64DEF8F0EA9055866064A254515480BC13",
+		y:     "8015D9B72D7D57244EA8EF9AC0C621896708A59367F9DFB9F54CA84B3F1C9DB1288B231C3AE0D4FE7344FD2533264720",
+		msg:   "test",
+		r:     "6D6DEFAC9AB64DABAFE36C6BF510352A4CC27001263638E5B16D9BB51D451559F918EEDAF2293BE5B475CC8F0188636B",
+		s:     "2D46F3BECBCC523D5F1A1256BF0C9B024D879BA9E838144C8BA6BAEB4B53B47D51AB373F9845C0514EEFB14024787265",
+	},
+	{
+		curve: elliptic.P521(),
+		key:   "0FAD06DAA62BA3B25D2FB40133DA757205DE67F5BB0018FEE8C86E1B68C7E75CAA896EB32F1F47C70855836A6D16FCC1466F6D8FBEC67DB89EC0C08B0E996B83538",
+		x:     "1894550D0785932E00EAA23B694F213F8C3121F86DC97A04E5A7167DB4E5BCD371123D46E45DB6B5D5370A7F20FB633155D38FFA16D2BD761DCAC474B9A2F5023A4",
+		y:     "0493101C962CD4D2FDDF782285E64584139C2F91B47F87FF82354D6630F746A28A0DB25741B5B34A828008B22ACC23F924FAAFBD4D33F81EA66956DFEAA2BFDFCF5",
+		msg:   "sample",
+		r:     "1511BB4D675114FE266FC4372B87682BAECC01D3CC62CF2303C92B3526012659D16876E25C7C1E57648F23B73564D67F61C6F14D527D54972810421E7D87589E1A7",
+		s:     "04A171143A83163D6DF460AAF61522695F207A58B95C0644D87E52AA1A347916E4F7A72930B1BC06DBE22CE3F58264AFD23704CBB63B29B931F7DE6C9D949A7ECFC",
+	},
+	{
+		curve: elliptic.P521(),
+		key:   "0FAD06DAA62BA3B25D2FB40133DA757205DE67F5BB0018FEE8C86E1B68C7E75CAA896EB32F1F47C70855836A6D16FCC1466F6D8FBEC67DB89EC0C08B0E996B83538",
+		x:     "1894550D0785932E00EAA23B694F213F8C3121F86DC97A04E5A7167DB4E5BCD371123D46E45DB6B5D5370A7F20FB633155D38FFA16D2BD761DCAC474B9A2F5023A4",
+		y:     "0493101C962CD4D2FDDF782285E64584139C2F91B47F87FF82354D6630F746A28A0DB25741B5B34A828008B22ACC23F924FAAFBD4D33F81EA66956DFEAA2BFDFCF5",
+		msg:   "test",
+		r:     "00E871C4A14F993C6C7369501900C4BC1E9C7B0B4BA44E04868B30B41D8071042EB28C4C250411D0CE08CD197E4188EA4876F279F90B3D8D74A3C76E6F1E4656AA8",
+		s:     "0CD52DBAA33B063C3A6CD8058A1FB0A46A4754B034FCC644766CA14DA8CA5CA9FDE00E88C1AD60CCBA759025299079D7A427EC3CC5B619BFBC828E7769BCD694E86",
+	},
+}
+
+func TestRFC6979OtherCurves(t *testing.T) {
+	for _, v := range rfc6979CurveVectors {
+		name := v.curve.Params().Name + " " + v.msg
+		d := hexInt(t, v.key)
+
+		x, y := v.curve.ScalarBaseMult(d.Bytes())
+		assert.Equal(t, hexInt(t, v.x), x, name)
+		assert.Equal(t, hexInt(t, v.y), y, name)
+
+		hashed := sha256.Sum256([]byte(v.msg))
+		r, s, err := sign(v.curve, gocrypto.SHA256, d, hashed[:])
+		require.Nil(t, err)
+		assert.Equal(t, hexInt(t, v.r), r, name)
+		assert.Equal(t, hexInt(t, v.s), s, name)
+	}
+}
+
+func hexInt(t *testing.T, s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 16)
+	require.True(t, ok, s)
+	return v
+}
+
+func TestMarshallUnmarshallKeys(t *testing.T) {
+	msg := []byte("ecdsa testing")
+
+	for _, size := range KeySizes {
+		s := NewECDSAHandler(size, DER)
+		public, private, err := s.Gen(0, 0)
+		require.Nil(t, err)
+
+		bytePubKey, err := public.MarshalBinary()
+		require.Nil(t, err)
+		bytePrivKey, err := private[0].MarshalBinary()
+		require.Nil(t, err)
+
+		public, err = s.UnmarshalPublic(bytePubKey)
+		require.Nil(t, err)
+		private[0], err = s.UnmarshalPrivate(bytePrivKey)
+		require.Nil(t, err)
+
+		sig, err := s.Sign(msg, private[0])
+		require.Nil(t, err)
+		require.Nil(t, s.Verify(sig, msg, public))
+
+		//uncompressed SEC1 points are accepted too
+		pub := public.(ecdsaPubKey)
+		point, err := s.UnmarshalPublic(elliptic.Marshal(pub.Curve, pub.X, pub.Y))
+		require.Nil(t, err)
+		require.Nil(t, s.Verify(sig, msg, point))
+	}
+}
+
+func TestUnmarshalInvalidKeys(t *testing.T) {
+	s := NewECDSAHandler(256, DER)
+
+	_, err := s.UnmarshalPublic([]byte("not a key"))
+	assert.True(t, errors.Is(err, crypto.ErrBadKeyEncoding))
+
+	_, err = s.UnmarshalPrivate([]byte("not a key"))
+	assert.True(t, errors.Is(err, crypto.ErrBadKeyEncoding))
+
+	//keys of other curves are rejected
+	public, private, err := NewECDSAKeyGenerator(384).Gen(0, 0)
+	require.Nil(t, err)
+
+	bytePubKey, err := public.MarshalBinary()
+	require.Nil(t, err)
+	_, err = s.UnmarshalPublic(bytePubKey)
+	assert.True(t, errors.Is(err, crypto.ErrBadKeyEncoding))
+
+	bytePrivKey, err := private[0].MarshalBinary()
+	require.Nil(t, err)
+	_, err = s.UnmarshalPrivate(bytePrivKey)
+	assert.True(t, errors.Is(err, crypto.ErrBadKeyEncoding))
+
+	_, err = s.Sign([]byte("msg"), private[0])
+	assert.True(t, errors.Is(err, crypto.ErrBadKeyEncoding))
+}
+
+func TestUnsupportedCurve(t *testing.T) {
+	_, _, err := NewECDSAKeyGenerator(224).Gen(0, 0)
+	assert.True(t, errors.Is(err, crypto.ErrUnsupported))
+}
+
+func TestAggregateUnsupported(t *testing.T) {
+	s := NewECDSAHandler(256, DER)
+	public, _, err := s.Gen(0, 0)
+	require.Nil(t, err)
+
+	_, err = s.Aggregate(nil, []byte("msg"), public, 1, 1)
+	assert.True(t, errors.Is(err, crypto.ErrUnsupported))
+	assert.False(t, crypto.Supports(s, crypto.AggregateOperation))
+}
+
+func TestRegistered(t *testing.T) {
+	for _, name := range []string{"ECDSA256", "ECDSA384", "ECDSA521", "ECDSARaw256", "ECDSARaw384", "ECDSARaw521"} {
+		h, err := crypto.Lookup(name)
+		require.Nil(t, err, name)
+		assert.Equal(t, name, h.SchemeName())
+	}
+
+	h, err := crypto.Lookup("ECDSARaw384")
+	require.Nil(t, err)
+	info := crypto.DescribeScheme(h)
+	assert.Equal(t, crypto.Descriptor{Family: ECDSARaw, KeySize: 384, Aggregation: crypto.NoAggregation}, info.Descriptor())
+}
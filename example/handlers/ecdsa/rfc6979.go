@@ -0,0 +1,91 @@
+package ecdsa
+
+import (
+	"crypto/hmac"
+	"hash"
+	"math/big"
+)
+
+//nonces derives the deterministic nonces of RFC 6979, section 3.2,
+//for signing the hash h1 with the private key x of order q
+type nonces struct {
+	q       *big.Int
+	newHash func() hash.Hash
+	k       []byte
+	v       []byte
+}
+
+func newNonces(q *big.Int, x *big.Int, newHash func() hash.Hash, h1 []byte) *nonces {
+	g := &nonces{q: q, newHash: newHash}
+
+	hlen := newHash().Size()
+	g.v = make([]byte, hlen)
+	g.k = make([]byte, hlen)
+	for i := range g.v {
+		g.v[i] = 0x01
+	}
+
+	key := int2octets(x, q)
+	msg := bits2octets(h1, q)
+
+	g.k = g.mac(g.v, []byte{0x00}, key, msg)
+	g.v = g.mac(g.v)
+	g.k = g.mac(g.v, []byte{0x01}, key, msg)
+	g.v = g.mac(g.v)
+
+	return g
+}
+
+//next returns the following candidate nonce in [1, q-1]
+func (g *nonces) next() *big.Int {
+	qlen := g.q.BitLen()
+
+	for {
+		t := make([]byte, 0, (qlen+7)/8)
+		for len(t)*8 < qlen {
+			g.v = g.mac(g.v)
+			t = append(t, g.v...)
+		}
+
+		k := bits2int(t, g.q)
+		g.k = g.mac(g.v, []byte{0x00})
+		g.v = g.mac(g.v)
+
+		if k.Sign() > 0 && k.Cmp(g.q) < 0 {
+			return k
+		}
+	}
+}
+
+func (g *nonces) mac(data ...[]byte) []byte {
+	m := hmac.New(g.newHash, g.k)
+	for _, d := range data {
+		m.Write(d)
+	}
+	return m.Sum(nil)
+}
+
+//bits2int keeps the leftmost bits of b, as many as q has
+func bits2int(b []byte, q *big.Int) *big.Int {
+	v := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - q.BitLen(); excess > 0 {
+		v.Rsh(v, uint(excess))
+	}
+	return v
+}
+
+//int2octets encodes x in as many bytes as q needs
+func int2octets(x *big.Int, q *big.Int) []byte {
+	out := make([]byte, (q.BitLen()+7)/8)
+	b := x.Bytes()
+	copy(out[len(out)-len(b):], b)
+	return out
+}
+
+func bits2octets(b []byte, q *big.Int) []byte {
+	z := bits2int(b, q)
+	if z.Cmp(q) >= 0 {
+		z.Sub(z, q)
+	}
+	return int2octets(z, q)
+}
@@ -0,0 +1,141 @@
+package ed25519
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+)
+
+//Ed25519 is the name of the scheme, and the registry family it is the only member of
+const Ed25519 = "Ed25519"
+
+func init() {
+	crypto.Register(descriptor(), NewEd25519Handler)
+}
+
+func descriptor() crypto.Descriptor {
+	return crypto.Descriptor{Family: Ed25519, Aggregation: crypto.NoAggregation}
+}
+
+var keyError = fmt.Errorf("%w: invalid key", crypto.ErrBadKeyEncoding)
+
+//ed25519Handler signs messages as specified by RFC 8032,
+//without hashing them beforehand
+type ed25519Handler struct {
+	scheme string
+}
+
+//pubKey is encoded in its standard 32 bytes
+type pubKey struct {
+	ed25519.PublicKey
+}
+
+func (p pubKey) MarshalBinary() (data []byte, err error) {
+	return append([]byte{}, p.PublicKey...), nil
+}
+
+//privKey is encoded in the 64 bytes of its seed followed by its public key
+type privKey struct {
+	ed25519.PrivateKey
+}
+
+func (p privKey) MarshalBinary() (data []byte, err error) {
+	return append([]byte{}, p.PrivateKey...), nil
+}
+
+func (self ed25519Handler) Sign(digest []byte, key crypto.PrivateKey) (signature []byte, err error) {
+	priv, ok := key.(privKey)
+
+	if !ok {
+		return nil, keyError
+	}
+
+	return ed25519.Sign(priv.PrivateKey, digest), nil
+}
+
+func (self ed25519Handler) Verify(signature []byte, msg []byte, key crypto.PublicKey) error {
+	pub, ok := key.(pubKey)
+
+	if !ok {
+		return keyError
+	}
+
+	if len(signature) != ed25519.SignatureSize || !ed25519.Verify(pub.PublicKey, msg, signature) {
+		return fmt.Errorf("%w: %v verification failed", crypto.ErrInvalidSignature, self.scheme)
+	}
+
+	return nil
+}
+
+func (self ed25519Handler) Aggregate(share [][]byte, digest []byte, key crypto.PublicKey, t, n int) (signature []byte, err error) {
+	return nil, fmt.Errorf("%w: %v does not aggregate signatures", crypto.ErrUnsupported, self.scheme)
+}
+
+func (self ed25519Handler) Gen(n int, t int) (crypto.PublicKey, crypto.PrivateKeyList, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pubKey{pub},
+		crypto.PrivateKeyList{privKey{priv}}, nil
+}
+
+//SupportedOperations reports that ed25519Handler does not aggregate signatures
+func (self ed25519Handler) SupportedOperations() []crypto.Operation {
+	return []crypto.Operation{crypto.SignOperation, crypto.VerifyOperation, crypto.GenerateOperation}
+}
+
+func (self ed25519Handler) DescribeScheme() crypto.SchemeInfo {
+	return crypto.SchemeInfo{
+		Name:        self.scheme,
+		Operations:  self.SupportedOperations(),
+		Family:      Ed25519,
+		Threshold:   false,
+		Aggregation: crypto.NoAggregation,
+	}
+}
+
+func (self ed25519Handler) SchemeName() string {
+	return self.scheme
+}
+
+func (self ed25519Handler) UnmarshalPublic(data []byte) (crypto.PublicKey, error) {
+	if len(data) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: public key of %v bytes", keyError, len(data))
+	}
+
+	return pubKey{append(ed25519.PublicKey{}, data...)}, nil
+}
+
+//UnmarshalPrivate accepts both the 32 byte seed of a key
+//and the 64 byte encoding of the seed and the public key
+func (self ed25519Handler) UnmarshalPrivate(data []byte) (crypto.PrivateKey, error) {
+	switch len(data) {
+	case ed25519.SeedSize:
+		return privKey{ed25519.NewKeyFromSeed(data)}, nil
+	case ed25519.PrivateKeySize:
+		priv := ed25519.NewKeyFromSeed(data[:ed25519.SeedSize])
+		if !bytes.Equal(priv[ed25519.SeedSize:], data[ed25519.SeedSize:]) {
+			return nil, fmt.Errorf("%w: public key does not match the seed", keyError)
+		}
+		return privKey{priv}, nil
+	default:
+		return nil, fmt.Errorf("%w: private key of %v bytes", keyError, len(data))
+	}
+}
+
+func NewEd25519KeyGenerator() crypto.KeyShareGenerator {
+	return &ed25519Handler{descriptor().Name()}
+}
+
+func NewEd25519() crypto.SignerVerifierAggregator {
+	return &ed25519Handler{descriptor().Name()}
+}
+
+func NewEd25519Handler() crypto.THSignerHandler {
+	return &ed25519Handler{descriptor().Name()}
+}
@@ -0,0 +1,146 @@
+package ed25519
+
+import (
+	"encoding/hex"
+	"errors"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+//rfc8032Vectors are the first test vectors of RFC 8032, section 7.1
+var rfc8032Vectors = []struct {
+	secret    string
+	public    string
+	msg       string
+	signature string
+}{
+	{
+		secret:    "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+		public:    "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+		msg:       "",
+		signature: "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+	},
+	{
+		secret:    "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+		public:    "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+		msg:       "72",
+		signature: "92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00",
+	},
+	{
+		secret:    "c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7",
+		public:    "fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025",
+		msg:       "af82",
+		signature: "6291d657deec24024827e69c3abe01a30ce548a284743a445e3680d7db5ac3ac18ff9b538d16f290ae67f760984dc6594a7c15e9716ed28dc027beceea1ec40a",
+	},
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.Nil(t, err)
+	return b
+}
+
+func TestEd25519(t *testing.T) {
+	msg := []byte("ed25519 testing")
+	s := NewEd25519Handler()
+	public, private, err := s.Gen(0, 0)
+	require.Nil(t, err)
+	require.Len(t, private, 1)
+
+	sig, err := s.Sign(msg, private[0])
+	require.Nil(t, err)
+	require.Nil(t, s.Verify(sig, msg, public))
+
+	err = s.Verify(sig, []byte("other message"), public)
+	assert.True(t, errors.Is(err, crypto.ErrInvalidSignature))
+
+	err = s.Verify(sig[:10], msg, public)
+	assert.True(t, errors.Is(err, crypto.ErrInvalidSignature))
+}
+
+func TestRFC8032Vectors(t *testing.T) {
+	s := NewEd25519Handler()
+
+	for i, v := range rfc8032Vectors {
+		priv, err := s.UnmarshalPrivate(decodeHex(t, v.secret))
+		require.Nil(t, err, "vector %v", i+1)
+		pub, err := s.UnmarshalPublic(decodeHex(t, v.public))
+		require.Nil(t, err, "vector %v", i+1)
+
+		//the 64 byte encoding is the seed followed by the public key
+		privBytes, err := priv.MarshalBinary()
+		require.Nil(t, err)
+		assert.Equal(t, v.secret+v.public, hex.EncodeToString(privBytes), "vector %v", i+1)
+
+		sig, err := s.Sign(decodeHex(t, v.msg), priv)
+		require.Nil(t, err)
+		assert.Equal(t, v.signature, hex.EncodeToString(sig), "vector %v", i+1)
+		assert.Nil(t, s.Verify(sig, decodeHex(t, v.msg), pub), "vector %v", i+1)
+	}
+}
+
+func TestMarshallUnmarshallKeys(t *testing.T) {
+	msg := []byte("ed25519 testing")
+	s := NewEd25519Handler()
+	public, private, err := s.Gen(0, 0)
+	require.Nil(t, err)
+
+	bytePubKey, err := public.MarshalBinary()
+	require.Nil(t, err)
+	assert.Len(t, bytePubKey, 32)
+	bytePrivKey, err := private[0].MarshalBinary()
+	require.Nil(t, err)
+	assert.Len(t, bytePrivKey, 64)
+
+	public, err = s.UnmarshalPublic(bytePubKey)
+	require.Nil(t, err)
+	private[0], err = s.UnmarshalPrivate(bytePrivKey)
+	require.Nil(t, err)
+
+	sig, err := s.Sign(msg, private[0])
+	require.Nil(t, err)
+	require.Nil(t, s.Verify(sig, msg, public))
+
+	//a key decoded from its seed signs the same
+	seeded, err := s.UnmarshalPrivate(bytePrivKey[:32])
+	require.Nil(t, err)
+	seededSig, err := s.Sign(msg, seeded)
+	require.Nil(t, err)
+	assert.Equal(t, sig, seededSig)
+}
+
+func TestUnmarshalInvalidKeys(t *testing.T) {
+	s := NewEd25519Handler()
+
+	_, err := s.UnmarshalPublic([]byte("not a key"))
+	assert.True(t, errors.Is(err, crypto.ErrBadKeyEncoding))
+
+	_, err = s.UnmarshalPrivate([]byte("not a key"))
+	assert.True(t, errors.Is(err, crypto.ErrBadKeyEncoding))
+
+	//the public half must match the seed
+	mismatched := decodeHex(t, rfc8032Vectors[0].secret+rfc8032Vectors[1].public)
+	_, err = s.UnmarshalPrivate(mismatched)
+	assert.True(t, errors.Is(err, crypto.ErrBadKeyEncoding))
+}
+
+func TestAggregateUnsupported(t *testing.T) {
+	s := NewEd25519Handler()
+	public, _, err := s.Gen(0, 0)
+	require.Nil(t, err)
+
+	_, err = s.Aggregate(nil, []byte("msg"), public, 1, 1)
+	assert.True(t, errors.Is(err, crypto.ErrUnsupported))
+	assert.False(t, crypto.Supports(s, crypto.AggregateOperation))
+}
+
+func TestRegistered(t *testing.T) {
+	h, err := crypto.Lookup(Ed25519)
+	require.Nil(t, err)
+	assert.Equal(t, Ed25519, h.SchemeName())
+
+	info := crypto.DescribeScheme(h)
+	assert.Equal(t, crypto.Descriptor{Family: Ed25519, Aggregation: crypto.NoAggregation}, info.Descriptor())
+}
@@ -4,18 +4,96 @@ import (
 	"crypto/rand"
 	gocrypto "crypto"
 	"crypto/rsa"
-	"crypto/sha256"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
 	"crypto/x509"
-	"errors"
 	"fmt"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
 )
 
-const RSA = "RSA"
+//RSA is the family of the schemes signing with PKCS #1 v1.5 padding
+//and RSAPSS the one of the schemes signing with RSASSA-PSS.
+//Both use RSA keys, but are registered as different families, so keys
+//are generated and stored for each family apart and a key generated for
+//one family is not used by the schemes of the other.
+const (
+	RSA    = "RSA"
+	RSAPSS = "RSAPSS"
+)
+
+//KeySizes are the key sizes registered for RSA
+var KeySizes = []int{1024, 2048, 3072, 4096}
+
+//PSSKeySizes are the key sizes registered for RSAPSS
+var PSSKeySizes = []int{2048, 3072, 4096}
+
+func init() {
+	for _, size := range KeySizes {
+		size := size
+		crypto.Register(descriptor(RSA, size), func() crypto.THSignerHandler {
+			return NewRSAHandler(size)
+		})
+	}
+
+	for _, size := range PSSKeySizes {
+		size := size
+		crypto.Register(descriptor(RSAPSS, size), func() crypto.THSignerHandler {
+			return NewRSAPSSHandler(size)
+		})
+	}
+}
+
+func descriptor(family string, keySize int) crypto.Descriptor {
+	return crypto.Descriptor{Family: family, KeySize: keySize, Aggregation: crypto.NoAggregation}
+}
+
+var keyError = fmt.Errorf("%w: invalid key", crypto.ErrBadKeyEncoding)
+
+//PSSOption configures the padding of the RSAPSS handlers
+type PSSOption func(*rsa.PSSOptions)
+
+//WithSaltLength sets the length of the salt of the signatures, in bytes.
+//It also accepts rsa.PSSSaltLengthEqualsHash, the default, and
+//rsa.PSSSaltLengthAuto, which signs with the longest salt and verifies
+//signatures of any salt length. As rsa.PSSSaltLengthAuto is 0,
+//signatures cannot be made without a salt.
+func WithSaltLength(length int) PSSOption {
+	return func(opts *rsa.PSSOptions) {
+		opts.SaltLength = length
+	}
+}
+
+//WithHash sets the hash of the messages and of the padding, SHA-256 by default
+func WithHash(hash gocrypto.Hash) PSSOption {
+	return func(opts *rsa.PSSOptions) {
+		opts.Hash = hash
+	}
+}
 
+//rsaHandler signs with PKCS #1 v1.5 padding, or with RSASSA-PSS if pss is set
 type rsaHandler struct {
 	scheme string
 	keySize int
+	hash gocrypto.Hash
+	pss *rsa.PSSOptions
+}
+
+func (self rsaHandler) family() string {
+	if self.pss != nil {
+		return RSAPSS
+	}
+	return RSA
+}
+
+//digest hashes msg with the hash of the handler
+func (self rsaHandler) digest(msg []byte) ([]byte, error) {
+	if !self.hash.Available() {
+		return nil, fmt.Errorf("%w: hash %v is not available", crypto.ErrUnsupported, self.hash)
+	}
+
+	h := self.hash.New()
+	h.Write(msg)
+	return h.Sum(nil), nil
 }
 
 type rsaPubKey struct {
@@ -39,59 +117,127 @@ func (self rsaHandler) Sign(digest []byte, key crypto.PrivateKey) (signature []b
 	v,ok := key.(rsaPrivateKey)
 
 	if !ok {
-		return nil,errors.New("invalid key")
+		return nil,keyError
 	}
 
 	rng := rand.Reader
-	hashed := sha256.Sum256(digest)
-	return rsa.SignPKCS1v15(rng,v.PrivateKey,gocrypto.SHA256,hashed[:])
+	hashed, err := self.digest(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	if self.pss != nil {
+		return rsa.SignPSS(rng,v.PrivateKey,self.hash,hashed,self.pss)
+	}
+	return rsa.SignPKCS1v15(rng,v.PrivateKey,self.hash,hashed)
 }
 
 func (self rsaHandler) Verify(signature []byte, msg []byte, key crypto.PublicKey) error {
 	v,ok := key.(rsaPubKey)
 
 	if !ok {
-		return errors.New("invalid key")
+		return keyError
 	}
 
-	hashed := sha256.Sum256(msg)
+	hashed, err := self.digest(msg)
+	if err != nil {
+		return err
+	}
 
-	return rsa.VerifyPKCS1v15(v.PublicKey,gocrypto.SHA256,hashed[:],signature)
+	if self.pss != nil {
+		return rsa.VerifyPSS(v.PublicKey,self.hash,hashed,signature,self.pss)
+	}
+	return rsa.VerifyPKCS1v15(v.PublicKey,self.hash,hashed,signature)
 }
 
 func (self rsaHandler) Aggregate(share [][]byte, digest []byte, key crypto.PublicKey, t, n int) (signature []byte, err error) {
-	panic("Not implemented")
+	return nil, fmt.Errorf("%w: %v does not aggregate signatures", crypto.ErrUnsupported, self.scheme)
 }
 
-func (self rsaHandler) Gen(n int, t int) (crypto.PublicKey, crypto.PrivateKeyList) {
-	priv, _ := rsa.GenerateKey(rand.Reader, self.keySize)
+func (self rsaHandler) Gen(n int, t int) (crypto.PublicKey, crypto.PrivateKeyList, error) {
+	priv, err := rsa.GenerateKey(rand.Reader, self.keySize)
+
+	if err != nil {
+		return nil, nil, err
+	}
 
 	return rsaPubKey{&priv.PublicKey},
-			crypto.PrivateKeyList{rsaPrivateKey{priv}}
+			crypto.PrivateKeyList{rsaPrivateKey{priv}}, nil
+}
+
+//SupportedOperations reports that rsaHandler does not aggregate signatures
+func (self rsaHandler) SupportedOperations() []crypto.Operation {
+	return []crypto.Operation{crypto.SignOperation, crypto.VerifyOperation, crypto.GenerateOperation}
+}
+
+func (self rsaHandler) DescribeScheme() crypto.SchemeInfo {
+	return crypto.SchemeInfo{
+		Name:        self.scheme,
+		Operations:  self.SupportedOperations(),
+		Family:      self.family(),
+		KeySize:     self.keySize,
+		Threshold:   false,
+		Aggregation: crypto.NoAggregation,
+	}
 }
 
 func (self rsaHandler) SchemeName() string {
 	return self.scheme
 }
 
-func (self rsaHandler) UnmarshalPublic(data []byte) crypto.PublicKey {
-	pubKey,_ := x509.ParsePKCS1PublicKey(data)
-	return  rsaPubKey{pubKey}
+func (self rsaHandler) UnmarshalPublic(data []byte) (crypto.PublicKey, error) {
+	pubKey, err := x509.ParsePKCS1PublicKey(data)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", crypto.ErrBadKeyEncoding, err)
+	}
+
+	return rsaPubKey{pubKey}, nil
 }
 
-func (self rsaHandler) UnmarshalPrivate(data []byte) crypto.PrivateKey {
-	privKey,_ := x509.ParsePKCS1PrivateKey(data)
-	return  rsaPrivateKey{privKey}
+func (self rsaHandler) UnmarshalPrivate(data []byte) (crypto.PrivateKey, error) {
+	privKey, err := x509.ParsePKCS1PrivateKey(data)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", crypto.ErrBadKeyEncoding, err)
+	}
+
+	return rsaPrivateKey{privKey}, nil
+}
+
+func newRSAHandler(keySize int) *rsaHandler {
+	return &rsaHandler{descriptor(RSA, keySize).Name(),keySize,gocrypto.SHA256,nil}
+}
+
+func newRSAPSSHandler(keySize int, opts []PSSOption) *rsaHandler {
+	pss := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: gocrypto.SHA256}
+	for _, opt := range opts {
+		opt(pss)
+	}
+
+	return &rsaHandler{descriptor(RSAPSS, keySize).Name(),keySize,pss.Hash,pss}
 }
 
 func NewRSAKeyGenerator(keySize int) crypto.KeyShareGenerator {
-	return &rsaHandler{RSA + fmt.Sprint(keySize),keySize}
+	return newRSAHandler(keySize)
 }
 
 func NewRSA(keySize int) crypto.SignerVerifierAggregator {
-	return &rsaHandler{RSA + fmt.Sprint(keySize),keySize}
+	return newRSAHandler(keySize)
 }
 
 func NewRSAHandler(keySize int) crypto.THSignerHandler {
-	return &rsaHandler{RSA + fmt.Sprint(keySize),keySize}
+	return newRSAHandler(keySize)
+}
+
+func NewRSAPSSKeyGenerator(keySize int) crypto.KeyShareGenerator {
+	return newRSAPSSHandler(keySize, nil)
+}
+
+func NewRSAPSS(keySize int, opts ...PSSOption) crypto.SignerVerifierAggregator {
+	return newRSAPSSHandler(keySize, opts)
+}
+
+func NewRSAPSSHandler(keySize int, opts ...PSSOption) crypto.THSignerHandler {
+	return newRSAPSSHandler(keySize, opts)
 }
@@ -1,6 +1,10 @@
 package rsa
 
 import (
+	gocrypto "crypto"
+	"crypto/rsa"
+	"errors"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -11,7 +15,8 @@ const KEY_SIZE = 1024
 func TestRSA(t *testing.T) {
 	msg := []byte("rsa testing")
 	s := NewRSAHandler(KEY_SIZE)
-	public,private := s.Gen(0,0)
+	public,private,err := s.Gen(0,0)
+	require.Nil(t, err)
 	sig, err := s.Sign(msg, private[0])
 	require.Nil(t, err)
 	err = s.Verify(sig,msg, public)
@@ -22,18 +27,123 @@ func TestMarshallUnmarshallKeys(t *testing.T) {
 
 	msg := []byte("rsa testing")
 	s := NewRSAHandler(KEY_SIZE)
-	public,private := s.Gen(0,0)
+	public,private,err := s.Gen(0,0)
+	require.Nil(t, err)
 
 	bytePubKey,err := public.MarshalBinary()
 	assert.Nil(t,err)
 	bytePrivKey,err := private[0].MarshalBinary()
 	assert.Nil(t,err)
 
-	public = s.UnmarshalPublic(bytePubKey)
-	private[0] = s.UnmarshalPrivate(bytePrivKey)
+	public, err = s.UnmarshalPublic(bytePubKey)
+	require.Nil(t, err)
+	private[0], err = s.UnmarshalPrivate(bytePrivKey)
+	require.Nil(t, err)
 
 	sig, err := s.Sign(msg, private[0])
 	require.Nil(t, err)
 	err = s.Verify(sig,msg, public)
 	require.Nil(t, err)
 }
+
+func TestUnmarshalInvalidKeys(t *testing.T) {
+	s := NewRSAHandler(KEY_SIZE)
+
+	_, err := s.UnmarshalPublic([]byte("not a key"))
+	assert.True(t, errors.Is(err, crypto.ErrBadKeyEncoding))
+
+	_, err = s.UnmarshalPrivate([]byte("not a key"))
+	assert.True(t, errors.Is(err, crypto.ErrBadKeyEncoding))
+}
+
+func TestAggregateUnsupported(t *testing.T) {
+	s := NewRSAHandler(KEY_SIZE)
+	public, _, err := s.Gen(0, 0)
+	require.Nil(t, err)
+
+	_, err = s.Aggregate(nil, []byte("msg"), public, 0, 0)
+	assert.True(t, errors.Is(err, crypto.ErrUnsupported))
+
+	assert.False(t, crypto.Supports(s, crypto.AggregateOperation))
+	assert.True(t, crypto.Supports(s, crypto.SignOperation))
+}
+
+func TestRSA4096(t *testing.T) {
+	msg := []byte("rsa testing")
+	s := NewRSAHandler(4096)
+	public,private,err := s.Gen(0,0)
+	require.Nil(t, err)
+	assert.Equal(t, 4096, public.(rsaPubKey).N.BitLen())
+
+	sig, err := s.Sign(msg, private[0])
+	require.Nil(t, err)
+	assert.Len(t, sig, 512)
+	require.Nil(t, s.Verify(sig,msg, public))
+}
+
+func TestRSAPSS(t *testing.T) {
+	msg := []byte("rsa pss testing")
+	public,private,err := NewRSAPSSKeyGenerator(2048).Gen(0,0)
+	require.Nil(t, err)
+
+	handlers := []crypto.SignerVerifierAggregator{
+		NewRSAPSS(2048),
+		NewRSAPSS(2048, WithSaltLength(64)),
+		NewRSAPSS(2048, WithSaltLength(rsa.PSSSaltLengthAuto)),
+		NewRSAPSS(2048, WithHash(gocrypto.SHA512), WithSaltLength(20)),
+	}
+
+	for i, s := range handlers {
+		sig, err := s.Sign(msg, private[0])
+		require.Nil(t, err, "handler %v", i)
+		assert.Nil(t, s.Verify(sig,msg, public), "handler %v", i)
+		assert.NotNil(t, s.Verify(sig,[]byte("other message"), public), "handler %v", i)
+	}
+
+	//salts are random
+	first, err := handlers[0].Sign(msg, private[0])
+	require.Nil(t, err)
+	second, err := handlers[0].Sign(msg, private[0])
+	require.Nil(t, err)
+	assert.NotEqual(t, first, second)
+
+	//verifiers expect the configured salt length, unless set to auto
+	first, err = handlers[1].Sign(msg, private[0])
+	require.Nil(t, err)
+	assert.NotNil(t, handlers[0].Verify(first,msg, public))
+	assert.Nil(t, handlers[2].Verify(first,msg, public))
+
+	//PSS signatures are not PKCS #1 v1.5 signatures
+	assert.NotNil(t, NewRSA(2048).Verify(first,msg, public))
+	pkcs, err := NewRSA(2048).Sign(msg, private[0])
+	require.Nil(t, err)
+	assert.NotNil(t, handlers[2].Verify(pkcs,msg, public))
+}
+
+func TestRSAPSSUnavailableHash(t *testing.T) {
+	s := NewRSAPSS(2048, WithHash(gocrypto.MD4))
+	_, private, err := NewRSAKeyGenerator(KEY_SIZE).Gen(0,0)
+	require.Nil(t, err)
+
+	_, err = s.Sign([]byte("msg"), private[0])
+	assert.True(t, errors.Is(err, crypto.ErrUnsupported))
+}
+
+func TestRegistered(t *testing.T) {
+	h, err := crypto.Lookup("RSA2048")
+	require.Nil(t, err)
+
+	info := crypto.DescribeScheme(h)
+	assert.Equal(t, crypto.Descriptor{Family: RSA, KeySize: 2048, Aggregation: crypto.NoAggregation}, info.Descriptor())
+
+	for _, name := range []string{"RSA4096", "RSAPSS2048", "RSAPSS3072", "RSAPSS4096"} {
+		h, err := crypto.Lookup(name)
+		require.Nil(t, err, name)
+		assert.Equal(t, name, h.SchemeName())
+	}
+
+	h, err = crypto.Lookup("RSAPSS3072")
+	require.Nil(t, err)
+	info = crypto.DescribeScheme(h)
+	assert.Equal(t, crypto.Descriptor{Family: RSAPSS, KeySize: 3072, Aggregation: crypto.NoAggregation}, info.Descriptor())
+}
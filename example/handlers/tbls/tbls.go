@@ -2,8 +2,8 @@ package tbls
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
-	"errors"
 	"fmt"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"go.dedis.ch/kyber/v3"
@@ -15,8 +15,22 @@ import (
 	"io"
 )
 
+//Family is the registry family of the threshold BLS schemes
+const Family = "TBLS"
+
+func init() {
+	crypto.Register(descriptor(crypto.NormalAggregation), NewTBLS256CryptoHandler)
+	crypto.Register(descriptor(crypto.OptimisticAggregation), NewTBLS256OptimisticCryptoHandler)
+	crypto.Register(descriptor(crypto.PessimisticAggregation), NewTBLS256PessimisticCryptoHandler)
+}
+
+func descriptor(aggregation crypto.AggregationStrategy) crypto.Descriptor {
+	return crypto.Descriptor{Family: Family, KeySize: 256, Aggregation: aggregation}
+}
+
 var (
-	privateKeyError = errors.New("invalid private key")
+	privateKeyError = fmt.Errorf("%w: invalid private key", crypto.ErrBadKeyEncoding)
+	publicKeyError  = fmt.Errorf("%w: invalid public key", crypto.ErrBadKeyEncoding)
 )
 
 type privKey struct {
@@ -51,7 +65,11 @@ func (pub pubKey) MarshalBinary() (data []byte, err error) {
 	return buffer.Bytes(), err
 }
 
-type AggregateTBLS func(suite pairing.Suite, public *share.PubPoly, msg []byte, sigs [][]byte, t, n int) ([]byte, error)
+func notEnoughSharesError(provided, needed int) error {
+	return fmt.Errorf("%w: provided %v, needed %v", crypto.ErrNotEnoughShares, provided, needed)
+}
+
+type AggregateTBLS func(ctx context.Context, suite pairing.Suite, public *share.PubPoly, msg []byte, sigs [][]byte, t, n int) ([]byte, error)
 type tbls struct {
 	suite pairing.Suite
 	recover AggregateTBLS
@@ -73,39 +91,65 @@ func (t *tbls) Verify(signature, msg []byte, key crypto.PublicKey) error {
 	pub, ok := key.(pubKey)
 
 	if !ok {
-		return errors.New(fmt.Sprintf("Error Unmarshalling public key: %v",privateKeyError))
+		return publicKeyError
 	}
 
 	return bls.Verify(t.suite, pub.pub.Commit(), msg, signature)
 }
 
 func (tbls *tbls) Aggregate(shares [][]byte, digest []byte, key crypto.PublicKey, t, n int) ([]byte, error) {
+	return tbls.AggregateContext(context.Background(), shares, digest, key, t, n)
+}
+
+func (tbls *tbls) AggregateContext(ctx context.Context, shares [][]byte, digest []byte, key crypto.PublicKey, t, n int) ([]byte, error) {
 	pub, ok := key.(pubKey)
 
 	if !ok {
-		return nil, privateKeyError
+		return nil, publicKeyError
 	}
 
 	//return ths.Recover(tbls.suite, pub.pub, digest, shares, t, n)
-	return tbls.recover(tbls.suite, pub.pub, digest, shares, t, n)
+	return tbls.recover(ctx, tbls.suite, pub.pub, digest, shares, t, n)
 }
 
 type tblsKeyGenerator struct {
 	suite pairing.Suite
 }
 
-func (g *tblsKeyGenerator) Gen(n int, t int) (crypto.PublicKey, crypto.PrivateKeyList) {
+func (g *tblsKeyGenerator) Gen(n int, t int) (crypto.PublicKey, crypto.PrivateKeyList, error) {
+	return g.GenContext(context.Background(), n, t)
+}
+
+//GenContext checks ctx before each step of the generation:
+//committing to the polynomial and evaluating every share
+func (g *tblsKeyGenerator) GenContext(ctx context.Context, n int, t int) (crypto.PublicKey, crypto.PrivateKeyList, error) {
+	if t < 1 || t > n {
+		return nil, nil, fmt.Errorf("%w: invalid threshold t=%v for n=%v", crypto.ErrMalformedRequest, t, n)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
 	suite := g.suite
 	secret := suite.G1().Scalar().Pick(suite.RandomStream())
 	priPoly := share.NewPriPoly(suite.G2(), t, secret, suite.RandomStream())
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
 	pubPoly := priPoly.Commit(suite.G2().Point().Base())
 
 	shares := make([]crypto.PrivateKey, n)
-	for i, v := range priPoly.Shares(n) {
-		shares[i] = privKey{v}
+	for i := range shares {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		shares[i] = privKey{priPoly.Eval(i)}
 	}
 
-	return pubKey{pubPoly}, shares
+	return pubKey{pubPoly}, shares, nil
 }
 
 func NewTBLS256KeyGenerator() crypto.KeyShareGenerator {
@@ -118,39 +162,75 @@ type tblsHandler struct {
 	crypto.SignerVerifierAggregator
 	crypto.KeyShareGenerator
 	schemeName string
+	aggregation crypto.AggregationStrategy
 }
 
 func (tbls tblsHandler) SchemeName() string {
 	return tbls.schemeName
 }
 
-func (tbls tblsHandler) UnmarshalPublic(data []byte) crypto.PublicKey {
+func (tbls tblsHandler) AggregateContext(ctx context.Context, shares [][]byte, digest []byte, key crypto.PublicKey, t, n int) ([]byte, error) {
+	return crypto.AggregateContext(ctx, tbls.SignerVerifierAggregator, shares, digest, key, t, n)
+}
+
+func (tbls tblsHandler) GenContext(ctx context.Context, n int, t int) (crypto.PublicKey, crypto.PrivateKeyList, error) {
+	return crypto.GenContext(ctx, tbls.KeyShareGenerator, n, t)
+}
+
+func (tbls tblsHandler) DescribeScheme() crypto.SchemeInfo {
+	return crypto.SchemeInfo{
+		Name:        tbls.schemeName,
+		Family:      Family,
+		KeySize:     256,
+		Threshold:   true,
+		Aggregation: tbls.aggregation,
+	}
+}
+
+func (tbls tblsHandler) UnmarshalPublic(data []byte) (crypto.PublicKey, error) {
 	suite := bn256.NewSuiteG2()
 	reader := bytes.NewReader(data)
+	pointLen := suite.G2().PointLen()
 
 	var nCommits int64
-	binary.Read(reader, binary.LittleEndian, &nCommits)
+	err := binary.Read(reader, binary.LittleEndian, &nCommits)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", publicKeyError, err)
+	}
+
+	if nCommits <= 0 || nCommits != int64(reader.Len()/pointLen) || reader.Len()%pointLen != 0 {
+		return nil, fmt.Errorf("%w: unexpected length for %v commits", publicKeyError, nCommits)
+	}
 
 	sl := make([]kyber.Point, nCommits)
 
 	for i, _ := range sl {
-		bytes := make([]byte, 128)
-		io.ReadFull(reader, bytes)
+		bytes := make([]byte, pointLen)
+		if _, err := io.ReadFull(reader, bytes); err != nil {
+			return nil, fmt.Errorf("%w: %v", publicKeyError, err)
+		}
 		p := suite.Point()
-		p.UnmarshalBinary(bytes)
+		if err := p.UnmarshalBinary(bytes); err != nil {
+			return nil, fmt.Errorf("%w: %v", publicKeyError, err)
+		}
 
 		sl[i] = p
 	}
 
-	return pubKey{share.NewPubPoly(suite.G2(), suite.G2().Point().Base(), sl)}
+	return pubKey{share.NewPubPoly(suite.G2(), suite.G2().Point().Base(), sl)}, nil
 }
 
-func (tbls tblsHandler) UnmarshalPrivate(data []byte) crypto.PrivateKey {
+func (tbls tblsHandler) UnmarshalPrivate(data []byte) (crypto.PrivateKey, error) {
 	suite := bn256.NewSuiteG1()
 	reader := bytes.NewReader(data)
 	privShare := share.PriShare{}
 
-	_ = suite.Read(reader, &privShare)
+	err := suite.Read(reader, &privShare)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", privateKeyError, err)
+	}
 
-	return privKey{&privShare}
+	return privKey{&privShare}, nil
 }
\ No newline at end of file
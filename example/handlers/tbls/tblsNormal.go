@@ -1,15 +1,30 @@
 package tbls
 
 import (
+	"context"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"go.dedis.ch/kyber/v3/pairing"
 	"go.dedis.ch/kyber/v3/pairing/bn256"
+	"go.dedis.ch/kyber/v3/share"
 	ths "go.dedis.ch/kyber/v3/sign/tbls"
 )
 
+func recoverNormal(ctx context.Context, suite pairing.Suite, public *share.PubPoly, msg []byte, sigs [][]byte, t, n int) ([]byte, error) {
+	if len(sigs) < t {
+		return nil, notEnoughSharesError(len(sigs), t)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return ths.Recover(suite, public, msg, sigs, t, n)
+}
+
 func NewTBLS256() crypto.SignerVerifierAggregator {
 	return &tbls{
 		bn256.NewSuite(),
-			ths.Recover,
+			recoverNormal,
 	}
 }
 
@@ -18,5 +33,6 @@ func NewTBLS256CryptoHandler() crypto.THSignerHandler {
 	return tblsHandler{
 		NewTBLS256(),
 		NewTBLS256KeyGenerator(),
-		"TBLS256"}
+		descriptor(crypto.NormalAggregation).Name(),
+		crypto.NormalAggregation}
 }
@@ -1,7 +1,8 @@
 package tbls
 
 import (
-	"errors"
+	"context"
+	"fmt"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"github.com/jffp113/go-util/algorithms/twiddle"
 	"go.dedis.ch/kyber/v3/pairing"
@@ -13,14 +14,18 @@ import (
 
 const TBLSOptimistic = "TBLS256Optimistic"
 
-func recoverOptimistic(suite pairing.Suite, public *share.PubPoly, msg []byte, sigs [][]byte, t, n int) ([]byte, error){
+func recoverOptimistic(ctx context.Context, suite pairing.Suite, public *share.PubPoly, msg []byte, sigs [][]byte, t, n int) ([]byte, error){
 	if len(sigs) < t {
-		return nil, errors.New("not enough signatures")
+		return nil, notEnoughSharesError(len(sigs), t)
 	}
 
 	tw := twiddle.New(t, len(sigs))
 
 	for b := tw.Next(); b != nil; b = tw.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		var perm [][]byte
 
 		for i,c := range b {
@@ -42,7 +47,7 @@ func recoverOptimistic(suite pairing.Suite, public *share.PubPoly, msg []byte, s
 		}
 
 	}
-	return nil, errors.New("no valid combination found")
+	return nil, fmt.Errorf("%w: no valid combination found", crypto.ErrInvalidShare)
 }
 
 func recover(suite pairing.Suite, public *share.PubPoly, msg []byte, sigs [][]byte, t, n int) ([]byte,error){
@@ -84,7 +89,8 @@ func NewTBLS256OptimisticCryptoHandler() crypto.THSignerHandler {
 	return tblsHandler{
 		NewTBLS256Optimistic(),
 		NewTBLS256KeyGenerator(),
-		TBLSOptimistic}
+		descriptor(crypto.OptimisticAggregation).Name(),
+		crypto.OptimisticAggregation}
 }
 
 
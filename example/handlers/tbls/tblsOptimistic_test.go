@@ -1,6 +1,10 @@
 package tbls
 
 import (
+	"context"
+	"errors"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -20,3 +24,29 @@ func TestTBLSOptimisticNotEnouthShares(test *testing.T) {
 func TestTBLSOptimisticLessThanTByzantineSignature(test *testing.T) {
 	tblsHalfByzantineSignature(NewTBLS256OptimisticCryptoHandler() ,test)
 }
+
+func TestTBLSOptimisticCancelledAggregate(test *testing.T) {
+	msg := []byte("Test TBLS")
+	n := 10
+	t := n/2 + 1
+
+	handler := NewTBLS256OptimisticCryptoHandler()
+	pub, shares, err := handler.Gen(n, t)
+	require.Nil(test, err)
+
+	sigShares := make([][]byte, 0)
+	for _, x := range shares {
+		s, err := handler.Sign(msg, x)
+		require.Nil(test, err)
+		sigShares = append(sigShares, s)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = crypto.AggregateContext(ctx, handler, sigShares, msg, pub, t, n)
+	require.True(test, errors.Is(err, context.Canceled))
+
+	_, _, err = crypto.GenContext(ctx, handler, n, t)
+	require.True(test, errors.Is(err, context.Canceled))
+}
@@ -1,6 +1,8 @@
 package tbls
 
 import (
+	"context"
+	"fmt"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"go.dedis.ch/kyber/v3/pairing"
 	"go.dedis.ch/kyber/v3/pairing/bn256"
@@ -12,9 +14,16 @@ import (
 const TBLSPessimistic = "TBLS256Pessimistic"
 
 
-func recoverPessimistic(suite pairing.Suite, public *share.PubPoly, msg []byte, sigs [][]byte, t, n int) ([]byte, error){
+func recoverPessimistic(ctx context.Context, suite pairing.Suite, public *share.PubPoly, msg []byte, sigs [][]byte, t, n int) ([]byte, error){
+	if len(sigs) < t {
+		return nil, notEnoughSharesError(len(sigs), t)
+	}
 	pubShares := make([]*share.PubShare, 0)
 	for _, sig := range sigs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		s := ths.SigShare(sig)
 		i, err := s.Index()
 		if err != nil {
@@ -32,6 +41,9 @@ func recoverPessimistic(suite pairing.Suite, public *share.PubPoly, msg []byte,
 			break
 		}
 	}
+	if len(pubShares) < t {
+		return nil, fmt.Errorf("%w: only %v valid shares, needed %v", crypto.ErrInvalidShare, len(pubShares), t)
+	}
 	commit, err := share.RecoverCommit(suite.G1(), pubShares, t, n)
 	if err != nil {
 		return nil, err
@@ -54,7 +66,8 @@ func NewTBLS256PessimisticCryptoHandler() crypto.THSignerHandler {
 	return tblsHandler{
 		NewTBLS256Pessimistic(),
 		NewTBLS256KeyGenerator(),
-		TBLSPessimistic}
+		descriptor(crypto.PessimisticAggregation).Name(),
+		crypto.PessimisticAggregation}
 }
 
 
@@ -1,6 +1,9 @@
 package tbls
 
 import (
+	"context"
+	"errors"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -33,13 +36,15 @@ func tblsSuccessMarshallAndUnmarshallSignature(n, t int, test *testing.T) {
 
 	keygen := NewTBLS256KeyGenerator()
 	tbls := NewTBLS256()
-	pub, shares := keygen.Gen(n, t)
+	pub, shares, err := keygen.Gen(n, t)
+	require.Nil(test, err)
 	h := NewTBLS256CryptoHandler()
 	sigShares := make([][]byte, 0)
 	for _, x := range shares {
 		b, err := x.MarshalBinary()
 		require.Nil(test, err)
-		x2 := h.UnmarshalPrivate(b)
+		x2, err := h.UnmarshalPrivate(b)
+		require.Nil(test, err)
 
 		s, err := tbls.Sign(msg, x2)
 		require.Nil(test, err)
@@ -48,7 +53,8 @@ func tblsSuccessMarshallAndUnmarshallSignature(n, t int, test *testing.T) {
 
 	b, err := pub.MarshalBinary()
 	require.Nil(test, err)
-	pub2 := h.UnmarshalPublic(b)
+	pub2, err := h.UnmarshalPublic(b)
+	require.Nil(test, err)
 
 	sig, err := tbls.Aggregate(sigShares, msg, pub2, t, n)
 
@@ -60,4 +66,70 @@ func tblsSuccessMarshallAndUnmarshallSignature(n, t int, test *testing.T) {
 
 func TestTBLSByzantineSignature(test *testing.T) {
 	tblsByzantineSignature(NewTBLS256CryptoHandler() ,test)
-}
\ No newline at end of file
+}
+func TestTBLSUnmarshalInvalidKeys(test *testing.T) {
+	h := NewTBLS256CryptoHandler()
+
+	_, err := h.UnmarshalPublic([]byte("not a key"))
+	require.True(test, errors.Is(err, crypto.ErrBadKeyEncoding))
+
+	_, err = h.UnmarshalPublic([]byte{})
+	require.True(test, errors.Is(err, crypto.ErrBadKeyEncoding))
+
+	_, err = h.UnmarshalPrivate([]byte{1})
+	require.True(test, errors.Is(err, crypto.ErrBadKeyEncoding))
+}
+
+func TestTBLSGenInvalidThreshold(test *testing.T) {
+	_, _, err := NewTBLS256KeyGenerator().Gen(3, 5)
+	require.NotNil(test, err)
+}
+
+//cancelledAfter is a context cancelled once Err was called calls times
+type cancelledAfter struct {
+	context.Context
+	calls int
+}
+
+func (c *cancelledAfter) Err() error {
+	if c.calls <= 0 {
+		return context.Canceled
+	}
+	c.calls--
+	return nil
+}
+
+func TestTBLSCancelledGen(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := crypto.GenContext(ctx, NewTBLS256CryptoHandler(), 10, 6)
+	require.True(test, errors.Is(err, context.Canceled))
+
+	//generation gives up between its steps
+	for calls := 1; calls < 10; calls++ {
+		_, _, err = crypto.GenContext(&cancelledAfter{context.Background(), calls}, NewTBLS256CryptoHandler(), 10, 6)
+		require.True(test, errors.Is(err, context.Canceled), "%v", calls)
+	}
+}
+
+func TestDescribeScheme(test *testing.T) {
+	info := crypto.DescribeScheme(NewTBLS256PessimisticCryptoHandler())
+
+	require.Equal(test, TBLSPessimistic, info.Name)
+	require.Equal(test, 256, info.KeySize)
+	require.True(test, info.Threshold)
+	require.Equal(test, crypto.PessimisticAggregation, info.Aggregation)
+	require.True(test, info.Supports(crypto.AggregateOperation))
+}
+
+func TestRegistered(test *testing.T) {
+	for _, name := range []string{"TBLS256", TBLSOptimistic, TBLSPessimistic} {
+		h, err := crypto.Lookup(name)
+		require.Nil(test, err)
+		require.Equal(test, name, h.SchemeName())
+	}
+
+	info := crypto.DescribeScheme(NewTBLS256OptimisticCryptoHandler())
+	require.Equal(test, crypto.Descriptor{Family: Family, KeySize: 256, Aggregation: crypto.OptimisticAggregation}, info.Descriptor())
+}
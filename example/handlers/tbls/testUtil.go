@@ -1,6 +1,7 @@
 package tbls
 
 import (
+	"errors"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"github.com/stretchr/testify/require"
 	"math/rand"
@@ -13,7 +14,8 @@ func tblsSuccessSignature(n, t int, handler crypto.THSignerHandler,test *testing
 
 	//keygen := NewTBLS256KeyGenerator()
 	//tbls := NewTBLS256()
-	pub, shares := handler.Gen(n, t)
+	pub, shares, err := handler.Gen(n, t)
+	require.Nil(test, err)
 
 	sigShares := make([][]byte, 0)
 	for _, x := range shares {
@@ -38,7 +40,8 @@ func notEnoughShares(handler crypto.THSignerHandler,test *testing.T) {
 	n := 10
 	t := n/2 + 1
 
-	pub, shares := handler.Gen(n, t)
+	pub, shares, err := handler.Gen(n, t)
+	require.Nil(test, err)
 
 	sigShares := make([][]byte, 0)
 	for _, x := range shares[0 : t-1] {
@@ -49,7 +52,7 @@ func notEnoughShares(handler crypto.THSignerHandler,test *testing.T) {
 
 	_, err = handler.Aggregate(sigShares, msg, pub, t, n)
 
-	require.NotNil(test, err)
+	require.True(test, errors.Is(err, crypto.ErrNotEnoughShares))
 }
 
 func tblsByzantineSignature(handler crypto.THSignerHandler,test *testing.T) {
@@ -59,7 +62,8 @@ func tblsByzantineSignature(handler crypto.THSignerHandler,test *testing.T) {
 	n := 10
 	t := n/2 + 1
 
-	pub, shares := handler.Gen(n, t)
+	pub, shares, err := handler.Gen(n, t)
+	require.Nil(test, err)
 
 	sigShares := make([][]byte, 0)
 	for i, x := range shares {
@@ -87,7 +91,8 @@ func tblsHalfByzantineSignature(handler crypto.THSignerHandler,test *testing.T)
 	n := 10
 	t := n/2 + 1
 
-	pub, shares := handler.Gen(n, t)
+	pub, shares, err := handler.Gen(n, t)
+	require.Nil(test, err)
 
 	sigShares := make([][]byte, 0)
 	for _, x := range shares {
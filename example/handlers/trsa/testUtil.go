@@ -1,6 +1,7 @@
 package trsa
 
 import (
+	"errors"
 	"fmt"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"github.com/stretchr/testify/require"
@@ -23,7 +24,10 @@ func initTests() {
 	privateSharesTest = make(map[string]crypto.PrivateKeyList)
 
 	for i := t; i <= n; i++ {
-		pub, shares := keygen.Gen(n, i)
+		pub, shares, err := keygen.Gen(n, i)
+		if err != nil {
+			panic(err)
+		}
 		publicKeyTest[getEntryName(i,n)] = pub
 		privateSharesTest[getEntryName(i,n)] = shares
 	}
@@ -96,7 +100,8 @@ func trsaSuccessMarshallAndUnmarshallSignature(n, t int,trsa crypto.THSignerHand
 	for _, x := range shares {
 		b, err := x.MarshalBinary()
 		require.Nil(test, err)
-		x2 := trsa.UnmarshalPrivate(b)
+		x2, err := trsa.UnmarshalPrivate(b)
+		require.Nil(test, err)
 
 		s, err := trsa.Sign(msg, x2)
 		require.Nil(test, err)
@@ -105,7 +110,8 @@ func trsaSuccessMarshallAndUnmarshallSignature(n, t int,trsa crypto.THSignerHand
 
 	b, err := pub.MarshalBinary()
 	require.Nil(test, err)
-	pub2 := trsa.UnmarshalPublic(b)
+	pub2, err := trsa.UnmarshalPublic(b)
+	require.Nil(test, err)
 
 	sig, err := trsa.Aggregate(sigShares, msg, pub2, t, n)
 
@@ -168,7 +174,8 @@ func testTRSASomeByzantineSignature(trsa crypto.THSignerHandler, test *testing.T
 		sigShares = append(sigShares, s)
 	}
 
-	destroyUpToShares(t - 1, sigShares)
+	//at most n - t shares can be byzantine for t valid shares to remain
+	destroyUpToShares(n - t, sigShares)
 	sig, err := trsa.Aggregate(sigShares, msg, pub, t, n)
 
 	require.Nil(test, err)
@@ -177,6 +184,35 @@ func testTRSASomeByzantineSignature(trsa crypto.THSignerHandler, test *testing.T
 	require.Nil(test, err)
 }
 
+func testTRSATooManyByzantineSignatures(trsa crypto.THSignerHandler, test *testing.T) {
+	msg := []byte("Test TRSA")
+
+	n := 10
+	t := n/2 + 1
+
+	once.Do(initTests)
+
+	pub := publicKeyTest[getEntryName(t,n)]
+	shares := privateSharesTest[getEntryName(t,n)]
+	sigShares := make([][]byte, 0)
+	for _, x := range shares {
+
+		s, err := trsa.Sign(msg, x)
+
+		require.Nil(test, err)
+		sigShares = append(sigShares, s)
+	}
+
+	//enough shares are sent, but only t - 1 of them are valid
+	for i := 0; i <= n - t; i++ {
+		sigShares[i] = []byte("Destroyed")
+	}
+
+	_, err := trsa.Aggregate(sigShares, msg, pub, t, n)
+
+	require.True(test, errors.Is(err, crypto.ErrInvalidShare), "%v", err)
+}
+
 
 func destroyUpToShares(t int, shares [][]byte){
 	var destroyed int
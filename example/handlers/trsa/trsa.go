@@ -2,26 +2,66 @@ package trsa
 
 import (
 	"bytes"
+	"context"
 	go_crypto "crypto"
+	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
 	"encoding/json"
-	"errors"
+	"fmt"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"github.com/niclabs/tcrsa"
+	"math/big"
 )
 
+//Family is the registry family of the threshold RSA schemes
+const Family = "TRSA"
+
+//primalityRounds are the Miller-Rabin rounds tcrsa checks primes with
+const primalityRounds = 20
+
+//KeySizes are the key sizes registered for every aggregation strategy
+var KeySizes = []int{1024, 2048, 3072}
+
+func init() {
+	for _, size := range KeySizes {
+		size := size
+		crypto.Register(descriptor(size, crypto.NormalAggregation), func() crypto.THSignerHandler {
+			return NewTRSACryptoHandler(size)
+		})
+		crypto.Register(descriptor(size, crypto.OptimisticAggregation), func() crypto.THSignerHandler {
+			return NewOptimisticTRSACryptoHandler(size)
+		})
+		crypto.Register(descriptor(size, crypto.PessimisticAggregation), func() crypto.THSignerHandler {
+			return NewPessimisticTRSACryptoHandler(size)
+		})
+	}
+}
+
+func descriptor(size int, aggregation crypto.AggregationStrategy) crypto.Descriptor {
+	return crypto.Descriptor{Family: Family, KeySize: size, Aggregation: aggregation}
+}
+
 var (
-	keyError = errors.New("invalid key")
+	keyError = fmt.Errorf("%w: invalid key", crypto.ErrBadKeyEncoding)
 )
 
+func notEnoughSharesError(provided, needed int) error {
+	return fmt.Errorf("%w: provided %v, needed %v", crypto.ErrNotEnoughShares, provided, needed)
+}
+
+func notEnoughValidSharesError(valid, needed int) error {
+	return fmt.Errorf("%w: only %v valid shares, needed %v", crypto.ErrInvalidShare, valid, needed)
+}
+
 const HashType = go_crypto.SHA256
 
-type AggregateTRSA func(sigShares tcrsa.SigShareList,digest []byte,pub pubKey, t, n int) (signature []byte, err error)
+type AggregateTRSA func(ctx context.Context, sigShares tcrsa.SigShareList,digest []byte,pub pubKey, t, n int) (signature []byte, err error)
 type trsa struct {
 	aggregate AggregateTRSA
 	scheme string
 	keySize int
+	aggregation crypto.AggregationStrategy
 }
 
 type signatureShare struct {
@@ -84,6 +124,10 @@ func (self trsa) Verify(signature []byte, msg []byte, key crypto.PublicKey) erro
 }
 
 func (self trsa) Aggregate(share [][]byte, digest []byte, key crypto.PublicKey, t, n int) (signature []byte, err error) {
+	return self.AggregateContext(context.Background(), share, digest, key, t, n)
+}
+
+func (self trsa) AggregateContext(ctx context.Context, share [][]byte, digest []byte, key crypto.PublicKey, t, n int) (signature []byte, err error) {
 	pub, ok := key.(pubKey)
 
 	if !ok {
@@ -99,12 +143,16 @@ func (self trsa) Aggregate(share [][]byte, digest []byte, key crypto.PublicKey,
 	}
 
 
-	return self.aggregate(s,digest, pub,t,n)
+	return self.aggregate(ctx, s, digest, pub, t, n)
 }
 
-func getValidShares(sigShares tcrsa.SigShareList,docPKCS1 []byte, pub pubKey) tcrsa.SigShareList{
+func getValidShares(ctx context.Context, sigShares tcrsa.SigShareList, docPKCS1 []byte, pub pubKey) (tcrsa.SigShareList, error) {
 	valid := make(tcrsa.SigShareList, 0)
 	for i := 0; i < len(sigShares); i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		if sigShares[i] == nil {
 			continue
 		}
@@ -117,15 +165,35 @@ func getValidShares(sigShares tcrsa.SigShareList,docPKCS1 []byte, pub pubKey) tc
 
 	}
 
-	return valid
+	return valid, nil
 }
 
-func (self trsa) Gen(n int, t int) (crypto.PublicKey, crypto.PrivateKeyList) {
+func (self trsa) Gen(n int, t int) (crypto.PublicKey, crypto.PrivateKeyList, error) {
+	return self.GenContext(context.Background(), n, t)
+}
+
+//GenContext stops generating the primes of the key as soon as ctx is done,
+//so a cancelled generation does not keep running in the background
+func (self trsa) GenContext(ctx context.Context, n int, t int) (crypto.PublicKey, crypto.PrivateKeyList, error) {
+	//sizes tcrsa expects of p and q
+	pSize := (self.keySize + 1) / 2
+	qSize := self.keySize - pSize - 1
+
+	p, err := safePrime(ctx, pSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	q, err := safePrime(ctx, qSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Generate keys provides to u with a list of keyShares and the key metainformation.
-	keyShares, keyMeta, err := tcrsa.NewKey(self.keySize, uint16(t), uint16(n), nil)
+	keyShares, keyMeta, err := tcrsa.NewKey(self.keySize, uint16(t), uint16(n), &tcrsa.KeyMetaArgs{P: p, Q: q})
 
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
 	sl := make(crypto.PrivateKeyList, 0)
@@ -138,23 +206,74 @@ func (self trsa) Gen(n int, t int) (crypto.PublicKey, crypto.PrivateKeyList) {
 
 	pubKey := pubKey{keyMeta}
 
-	return pubKey, sl
+	return pubKey, sl, nil
+}
+
+//safePrime returns a prime p of bitLen bits such that (p-1)/2 is prime,
+//checking ctx between candidates
+func safePrime(ctx context.Context, bitLen int) (*big.Int, error) {
+	p := new(big.Int)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		q, err := rand.Prime(rand.Reader, bitLen-1)
+		if err != nil {
+			return nil, err
+		}
+
+		//p = 2q + 1
+		p.Lsh(q, 1)
+		p.SetBit(p, 0, 1)
+		if p.ProbablyPrime(primalityRounds) {
+			return p, nil
+		}
+	}
 }
 
 func (self trsa) SchemeName() string {
 	return self.scheme
 }
 
-func (self trsa) UnmarshalPublic(data []byte) crypto.PublicKey {
+func (self trsa) DescribeScheme() crypto.SchemeInfo {
+	return crypto.SchemeInfo{
+		Name:        self.scheme,
+		Family:      Family,
+		KeySize:     self.keySize,
+		Threshold:   true,
+		Aggregation: self.aggregation,
+	}
+}
+
+func (self trsa) UnmarshalPublic(data []byte) (crypto.PublicKey, error) {
 	pub := pubKey{}
-	unmarshallFromJson(data, &pub)
-	return pub
+	err := unmarshallFromJson(data, &pub)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", keyError, err)
+	}
+
+	if pub.Meta == nil || pub.Meta.PublicKey == nil {
+		return nil, fmt.Errorf("%w: missing key metadata", keyError)
+	}
+
+	return pub, nil
 }
 
-func (self trsa) UnmarshalPrivate(data []byte) crypto.PrivateKey {
+func (self trsa) UnmarshalPrivate(data []byte) (crypto.PrivateKey, error) {
 	priv := privKey{}
-	unmarshallFromJson(data, &priv)
-	return priv
+	err := unmarshallFromJson(data, &priv)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", keyError, err)
+	}
+
+	if priv.Meta == nil || priv.Meta.PublicKey == nil || priv.KeyShare == nil {
+		return nil, fmt.Errorf("%w: missing key share or metadata", keyError)
+	}
+
+	return priv, nil
 }
 
 func NewTRSAKeyGenerator(keysize int) crypto.KeyShareGenerator {
@@ -168,8 +287,8 @@ func marshallToJSON(v interface{}) ([]byte, error) {
 	return buffer.Bytes(), err
 }
 
-func unmarshallFromJson(data []byte, v interface{}) {
+func unmarshallFromJson(data []byte, v interface{}) error {
 	reader := bytes.NewReader(data)
 	dec := json.NewDecoder(reader)
-	dec.Decode(v)
+	return dec.Decode(v)
 }
@@ -1,35 +1,54 @@
 package trsa
 
 import (
+	"context"
 	"crypto/sha256"
-	"fmt"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"github.com/niclabs/tcrsa"
 )
 
 const NormalScheme = "TRSA%v"
 
-func aggregateNormal(sigShares tcrsa.SigShareList,digest []byte,pub pubKey, t, n int) (signature []byte, err error) {
+func aggregateNormal(ctx context.Context, sigShares tcrsa.SigShareList, digest []byte, pub pubKey, t, n int) (signature []byte, err error) {
+	if len(sigShares) < t {
+		return nil, notEnoughSharesError(len(sigShares), t)
+	}
+
 	docHash := sha256.Sum256(digest)
 	docPKCS1, err := tcrsa.PrepareDocumentHash(pub.Meta.PublicKey.Size(), HashType, docHash[:])
 
-	valid := getValidShares(sigShares,docPKCS1,pub)
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := getValidShares(ctx, sigShares, docPKCS1, pub)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(valid) < t {
+		return nil, notEnoughValidSharesError(len(valid), t)
+	}
+
 	return valid.Join(docPKCS1, pub.Meta)
 }
 
 func NewTRSA(size int) crypto.SignerVerifierAggregator {
 	return &trsa{
-		scheme: fmt.Sprintf(NormalScheme,size),
+		scheme: descriptor(size, crypto.NormalAggregation).Name(),
 		aggregate: aggregateNormal,
 		keySize: size,
+		aggregation: crypto.NormalAggregation,
 	}
 }
 
 func NewTRSACryptoHandler(size int) crypto.THSignerHandler {
 	return &trsa{
-		scheme: fmt.Sprintf(NormalScheme,size),
+		scheme: descriptor(size, crypto.NormalAggregation).Name(),
 		aggregate: aggregateNormal,
 		keySize: size,
+		aggregation: crypto.NormalAggregation,
 	}
 }
 
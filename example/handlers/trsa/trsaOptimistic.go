@@ -1,9 +1,9 @@
 package trsa
 
 import (
+	"context"
 	"crypto/rsa"
 	"crypto/sha256"
-	"errors"
 	"fmt"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"github.com/jffp113/go-util/algorithms/twiddle"
@@ -14,9 +14,9 @@ const OptimisticScheme = "TRSA%vOptimistic"
 
 
 
-func aggregateOptimistic(sigShares tcrsa.SigShareList,digest []byte,pub pubKey, t, n int) (signature []byte, err error){
+func aggregateOptimistic(ctx context.Context, sigShares tcrsa.SigShareList, digest []byte, pub pubKey, t, n int) (signature []byte, err error){
 	if len(sigShares) < t {
-		return nil, errors.New("not enough signatures")
+		return nil, notEnoughSharesError(len(sigShares), t)
 	}
 
 	docHash := sha256.Sum256(digest)
@@ -25,6 +25,10 @@ func aggregateOptimistic(sigShares tcrsa.SigShareList,digest []byte,pub pubKey,
 	tw := twiddle.New(t, len(sigShares))
 
 	for b := tw.Next(); b != nil; b = tw.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		var perm []*tcrsa.SigShare
 
 		for i, c := range b {
@@ -45,21 +49,23 @@ func aggregateOptimistic(sigShares tcrsa.SigShareList,digest []byte,pub pubKey,
 		}
 
 	}
-	return nil, errors.New("no valid combination found")
+	return nil, fmt.Errorf("%w: no valid combination found", crypto.ErrInvalidShare)
 }
 
 func NewOptimisticTRSA(size int) crypto.SignerVerifierAggregator {
 	return &trsa{
-		scheme: fmt.Sprintf(OptimisticScheme,size),
+		scheme: descriptor(size, crypto.OptimisticAggregation).Name(),
 		aggregate: aggregateOptimistic,
 		keySize: size,
+		aggregation: crypto.OptimisticAggregation,
 	}
 }
 
 func NewOptimisticTRSACryptoHandler(size int) crypto.THSignerHandler {
 	return &trsa{
-		scheme: fmt.Sprintf(OptimisticScheme,size),
+		scheme: descriptor(size, crypto.OptimisticAggregation).Name(),
 		aggregate: aggregateOptimistic,
 		keySize: size,
+		aggregation: crypto.OptimisticAggregation,
 	}
 }
@@ -1,6 +1,12 @@
 package trsa
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
 
 func TestOptimisticTRSA(test *testing.T) {
 	n := 10
@@ -27,3 +33,35 @@ func TestOptimisticTRSAByzantineSignature(test *testing.T) {
 	testTRSAByzantineSignature(NewOptimisticTRSACryptoHandler(1024), test)
 }
 
+func TestOptimisticTRSACancelledGen(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := crypto.GenContext(ctx, NewOptimisticTRSACryptoHandler(1024), 10, 6)
+	require.True(test, errors.Is(err, context.Canceled))
+}
+
+//cancelledAfter is a context cancelled once its Err was checked calls times
+type cancelledAfter struct {
+	context.Context
+	calls int
+}
+
+func (c *cancelledAfter) Err() error {
+	if c.calls == 0 {
+		return context.Canceled
+	}
+	c.calls--
+	return nil
+}
+
+func TestOptimisticTRSACancelledDuringGen(test *testing.T) {
+	//the generation stops between prime candidates, and does not go on
+	//generating the key once GenContext returned
+	_, _, err := crypto.GenContext(&cancelledAfter{context.Background(), 3}, NewOptimisticTRSACryptoHandler(3072), 10, 6)
+	require.True(test, errors.Is(err, context.Canceled))
+}
+
+func TestOptimisticTRSATooManyByzantineSignatures(test *testing.T) {
+	testTRSATooManyByzantineSignatures(NewOptimisticTRSACryptoHandler(1024), test)
+}
@@ -1,24 +1,35 @@
 package trsa
 
 import (
+	"context"
 	"crypto/rsa"
 	"crypto/sha256"
-	"fmt"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"github.com/niclabs/tcrsa"
 )
 
 const PessimisticScheme = "TRSA%vPessimistic"
 
-func aggregatePessimistic(sigShares tcrsa.SigShareList,digest []byte,pub pubKey, t, n int) (signature []byte, err error){
+func aggregatePessimistic(ctx context.Context, sigShares tcrsa.SigShareList, digest []byte, pub pubKey, t, n int) (signature []byte, err error){
+	if len(sigShares) < t {
+		return nil, notEnoughSharesError(len(sigShares), t)
+	}
+
 	docHash := sha256.Sum256(digest)
 	docPKCS1, err := tcrsa.PrepareDocumentHash(pub.Meta.PublicKey.Size(), HashType, docHash[:])
 
 	if err != nil {
 		return
 	}
-	valid := getValidShares(sigShares,docPKCS1,pub)
+	valid, err := getValidShares(ctx, sigShares, docPKCS1, pub)
+
+	if err != nil {
+		return nil, err
+	}
 
+	if len(valid) < t {
+		return nil, notEnoughValidSharesError(len(valid), t)
+	}
 
 	sig,err := valid.Join(docPKCS1, pub.Meta)
 
@@ -33,17 +44,19 @@ func aggregatePessimistic(sigShares tcrsa.SigShareList,digest []byte,pub pubKey,
 
 func NewPessimisticTRSA(size int) crypto.SignerVerifierAggregator {
 	return &trsa{
-		scheme: fmt.Sprintf(PessimisticScheme,size),
+		scheme: descriptor(size, crypto.PessimisticAggregation).Name(),
 		aggregate: aggregatePessimistic,
 		keySize: size,
+		aggregation: crypto.PessimisticAggregation,
 	}
 }
 
 func NewPessimisticTRSACryptoHandler(size int) crypto.THSignerHandler {
 	return &trsa{
-		scheme: fmt.Sprintf(PessimisticScheme,size),
+		scheme: descriptor(size, crypto.PessimisticAggregation).Name(),
 		aggregate: aggregatePessimistic,
 		keySize: size,
+		aggregation: crypto.PessimisticAggregation,
 	}
 }
 
@@ -27,3 +27,6 @@ func TestPessimisticTRSAByzantineSignature(test *testing.T) {
 	testTRSAByzantineSignature(NewPessimisticTRSACryptoHandler(1024), test)
 }
 
+func TestPessimisticTRSATooManyByzantineSignatures(test *testing.T) {
+	testTRSATooManyByzantineSignatures(NewPessimisticTRSACryptoHandler(1024), test)
+}
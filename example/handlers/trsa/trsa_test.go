@@ -1,6 +1,10 @@
 package trsa
 
 import (
+	"errors"
+	"fmt"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -34,3 +38,21 @@ func TestTRSAByzantineSignature(test *testing.T) {
 
 	testTRSAByzantineSignature(NewTRSACryptoHandler(1024), test)
 }
+
+func TestTRSAUnmarshalInvalidKeys(test *testing.T) {
+	h := NewTRSACryptoHandler(1024)
+
+	_, err := h.UnmarshalPublic([]byte("not a key"))
+	require.True(test, errors.Is(err, crypto.ErrBadKeyEncoding))
+
+	_, err = h.UnmarshalPrivate([]byte("{}"))
+	require.True(test, errors.Is(err, crypto.ErrBadKeyEncoding))
+}
+
+func TestRegistered(test *testing.T) {
+	for _, size := range KeySizes {
+		h, err := crypto.LookupDescriptor(crypto.Descriptor{Family: Family, KeySize: size, Aggregation: crypto.PessimisticAggregation})
+		require.Nil(test, err)
+		require.Equal(test, fmt.Sprintf(PessimisticScheme, size), h.SchemeName())
+	}
+}
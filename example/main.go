@@ -5,15 +5,22 @@ import (
 	"github.com/ipfs/go-log"
 	"github.com/jessevdk/go-flags"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
-	"github.com/jffp113/CryptoProviderSDK/example/handlers/bls"
-	"github.com/jffp113/CryptoProviderSDK/example/handlers/rsa"
-	"github.com/jffp113/CryptoProviderSDK/example/handlers/tbls"
-	"github.com/jffp113/CryptoProviderSDK/example/handlers/trsa"
+	_ "github.com/jffp113/CryptoProviderSDK/example/handlers/bls"
+	_ "github.com/jffp113/CryptoProviderSDK/example/handlers/ecdsa"
+	_ "github.com/jffp113/CryptoProviderSDK/example/handlers/ed25519"
+	_ "github.com/jffp113/CryptoProviderSDK/example/handlers/rsa"
+	_ "github.com/jffp113/CryptoProviderSDK/example/handlers/tbls"
+	_ "github.com/jffp113/CryptoProviderSDK/example/handlers/trsa"
+	"github.com/jffp113/CryptoProviderSDK/keychain"
 	"os"
 )
 
 type Opts struct {
 	SignerNodeURL string `short:"u" long:"url" description:"Signer Node URL" default:"tcp://127.0.0.1:9000"`
+	KeyDirectory  string `short:"k" long:"keys" description:"Keychain directory holding the keys of this node"`
+	Passphrase    string `long:"keychain-passphrase" description:"Passphrase encrypting the private keys of the keychain" env:"KEYCHAIN_PASSPHRASE"`
+	NoRawKeys     bool   `long:"no-raw-keys" description:"Reject sign requests carrying private keys"`
+	ExportToken   string `long:"export-token" description:"Token authorizing the export of stored shares" env:"SIGNER_EXPORT_TOKEN"`
 }
 
 func main() {
@@ -37,33 +44,25 @@ func main() {
 
 	_ = log.SetLogLevel("signer_processor", "debug")
 
-	processor := crypto.NewSignerProcessor(opts.SignerNodeURL)
-
-	//TBLS
-	processor.AddHandler(tbls.NewTBLS256CryptoHandler())
-	processor.AddHandler(tbls.NewTBLS256OptimisticCryptoHandler())
-	processor.AddHandler(tbls.NewTBLS256PessimisticCryptoHandler())
-
-	//TRSA
-	processor.AddHandler(trsa.NewTRSACryptoHandler(1024))
-	processor.AddHandler(trsa.NewTRSACryptoHandler(2048))
-	processor.AddHandler(trsa.NewTRSACryptoHandler(3072))
-
-	processor.AddHandler(trsa.NewOptimisticTRSACryptoHandler(1024))
-	processor.AddHandler(trsa.NewOptimisticTRSACryptoHandler(2048))
-	processor.AddHandler(trsa.NewOptimisticTRSACryptoHandler(3072))
-
-	processor.AddHandler(trsa.NewPessimisticTRSACryptoHandler(1024))
-	processor.AddHandler(trsa.NewPessimisticTRSACryptoHandler(2048))
-	processor.AddHandler(trsa.NewPessimisticTRSACryptoHandler(3072))
+	processorOpts := []crypto.ProcessorOption{crypto.WithRawKeys(!opts.NoRawKeys)}
+	if opts.KeyDirectory != "" {
+		keys := keychain.NewTypedKeyChain(keychain.Open(opts.KeyDirectory, []byte(opts.Passphrase)), crypto.DefaultRegistry)
+		if err := keys.Validate(); err != nil {
+			fmt.Printf("Invalid keychain %v: %v\n", opts.KeyDirectory, err)
+			os.Exit(1)
+		}
+		processorOpts = append(processorOpts, crypto.WithKeyStore(keys))
+	}
+	if opts.ExportToken != "" {
+		processorOpts = append(processorOpts, crypto.WithExportToken([]byte(opts.ExportToken)))
+	}
 
-	//RSA
-	processor.AddHandler(rsa.NewRSAHandler(1024))
-	processor.AddHandler(rsa.NewRSAHandler(2048))
-	processor.AddHandler(rsa.NewRSAHandler(3072))
+	processor := crypto.NewSignerProcessor(opts.SignerNodeURL, processorOpts...)
 
-	//BLS
-	processor.AddHandler(bls.NewBLS256Handler())
+	//every scheme registered by the handler packages imported above
+	for _, handler := range crypto.DefaultRegistry.Handlers() {
+		processor.AddHandler(handler)
+	}
 
 	processor.Start()
 }
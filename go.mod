@@ -12,5 +12,6 @@ require (
 	github.com/satori/go.uuid v1.2.0
 	github.com/stretchr/testify v1.4.0
 	go.dedis.ch/kyber/v3 v3.0.13
+	golang.org/x/crypto v0.0.0-20190510104115-cbcb75029529
 	google.golang.org/protobuf v1.23.0
 )
@@ -0,0 +1,110 @@
+package keychain
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+//DefaultCacheSize is how many key files a keychain keeps in memory
+//unless configured with WithCacheSize
+const DefaultCacheSize = 1024
+
+//cache keeps the most recently loaded key files.
+//A nil cache caches nothing.
+type cache struct {
+	lock    sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	//order holds the entries from the most to the least recently used
+	order *list.List
+}
+
+type cacheEntry struct {
+	name     string
+	data     []byte
+	modTime  time.Time
+	fileSize int64
+}
+
+func newCache(size int) *cache {
+	if size <= 0 {
+		return nil
+	}
+
+	return &cache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+//get returns the entry of the file name, marking it as recently used
+func (c *cache) get(name string) (cacheEntry, bool) {
+	if c == nil {
+		return cacheEntry{}, false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, ok := c.entries[name]
+	if !ok {
+		return cacheEntry{}, false
+	}
+
+	c.order.MoveToFront(elem)
+	e := elem.Value.(cacheEntry)
+	e.data = append([]byte{}, e.data...)
+	return e, true
+}
+
+//put caches e, evicting the least recently used entry if the cache is full
+func (c *cache) put(e cacheEntry) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	e.data = append([]byte{}, e.data...)
+	if elem, ok := c.entries[e.name]; ok {
+		elem.Value = e
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[e.name] = c.order.PushFront(e)
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(cacheEntry).name)
+	}
+}
+
+//invalidate drops the entry of the file name
+func (c *cache) invalidate(name string) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if elem, ok := c.entries[name]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, name)
+	}
+}
+
+func (c *cache) len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.order.Len()
+}
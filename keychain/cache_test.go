@@ -0,0 +1,165 @@
+package keychain
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCache_Bounded(test *testing.T) {
+	c := newCache(2)
+
+	c.put(cacheEntry{name: "a", data: []byte("a")})
+	c.put(cacheEntry{name: "b", data: []byte("b")})
+	_, ok := c.get("a")
+	assert.True(test, ok)
+
+	//b is the least recently used
+	c.put(cacheEntry{name: "c", data: []byte("c")})
+	assert.Equal(test, 2, c.len())
+
+	_, ok = c.get("b")
+	assert.False(test, ok)
+	e, ok := c.get("a")
+	assert.True(test, ok)
+	assert.Equal(test, []byte("a"), e.data)
+
+	c.invalidate("a")
+	_, ok = c.get("a")
+	assert.False(test, ok)
+
+	disabled := newCache(0)
+	disabled.put(cacheEntry{name: "a", data: []byte("a")})
+	_, ok = disabled.get("a")
+	assert.False(test, ok)
+}
+
+func TestKeychain_CacheInvalidation(test *testing.T) {
+	dir, err := ioutil.TempDir("test", "keystore")
+	require.Nil(test, err)
+
+	dir = dir + "/"
+	defer os.RemoveAll(dir)
+
+	k := newKeyChain(dir)
+	require.Nil(test, k.StorePublicKey("TBLS256_5_3", key("pub0")))
+
+	pub, err := k.LoadPublicKey("TBLS256_5_3")
+	require.Nil(test, err)
+	assert.Equal(test, key("pub0"), pub)
+	assert.Equal(test, 1, k.cache.len())
+
+	//loads are served from memory
+	require.Nil(test, ioutil.WriteFile(dir+"pub_TBLS256_5_3", []byte("changed"), 0600))
+	pub, err = k.LoadPublicKey("TBLS256_5_3")
+	require.Nil(test, err)
+	assert.Equal(test, key("pub0"), pub)
+
+	require.Nil(test, k.StorePublicKey("TBLS256_5_3", key("pub1")))
+	pub, err = k.LoadPublicKey("TBLS256_5_3")
+	require.Nil(test, err)
+	assert.Equal(test, key("pub1"), pub)
+
+	require.Nil(test, k.Delete("TBLS256_5_3"))
+	_, err = k.LoadPublicKey("TBLS256_5_3")
+	assert.True(test, os.IsNotExist(err))
+}
+
+func TestKeychain_Reload(test *testing.T) {
+	dir, err := ioutil.TempDir("test", "keystore")
+	require.Nil(test, err)
+
+	dir = dir + "/"
+	defer os.RemoveAll(dir)
+
+	k := newKeyChain(dir, WithReload(true))
+	require.Nil(test, k.StorePublicKey("TBLS256_5_3", key("pub0")))
+
+	_, err = k.LoadPublicKey("TBLS256_5_3")
+	require.Nil(test, err)
+
+	//another process replaces the key
+	require.Nil(test, ioutil.WriteFile(dir+"pub_TBLS256_5_3", []byte("pub1"), 0600))
+	later := time.Now().Add(time.Second)
+	require.Nil(test, os.Chtimes(dir+"pub_TBLS256_5_3", later, later))
+
+	pub, err := k.LoadPublicKey("TBLS256_5_3")
+	require.Nil(test, err)
+	assert.Equal(test, key("pub1"), pub)
+}
+
+//TestKeychain_Concurrent stores and loads the same keys from many goroutines.
+//Every load must see a whole key, never a partially written one.
+func TestKeychain_Concurrent(test *testing.T) {
+	dir, err := ioutil.TempDir("test", "keystore")
+	require.Nil(test, err)
+
+	dir = dir + "/"
+	defer os.RemoveAll(dir)
+
+	k := newKeyChain(dir, WithCacheSize(4))
+	names := 8
+	versions := 20
+	keySize := 4096
+
+	value := func(version int) key {
+		return key(bytes.Repeat([]byte{byte(version)}, keySize))
+	}
+
+	for n := 0; n < names; n++ {
+		require.Nil(test, k.StorePrivateKey(fmt.Sprint(n), value(0)))
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, names*versions*4)
+
+	for n := 0; n < names; n++ {
+		n := n
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := 1; v <= versions; v++ {
+				if err := k.StorePrivateKey(fmt.Sprint(n), value(v)); err != nil {
+					errs <- err
+				}
+			}
+		}()
+
+		for r := 0; r < 3; r++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < versions; i++ {
+					priv, err := k.LoadPrivateKey(fmt.Sprint(n))
+					if err != nil {
+						errs <- err
+						continue
+					}
+					b := priv.(key)
+					if len(b) != keySize || !bytes.Equal(b, value(int(b[0]))) {
+						errs <- fmt.Errorf("key %v was read partially written", n)
+					}
+				}
+			}()
+		}
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.Nil(test, err)
+	}
+
+	//the last store wins once every writer is done
+	for n := 0; n < names; n++ {
+		priv, err := k.LoadPrivateKey(fmt.Sprint(n))
+		require.Nil(test, err)
+		assert.Equal(test, value(versions), priv)
+	}
+}
@@ -0,0 +1,445 @@
+package keychain
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding"
+	"errors"
+	"fmt"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"golang.org/x/crypto/scrypt"
+	"io"
+	"sync"
+)
+
+//ErrDecryption is returned when a private key cannot be decrypted or a
+//public key cannot be authenticated, either because the passphrase is
+//wrong or the file was tampered with
+var ErrDecryption = errors.New("cannot decrypt key")
+
+//ScryptParams are the cost parameters of the scrypt key derivation,
+//N being 2^LogN
+type ScryptParams struct {
+	LogN int
+	R    int
+	P    int
+}
+
+//DefaultScryptParams are used to encrypt the keys of NewEncryptedKeyChain
+var DefaultScryptParams = ScryptParams{LogN: 15, R: 8, P: 1}
+
+//maxScryptParams bounds the cost a key file may ask for,
+//so a forged header cannot exhaust the memory of the node
+var maxScryptParams = ScryptParams{LogN: 20, R: 32, P: 16}
+
+const (
+	encryptedVersion = 1
+	saltSize         = 16
+	encryptionKeyLen = 32
+)
+
+//encryptedMagic prefixes the private key files of an encrypted keychain.
+//It is followed by the version, the scrypt parameters and the salt,
+//which are authenticated along with the file name.
+var encryptedMagic = []byte("THSE")
+
+//authenticatedMagic prefixes the public key files of an encrypted keychain.
+//It is followed by the same header, the nonce and the tag authenticating
+//the file name and the key, which is left in the clear.
+var authenticatedMagic = []byte("THSA")
+
+const headerSize = 4 + 4 + saltSize
+
+//scryptKey derives the encryption keys, replaced by the tests
+var scryptKey = scrypt.Key
+
+//This keychain encrypts private keys with AES-GCM under a key
+//derived from a passphrase. Public keys are stored in the clear,
+//authenticated with the same key.
+type encryptedKeychain struct {
+	*keychain
+	passphrase []byte
+	params     ScryptParams
+
+	lock sync.Mutex
+	//header is used for the keys stored by this keychain, after the magic
+	header []byte
+	//aeads caches the ciphers derived for the parameters and salt
+	//of each header read or written
+	aeads map[string]*derivation
+}
+
+//derivation is the cipher derived for the parameters and salt of a header,
+//derived once by the first of the loads and stores asking for it
+type derivation struct {
+	once sync.Once
+	aead cipher.AEAD
+	err  error
+}
+
+var _ KeyChain = (*encryptedKeychain)(nil)
+
+//NewEncryptedKeyChain returns a keychain storing its private keys in
+//directory encrypted with a key derived from passphrase.
+//Loading a key fails with ErrDecryption if the file was
+//not written with the same passphrase or was modified,
+//so public keys must also be stored through an encrypted keychain.
+//Only the encrypted files are cached.
+func NewEncryptedKeyChain(directory string, passphrase []byte, opts ...Option) KeyChain {
+	return newEncryptedKeyChain(directory, passphrase, DefaultScryptParams, opts...)
+}
+
+func newEncryptedKeyChain(directory string, passphrase []byte, params ScryptParams, opts ...Option) *encryptedKeychain {
+	return &encryptedKeychain{
+		keychain:   newKeyChain(directory, opts...),
+		passphrase: append([]byte{}, passphrase...),
+		params:     params,
+		aeads:      make(map[string]*derivation),
+	}
+}
+
+//Open returns the keychain in directory, encrypting private keys
+//with passphrase unless it is empty
+func Open(directory string, passphrase []byte, opts ...Option) KeyChain {
+	if len(passphrase) == 0 {
+		return NewKeyChain(directory, opts...)
+	}
+
+	return NewEncryptedKeyChain(directory, passphrase, opts...)
+}
+
+func (k *encryptedKeychain) LoadPrivateKey(name string) (crypto.PrivateKey, error) {
+	fileName, err := keyFileName(PrivateKeyPrefix, name)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := k.loadBytes(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBytes, err := k.open(fileName, sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	return key(keyBytes), nil
+}
+
+func (k *encryptedKeychain) StorePrivateKey(name string, priv crypto.PrivateKey) error {
+	unlock, err := k.lockName(name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return k.storePrivateKey(name, priv)
+}
+
+func (k *encryptedKeychain) storePrivateKey(name string, priv crypto.PrivateKey) error {
+	fileName, sealed, err := k.sealKey(name, priv)
+	if err != nil {
+		return err
+	}
+
+	return k.storeKey(fileName, sealed)
+}
+
+//sealKey returns the file storing priv under name and its sealed content
+func (k *encryptedKeychain) sealKey(name string, priv crypto.PrivateKey) (string, key, error) {
+	keyBytes, err := priv.MarshalBinary()
+	if err != nil {
+		return "", nil, err
+	}
+
+	fileName, err := keyFileName(PrivateKeyPrefix, name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sealed, err := k.seal(fileName, keyBytes)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fileName, key(sealed), nil
+}
+
+func (k *encryptedKeychain) LoadPublicKey(name string) (crypto.PublicKey, error) {
+	fileName, err := keyFileName(PublicKeyPrefix, name)
+	if err != nil {
+		return nil, err
+	}
+
+	authenticated, err := k.loadBytes(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBytes, err := k.verify(fileName, authenticated)
+	if err != nil {
+		return nil, err
+	}
+
+	return key(keyBytes), nil
+}
+
+func (k *encryptedKeychain) StorePublicKey(name string, pub crypto.PublicKey) error {
+	unlock, err := k.lockName(name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return k.storePublicKey(name, pub)
+}
+
+func (k *encryptedKeychain) storePublicKey(name string, pub crypto.PublicKey) error {
+	fileName, authenticated, err := k.authenticateKey(name, pub)
+	if err != nil {
+		return err
+	}
+
+	return k.storeKey(fileName, authenticated)
+}
+
+//authenticateKey returns the file storing pub under name
+//and its authenticated content
+func (k *encryptedKeychain) authenticateKey(name string, pub crypto.PublicKey) (string, key, error) {
+	keyBytes, err := pub.MarshalBinary()
+	if err != nil {
+		return "", nil, err
+	}
+
+	fileName, err := keyFileName(PublicKeyPrefix, name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	authenticated, err := k.authenticate(fileName, keyBytes)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fileName, key(authenticated), nil
+}
+
+//storeKeyPair stores pub and priv under name, keeping the previous
+//keys of name if either cannot be stored
+func (k *encryptedKeychain) storeKeyPair(name string, pub crypto.PublicKey, priv crypto.PrivateKey) error {
+	pubFile, authenticated, err := k.authenticateKey(name, pub)
+	if err != nil {
+		return err
+	}
+
+	privFile, sealed, err := k.sealKey(name, priv)
+	if err != nil {
+		return err
+	}
+
+	return k.storeKeys([]string{pubFile, privFile}, []encoding.BinaryMarshaler{authenticated, sealed})
+}
+
+//authenticate prefixes the public key stored in fileName with a tag
+//binding it to the passphrase and to the file name
+func (k *encryptedKeychain) authenticate(fileName string, keyBytes []byte) ([]byte, error) {
+	header, err := k.newHeader(authenticatedMagic)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := k.aead(header)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	authenticated := append(append([]byte{}, header...), nonce...)
+	authenticated = aead.Seal(authenticated, nonce, nil, authenticatedData(header, fileName, keyBytes))
+	return append(authenticated, keyBytes...), nil
+}
+
+//verify returns the public key stored in fileName if its tag is valid
+func (k *encryptedKeychain) verify(fileName string, authenticated []byte) ([]byte, error) {
+	header, aead, rest, err := k.openHeader(fileName, authenticatedMagic, authenticated)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) < aead.NonceSize()+aead.Overhead() {
+		return nil, fmt.Errorf("%w: %v is truncated", ErrDecryption, fileName)
+	}
+
+	nonce, tag := rest[:aead.NonceSize()], rest[aead.NonceSize():aead.NonceSize()+aead.Overhead()]
+	keyBytes := rest[aead.NonceSize()+aead.Overhead():]
+	if _, err := aead.Open(nil, nonce, tag, authenticatedData(header, fileName, keyBytes)); err != nil {
+		return nil, fmt.Errorf("%w: %v was modified or the passphrase is wrong", ErrDecryption, fileName)
+	}
+
+	return keyBytes, nil
+}
+
+//seal encrypts the key stored in fileName
+func (k *encryptedKeychain) seal(fileName string, keyBytes []byte) ([]byte, error) {
+	header, err := k.newHeader(encryptedMagic)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := k.aead(header)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := append(append([]byte{}, header...), nonce...)
+	return aead.Seal(sealed, nonce, keyBytes, additionalData(header, fileName)), nil
+}
+
+//open decrypts the key stored in fileName.
+//The file name is authenticated so keys cannot be swapped.
+func (k *encryptedKeychain) open(fileName string, sealed []byte) ([]byte, error) {
+	header, aead, sealed, err := k.openHeader(fileName, encryptedMagic, sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: %v is truncated", ErrDecryption, fileName)
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	keyBytes, err := aead.Open(nil, nonce, ciphertext, additionalData(header, fileName))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v was modified or the passphrase is wrong", ErrDecryption, fileName)
+	}
+
+	return keyBytes, nil
+}
+
+//openHeader returns the header of the file fileName starting with magic,
+//the cipher it was written with and what follows the header
+func (k *encryptedKeychain) openHeader(fileName string, magic []byte, data []byte) ([]byte, cipher.AEAD, []byte, error) {
+	if !bytes.HasPrefix(data, magic) {
+		return nil, nil, nil, fmt.Errorf("%w: %v is not written by an encrypted keychain", ErrDecryption, fileName)
+	}
+
+	if len(data) < headerSize {
+		return nil, nil, nil, fmt.Errorf("%w: %v is truncated", ErrDecryption, fileName)
+	}
+
+	header := data[:headerSize]
+	if header[4] != encryptedVersion {
+		return nil, nil, nil, fmt.Errorf("%w: %v has unknown version %v", ErrDecryption, fileName, header[4])
+	}
+
+	aead, err := k.aead(header)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return header, aead, data[headerSize:], nil
+}
+
+//newHeader returns the header of the keys stored by this keychain
+//starting with magic, choosing its salt on first use so scrypt only runs once
+func (k *encryptedKeychain) newHeader(magic []byte) ([]byte, error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if k.header == nil {
+		if err := k.params.check(); err != nil {
+			return nil, err
+		}
+
+		header := []byte{encryptedVersion, byte(k.params.LogN), byte(k.params.R), byte(k.params.P)}
+
+		salt := make([]byte, saltSize)
+		if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+			return nil, err
+		}
+
+		k.header = append(header, salt...)
+	}
+
+	return append(append([]byte{}, magic...), k.header...), nil
+}
+
+//aead returns the cipher for the parameters and salt of header.
+//scrypt runs without holding the lock of the keychain, so deriving the
+//cipher of a header does not hold up the keys of other headers.
+func (k *encryptedKeychain) aead(header []byte) (cipher.AEAD, error) {
+	id := string(header[4:])
+
+	k.lock.Lock()
+	d, ok := k.aeads[id]
+	if !ok {
+		d = &derivation{}
+		k.aeads[id] = d
+	}
+	k.lock.Unlock()
+
+	d.once.Do(func() {
+		d.aead, d.err = k.derive(header)
+	})
+
+	if d.err != nil {
+		//headers failing to derive are not kept, as they may be forged
+		k.lock.Lock()
+		if k.aeads[id] == d {
+			delete(k.aeads, id)
+		}
+		k.lock.Unlock()
+	}
+
+	return d.aead, d.err
+}
+
+//derive derives the cipher for the parameters and salt of header
+func (k *encryptedKeychain) derive(header []byte) (cipher.AEAD, error) {
+	params := ScryptParams{LogN: int(header[5]), R: int(header[6]), P: int(header[7])}
+	if err := params.check(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecryption, err)
+	}
+
+	salt := header[8:headerSize]
+	encryptionKey, err := scryptKey(k.passphrase, salt, 1<<params.LogN, params.R, params.P, encryptionKeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func (p ScryptParams) check() error {
+	if p.LogN < 1 || p.R < 1 || p.P < 1 ||
+		p.LogN > maxScryptParams.LogN || p.R > maxScryptParams.R || p.P > maxScryptParams.P {
+		return fmt.Errorf("invalid scrypt parameters N=2^%v r=%v p=%v", p.LogN, p.R, p.P)
+	}
+
+	return nil
+}
+
+func additionalData(header []byte, fileName string) []byte {
+	return append(append([]byte{}, header...), fileName...)
+}
+
+func authenticatedData(header []byte, fileName string, keyBytes []byte) []byte {
+	return append(append(additionalData(header, fileName), 0), keyBytes...)
+}
@@ -0,0 +1,197 @@
+package keychain
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/scrypt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+//testScryptParams keep the tests fast
+var testScryptParams = ScryptParams{LogN: 10, R: 8, P: 1}
+
+func newTestEncryptedKeyChain(test *testing.T, passphrase string, opts ...Option) (*encryptedKeychain, string) {
+	dir, err := ioutil.TempDir("test", "encrypted")
+	require.Nil(test, err)
+
+	dir = dir + "/"
+	return newEncryptedKeyChain(dir, []byte(passphrase), testScryptParams, opts...), dir
+}
+
+func TestEncryptedKeychain_StoreLoadKeys(test *testing.T) {
+	ks, dir := newTestEncryptedKeyChain(test, "passphrase")
+	defer os.RemoveAll(dir)
+
+	require.Nil(test, ks.StorePrivateKey("TBLS256_5_3", key("secret share")))
+	require.Nil(test, ks.StorePublicKey("TBLS256_5_3", key("pub")))
+
+	raw, err := ioutil.ReadFile(dir + "priv_TBLS256_5_3")
+	require.Nil(test, err)
+	assert.False(test, bytes.Contains(raw, []byte("secret share")))
+
+	priv, err := ks.LoadPrivateKey("TBLS256_5_3")
+	require.Nil(test, err)
+	assert.Equal(test, key("secret share"), priv)
+
+	//a new keychain derives the key again from the passphrase
+	reopened := newEncryptedKeyChain(dir, []byte("passphrase"), testScryptParams)
+	priv, err = reopened.LoadPrivateKey("TBLS256_5_3")
+	require.Nil(test, err)
+	assert.Equal(test, key("secret share"), priv)
+
+	//public keys are stored in the clear
+	raw, err = ioutil.ReadFile(dir + "pub_TBLS256_5_3")
+	require.Nil(test, err)
+	assert.True(test, bytes.HasSuffix(raw, []byte("pub")))
+
+	pub, err := reopened.LoadPublicKey("TBLS256_5_3")
+	require.Nil(test, err)
+	assert.Equal(test, key("pub"), pub)
+}
+
+func TestEncryptedKeychain_WrongPassphrase(test *testing.T) {
+	ks, dir := newTestEncryptedKeyChain(test, "passphrase")
+	defer os.RemoveAll(dir)
+
+	require.Nil(test, ks.StorePrivateKey("TBLS256_5_3", key("secret share")))
+
+	other := newEncryptedKeyChain(dir, []byte("other"), testScryptParams)
+	_, err := other.LoadPrivateKey("TBLS256_5_3")
+	assert.True(test, errors.Is(err, ErrDecryption))
+}
+
+func TestEncryptedKeychain_RejectsTamperedFiles(test *testing.T) {
+	//files are changed behind the keychain
+	ks, dir := newTestEncryptedKeyChain(test, "passphrase", WithCacheSize(0))
+	defer os.RemoveAll(dir)
+
+	require.Nil(test, ks.StorePrivateKey("TBLS256_5_3", key("secret share")))
+	sealed, err := ioutil.ReadFile(dir + "priv_TBLS256_5_3")
+	require.Nil(test, err)
+
+	for _, i := range []int{5, 8, headerSize, len(sealed) - 1} {
+		tampered := append([]byte{}, sealed...)
+		tampered[i] ^= 1
+		require.Nil(test, ioutil.WriteFile(dir+"priv_TBLS256_5_3", tampered, 0600))
+
+		_, err := ks.LoadPrivateKey("TBLS256_5_3")
+		assert.True(test, errors.Is(err, ErrDecryption), "byte %v", i)
+	}
+
+	require.Nil(test, ioutil.WriteFile(dir+"priv_TBLS256_5_3", sealed[:len(sealed)-4], 0600))
+	_, err = ks.LoadPrivateKey("TBLS256_5_3")
+	assert.True(test, errors.Is(err, ErrDecryption))
+
+	//keys cannot be moved to another name
+	require.Nil(test, ioutil.WriteFile(dir+"priv_TBLS256_5_4", sealed, 0600))
+	_, err = ks.LoadPrivateKey("TBLS256_5_4")
+	assert.True(test, errors.Is(err, ErrDecryption))
+
+	//nor replaced by a plain key
+	require.Nil(test, NewKeyChain(dir).StorePrivateKey("TBLS256_5_3", key("secret share")))
+	_, err = ks.LoadPrivateKey("TBLS256_5_3")
+	assert.True(test, errors.Is(err, ErrDecryption))
+}
+
+func TestEncryptedKeychain_RejectsTamperedPublicKeys(test *testing.T) {
+	ks, dir := newTestEncryptedKeyChain(test, "passphrase", WithCacheSize(0))
+	defer os.RemoveAll(dir)
+
+	require.Nil(test, ks.StorePublicKey("TBLS256_5_3", key("pub")))
+	authenticated, err := ioutil.ReadFile(dir + "pub_TBLS256_5_3")
+	require.Nil(test, err)
+
+	for _, i := range []int{5, 8, headerSize, len(authenticated) - 1} {
+		tampered := append([]byte{}, authenticated...)
+		tampered[i] ^= 1
+		require.Nil(test, ioutil.WriteFile(dir+"pub_TBLS256_5_3", tampered, 0600))
+
+		_, err := ks.LoadPublicKey("TBLS256_5_3")
+		assert.True(test, errors.Is(err, ErrDecryption), "byte %v", i)
+	}
+
+	//the key cannot be replaced keeping the tag
+	tampered := append(append([]byte{}, authenticated[:len(authenticated)-3]...), "bad"...)
+	require.Nil(test, ioutil.WriteFile(dir+"pub_TBLS256_5_3", tampered, 0600))
+	_, err = ks.LoadPublicKey("TBLS256_5_3")
+	assert.True(test, errors.Is(err, ErrDecryption))
+
+	require.Nil(test, ioutil.WriteFile(dir+"pub_TBLS256_5_3", authenticated[:headerSize+4], 0600))
+	_, err = ks.LoadPublicKey("TBLS256_5_3")
+	assert.True(test, errors.Is(err, ErrDecryption))
+
+	//nor moved to another name
+	require.Nil(test, ioutil.WriteFile(dir+"pub_TBLS256_5_4", authenticated, 0600))
+	_, err = ks.LoadPublicKey("TBLS256_5_4")
+	assert.True(test, errors.Is(err, ErrDecryption))
+
+	//nor replaced by a plain key
+	require.Nil(test, NewKeyChain(dir).StorePublicKey("TBLS256_5_3", key("pub")))
+	_, err = ks.LoadPublicKey("TBLS256_5_3")
+	assert.True(test, errors.Is(err, ErrDecryption))
+
+	//nor authenticated with another passphrase
+	require.Nil(test, ioutil.WriteFile(dir+"pub_TBLS256_5_3", authenticated, 0600))
+	other := newEncryptedKeyChain(dir, []byte("other"), testScryptParams)
+	_, err = other.LoadPublicKey("TBLS256_5_3")
+	assert.True(test, errors.Is(err, ErrDecryption))
+
+	pub, err := ks.LoadPublicKey("TBLS256_5_3")
+	require.Nil(test, err)
+	assert.Equal(test, key("pub"), pub)
+}
+
+//TestEncryptedKeychain_DerivesOutsideLock keeps the derivation of
+//one header running while the key of another is stored and loaded
+func TestEncryptedKeychain_DerivesOutsideLock(test *testing.T) {
+	ks, dir := newTestEncryptedKeyChain(test, "passphrase")
+	defer os.RemoveAll(dir)
+
+	slowSalt := bytes.Repeat([]byte{0xff}, saltSize)
+	deriving, release := make(chan struct{}), make(chan struct{})
+	defer func(derive func([]byte, []byte, int, int, int, int) ([]byte, error)) {
+		scryptKey = derive
+	}(scryptKey)
+	scryptKey = func(password, salt []byte, N, r, p, keyLen int) ([]byte, error) {
+		if bytes.Equal(salt, slowSalt) {
+			close(deriving)
+			<-release
+		}
+		return scrypt.Key(password, salt, N, r, p, keyLen)
+	}
+
+	header := append([]byte{'T', 'H', 'S', 'E', encryptedVersion, byte(testScryptParams.LogN), byte(testScryptParams.R), byte(testScryptParams.P)}, slowSalt...)
+	go ks.aead(header)
+	defer close(release)
+	<-deriving
+
+	done := make(chan error)
+	go func() {
+		if err := ks.StorePrivateKey("TBLS256_5_3", key("secret share")); err != nil {
+			done <- err
+			return
+		}
+		_, err := ks.LoadPrivateKey("TBLS256_5_3")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		assert.Nil(test, err)
+	case <-time.After(10 * time.Second):
+		test.Fatal("key waited for the derivation of another header")
+	}
+}
+
+func TestOpen(test *testing.T) {
+	_, ok := Open("dir/", nil).(*keychain)
+	assert.True(test, ok)
+
+	_, ok = Open("dir/", []byte("passphrase")).(*encryptedKeychain)
+	assert.True(test, ok)
+}
@@ -0,0 +1,229 @@
+package keychain
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+//ErrInvalidName is returned for key names that would not
+//name a file of the key directory
+var ErrInvalidName = errors.New("invalid key name")
+
+//KeyFileMode is the permission of the key files written by a keychain
+const KeyFileMode = 0600
+
+//lockFileName is the file locked by the writers of a key directory
+const lockFileName = ".lock"
+
+//keyFileName returns the name of the file storing name with prefix
+func keyFileName(prefix string, name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\\x00") {
+		return "", fmt.Errorf("%w: %q escapes the key directory", ErrInvalidName, name)
+	}
+
+	fileName := fmt.Sprintf(prefix, name)
+	if filepath.Base(fileName) != fileName {
+		return "", fmt.Errorf("%w: %q escapes the key directory", ErrInvalidName, name)
+	}
+
+	return fileName, nil
+}
+
+func (k *keychain) path(fileName string) string {
+	return filepath.Join(k.directory, fileName)
+}
+
+//writeFile replaces the file at path with data.
+//data is written to a temporary file that is synced and renamed over
+//path, so a crash leaves either the previous or the new key on disk.
+func writeFile(path string, data []byte) error {
+	return writeFiles([]string{path}, [][]byte{data})
+}
+
+//writeFiles replaces the files at paths, all in the same directory,
+//with data as writeFile does. Every temporary file is written before
+//any is renamed, and the files already replaced are restored if a
+//rename fails, so a failed write leaves the previous files on disk.
+func writeFiles(paths []string, data [][]byte) error {
+	dir, _ := filepath.Split(paths[0])
+	if dir == "" {
+		dir = "."
+	}
+
+	tmps := make([]string, 0, len(paths))
+	//fails for the files that were renamed
+	defer func() {
+		for _, tmp := range tmps {
+			os.Remove(tmp)
+		}
+	}()
+
+	for i, path := range paths {
+		tmp, err := writeTemp(dir, filepath.Base(path), data[i])
+		if err != nil {
+			return err
+		}
+		tmps = append(tmps, tmp)
+	}
+
+	previous := make([][]byte, len(paths))
+	for i, path := range paths {
+		b, err := ioutil.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		previous[i] = b
+	}
+
+	for i, path := range paths {
+		if err := os.Rename(tmps[i], path); err != nil {
+			restoreFiles(paths[:i], previous[:i])
+			return err
+		}
+	}
+
+	return syncDir(dir)
+}
+
+//writeTemp writes data to a synced temporary file in dir
+//named after name, returning the path of the file
+func writeTemp(dir string, name string, data []byte) (string, error) {
+	tmp, err := ioutil.TempFile(dir, "."+name+".tmp")
+	if err != nil {
+		return "", err
+	}
+
+	err = tmp.Chmod(KeyFileMode)
+	if err == nil {
+		_, err = tmp.Write(data)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	return tmp.Name(), nil
+}
+
+//restoreFiles puts back the previous content of the files at paths,
+//removing the ones that did not exist
+func restoreFiles(paths []string, previous [][]byte) {
+	for i, path := range paths {
+		if previous[i] == nil {
+			os.Remove(path)
+			continue
+		}
+		writeFile(path, previous[i])
+	}
+}
+
+//dirLock is an advisory lock on the key directory excluding the writers
+//of other processes and keychains. It is reentrant, so a rotation can
+//hold it across the stores it makes; writers of the same keychain are
+//excluded from each other by the locks of the names they write.
+//Key directories are not locked on Windows.
+type dirLock struct {
+	lock    sync.Mutex
+	holders int
+	file    *os.File
+}
+
+//lockDirectory takes the lock of the key directory,
+//waiting for other processes to release it
+func (k *keychain) lockDirectory() (unlock func(), err error) {
+	l := &k.dirLock
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.holders == 0 {
+		f, err := os.OpenFile(k.path(lockFileName), os.O_RDWR|os.O_CREATE, KeyFileMode)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := lockFile(f); err != nil {
+			f.Close()
+			return nil, err
+		}
+
+		l.file = f
+	}
+
+	l.holders++
+	return l.release, nil
+}
+
+//nameLocks serializes the writers of each key name
+type nameLocks struct {
+	lock  sync.Mutex
+	names map[string]*nameLock
+}
+
+type nameLock struct {
+	sync.Mutex
+	holders int
+}
+
+//lockName waits for the other writers of name, which cannot be the name
+//of a previous version as those are only written by rotations
+func (k *keychain) lockName(name string) (unlock func(), err error) {
+	if _, err := keyFileName(PublicKeyPrefix, name); err != nil {
+		return nil, err
+	}
+
+	if strings.Contains(name, versionSeparator) {
+		return nil, fmt.Errorf("%w: %q cannot contain %q, which names previous versions", ErrInvalidName, name, versionSeparator)
+	}
+
+	return k.names.acquire(name), nil
+}
+
+func (l *nameLocks) acquire(name string) (release func()) {
+	l.lock.Lock()
+	if l.names == nil {
+		l.names = make(map[string]*nameLock)
+	}
+	n, ok := l.names[name]
+	if !ok {
+		n = &nameLock{}
+		l.names[name] = n
+	}
+	n.holders++
+	l.lock.Unlock()
+
+	n.Lock()
+
+	return func() {
+		n.Unlock()
+
+		l.lock.Lock()
+		defer l.lock.Unlock()
+		n.holders--
+		if n.holders == 0 {
+			delete(l.names, name)
+		}
+	}
+}
+
+func (l *dirLock) release() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.holders--
+	if l.holders == 0 {
+		unlockFile(l.file)
+		l.file.Close()
+		l.file = nil
+	}
+}
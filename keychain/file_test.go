@@ -0,0 +1,174 @@
+package keychain
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKeychain_FilePermissions(test *testing.T) {
+	dir, err := ioutil.TempDir("test", "keystore")
+	require.Nil(test, err)
+	defer os.RemoveAll(dir)
+
+	//directories without a trailing separator are fine
+	ks := NewKeyChain(dir)
+	require.Nil(test, ks.StorePrivateKey("TBLS256_5_3", key("priv")))
+	require.Nil(test, ks.StorePrivateKey("TBLS256_5_3", key("priv2")))
+	require.Nil(test, ks.StorePublicKey("TBLS256_5_3", key("pub")))
+
+	files, err := ioutil.ReadDir(dir)
+	require.Nil(test, err)
+
+	names := make([]string, 0)
+	for _, file := range files {
+		names = append(names, file.Name())
+		if strings.HasPrefix(file.Name(), "p") {
+			assert.Equal(test, os.FileMode(KeyFileMode), file.Mode().Perm(), file.Name())
+		}
+	}
+
+	//no temporary files are left behind
+	assert.ElementsMatch(test, []string{lockFileName, "priv_TBLS256_5_3", "pub_TBLS256_5_3"}, names)
+
+	priv, err := NewKeyChain(dir).LoadPrivateKey("TBLS256_5_3")
+	require.Nil(test, err)
+	assert.Equal(test, key("priv2"), priv)
+}
+
+//TestWriteFiles_RestoresOnFailure fails the second rename of a write
+//by making its target a directory
+func TestWriteFiles_RestoresOnFailure(test *testing.T) {
+	dir, err := ioutil.TempDir("test", "keystore")
+	require.Nil(test, err)
+	defer os.RemoveAll(dir)
+
+	pub, priv := filepath.Join(dir, "pub_TBLS256_5_3"), filepath.Join(dir, "priv_TBLS256_5_3")
+	require.Nil(test, writeFile(pub, []byte("pub0")))
+	require.Nil(test, os.MkdirAll(filepath.Join(priv, "taken"), os.ModePerm))
+
+	assert.NotNil(test, writeFiles([]string{pub, priv}, [][]byte{[]byte("pub1"), []byte("priv1")}))
+
+	b, err := ioutil.ReadFile(pub)
+	require.Nil(test, err)
+	assert.Equal(test, []byte("pub0"), b)
+
+	//no temporary files are left behind
+	files, err := ioutil.ReadDir(dir)
+	require.Nil(test, err)
+	assert.Len(test, files, 2)
+}
+
+func TestKeychain_RejectsEscapingNames(test *testing.T) {
+	dir, err := ioutil.TempDir("test", "keystore")
+	require.Nil(test, err)
+	defer os.RemoveAll(dir)
+
+	ks := NewKeyChain(dir + "/")
+
+	for _, name := range []string{"", ".", "..", "../escaped", "a/b", `a\b`, "/etc/passwd", "a\x00b"} {
+		assert.True(test, errors.Is(ks.StorePublicKey(name, key("pub")), ErrInvalidName), name)
+		assert.True(test, errors.Is(ks.StorePrivateKey(name, key("priv")), ErrInvalidName), name)
+
+		_, err := ks.LoadPublicKey(name)
+		assert.True(test, errors.Is(err, ErrInvalidName), name)
+		_, err = ks.LoadPrivateKey(name)
+		assert.True(test, errors.Is(err, ErrInvalidName), name)
+
+		assert.True(test, errors.Is(ks.Delete(name), ErrInvalidName), name)
+		_, err = ks.Rotate(name, key("pub"), key("priv"))
+		assert.True(test, errors.Is(err, ErrInvalidName), name)
+	}
+
+	_, err = os.Stat("test/escaped")
+	assert.True(test, os.IsNotExist(err))
+
+	//only rotations store previous versions
+	assert.True(test, errors.Is(ks.StorePublicKey("TBLS256_5_3@1", key("pub")), ErrInvalidName))
+	assert.True(test, errors.Is(ks.StorePrivateKey("TBLS256_5_3@1", key("priv")), ErrInvalidName))
+	_, err = ks.Rotate("TBLS256_5_3@1", key("pub"), key("priv"))
+	assert.True(test, errors.Is(err, ErrInvalidName))
+}
+
+//TestKeychain_StoresWaitForRotations holds the name of a key as a rotation
+//does, which must keep stores of the name from interleaving with it
+func TestKeychain_StoresWaitForRotations(test *testing.T) {
+	dir, err := ioutil.TempDir("test", "keystore")
+	require.Nil(test, err)
+
+	dir = dir + "/"
+	defer os.RemoveAll(dir)
+
+	ks := newKeyChain(dir)
+	release := ks.names.acquire("TBLS256_5_3")
+
+	stored := make(chan error, 1)
+	go func() {
+		stored <- ks.StorePublicKey("TBLS256_5_3", key("pub"))
+	}()
+
+	select {
+	case <-stored:
+		test.Fatal("store did not wait for the rotation")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	//other names are not held
+	require.Nil(test, ks.StorePublicKey("TBLS256_5_4", key("pub")))
+
+	release()
+	require.Nil(test, <-stored)
+	assert.Empty(test, ks.names.names)
+}
+
+//TestKeychain_ConcurrentRotations rotates the same key from two keychains
+//of one directory, which only the directory lock keeps apart
+func TestKeychain_ConcurrentRotations(test *testing.T) {
+	dir, err := ioutil.TempDir("test", "keystore")
+	require.Nil(test, err)
+
+	dir = dir + "/"
+	defer os.RemoveAll(dir)
+
+	keychains := []KeyChain{NewKeyChain(dir, WithCacheSize(0)), NewKeyChain(dir, WithCacheSize(0))}
+	require.Nil(test, keychains[0].StorePublicKey("TBLS256_5_3", key("pub")))
+	require.Nil(test, keychains[0].StorePrivateKey("TBLS256_5_3", key("priv")))
+
+	rotations := 10
+	versions := make(chan int, len(keychains)*rotations)
+
+	var wg sync.WaitGroup
+	for _, ks := range keychains {
+		ks := ks
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < rotations; i++ {
+				version, err := ks.Rotate("TBLS256_5_3", key("pub"), key("priv"))
+				assert.Nil(test, err)
+				versions <- version
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(versions)
+
+	rotated := make([]int, 0)
+	for version := range versions {
+		rotated = append(rotated, version)
+	}
+	sort.Ints(rotated)
+
+	for i, version := range rotated {
+		assert.Equal(test, i+1, version)
+	}
+}
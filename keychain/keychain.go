@@ -2,10 +2,10 @@ package keychain
 
 import (
 	"encoding"
-	"fmt"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"io/ioutil"
 	"os"
+	"sync"
 )
 
 type KeyChain interface {
@@ -13,16 +13,39 @@ type KeyChain interface {
 	LoadPublicKey(name string) (crypto.PublicKey, error)
 	StorePublicKey(name string, pub crypto.PublicKey) error
 	StorePrivateKey(name string, priv crypto.PrivateKey) error
+
+	//List describes every version of every key in the keychain
+	List() ([]KeyInfo, error)
+	//Delete removes the public and private key stored under name,
+	//which may name a previous version
+	Delete(name string) error
+	//Rotate stores pub and priv under name, keeping the keys they
+	//replace addressable by VersionName(name, version).
+	//Rotations and stores of a name are exclusive, but only within the
+	//process on Windows where key directories are not locked.
+	Rotate(name string, pub crypto.PublicKey, priv crypto.PrivateKey) (version int, err error)
 }
 
+//A KeyChain can back the key store of a crypto.SignerProcessor
+var _ crypto.KeyStore = (KeyChain)(nil)
+
 const PrivateKeyPrefix = "priv_%v"
 const PublicKeyPrefix = "pub_%v"
 
 //This keychain can store and read public/private
-//keys for the client only in binary
+//keys for the client only in binary.
+//It is safe for concurrent use.
 type keychain struct {
 	directory string
-	cache     map[string][]byte
+	cache     *cache
+	//reload makes cached keys be read again when their file changes
+	reload bool
+	//files is held to read key files and exclusively to change them
+	files sync.RWMutex
+	//names serializes the stores and rotations of each key name,
+	//so rotations pick distinct versions and keep the keys they replace
+	names   nameLocks
+	dirLock dirLock
 }
 
 type key []byte
@@ -31,62 +54,233 @@ func (k key) MarshalBinary() (data []byte, err error) {
 	return k, nil
 }
 
-func NewKeyChain(directory string) KeyChain {
-	return &keychain{directory: directory}
+//Option configures a keychain
+type Option func(*keychain)
+
+//WithCacheSize keeps up to size key files in memory.
+//A size of 0 disables the cache.
+func WithCacheSize(size int) Option {
+	return func(k *keychain) {
+		k.cache = newCache(size)
+	}
+}
+
+//WithReload checks the modification time of cached key files on every
+//load and reads them again if they were changed by another process
+func WithReload(reload bool) Option {
+	return func(k *keychain) {
+		k.reload = reload
+	}
+}
+
+func NewKeyChain(directory string, opts ...Option) KeyChain {
+	return newKeyChain(directory, opts...)
+}
+
+func newKeyChain(directory string, opts ...Option) *keychain {
+	k := &keychain{directory: directory, cache: newCache(DefaultCacheSize)}
+	for _, opt := range opts {
+		opt(k)
+	}
+	return k
 }
 
 func (k *keychain) LoadPrivateKey(name string) (crypto.PrivateKey, error) {
-	keyBytes, err := k.loadBytes(fmt.Sprintf(PrivateKeyPrefix, name))
+	fileName, err := keyFileName(PrivateKeyPrefix, name)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBytes, err := k.loadBytes(fileName)
 	return key(keyBytes), err
 }
 
 func (k *keychain) LoadPublicKey(name string) (crypto.PublicKey, error) {
-	keyBytes, err := k.loadBytes(fmt.Sprintf(PublicKeyPrefix, name))
+	fileName, err := keyFileName(PublicKeyPrefix, name)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBytes, err := k.loadBytes(fileName)
 	return key(keyBytes), err
 }
 
 func (k *keychain) loadBytes(name string) ([]byte, error) {
-	v, ok := k.cache[k.directory+name]
+	e, ok := k.cache.get(name)
 
-	if ok {
-		return v, nil
+	if ok && !k.reload {
+		return e.data, nil
 	}
 
-	f, err := os.Open(k.directory + name)
+	k.files.RLock()
+	defer k.files.RUnlock()
+
+	info, err := os.Stat(k.path(name))
 
 	if err != nil {
 		return nil, err
 	}
 
-	b, err := ioutil.ReadAll(f)
+	if ok && info.ModTime().Equal(e.modTime) && info.Size() == e.fileSize {
+		return e.data, nil
+	}
+
+	b, err := ioutil.ReadFile(k.path(name))
 
-	return b, err
+	if err != nil {
+		return nil, err
+	}
+
+	//stores wait for the read lock, so b cannot be older than the cache
+	k.cache.put(cacheEntry{name: name, data: b, modTime: info.ModTime(), fileSize: info.Size()})
+
+	return b, nil
 }
 
 func (k *keychain) StorePublicKey(name string, pub crypto.PublicKey) error {
-	return k.storeKey(fmt.Sprintf(PublicKeyPrefix, name), pub)
+	unlock, err := k.lockName(name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return k.storePublicKey(name, pub)
 }
 
 func (k *keychain) StorePrivateKey(name string, priv crypto.PrivateKey) error {
-	return k.storeKey(fmt.Sprintf(PrivateKeyPrefix, name), priv)
+	unlock, err := k.lockName(name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return k.storePrivateKey(name, priv)
 }
 
-func (k *keychain) storeKey(name string, key encoding.BinaryMarshaler) error {
-	f, err := os.Create(k.directory + name)
+//storePublicKey stores pub under name, which may name a previous version
+func (k *keychain) storePublicKey(name string, pub crypto.PublicKey) error {
+	fileName, err := keyFileName(PublicKeyPrefix, name)
+	if err != nil {
+		return err
+	}
 
+	return k.storeKey(fileName, pub)
+}
+
+//storePrivateKey stores priv under name, which may name a previous version
+func (k *keychain) storePrivateKey(name string, priv crypto.PrivateKey) error {
+	fileName, err := keyFileName(PrivateKeyPrefix, name)
 	if err != nil {
 		return err
 	}
 
-	b, err := key.MarshalBinary()
+	return k.storeKey(fileName, priv)
+}
 
+//storeKeyPair stores pub and priv under name, keeping the previous
+//keys of name if either cannot be stored
+func (k *keychain) storeKeyPair(name string, pub crypto.PublicKey, priv crypto.PrivateKey) error {
+	pubFile, err := keyFileName(PublicKeyPrefix, name)
 	if err != nil {
 		return err
 	}
 
-	_, err = f.Write(b)
+	privFile, err := keyFileName(PrivateKeyPrefix, name)
+	if err != nil {
+		return err
+	}
+
+	return k.storeKeys([]string{pubFile, privFile}, []encoding.BinaryMarshaler{pub, priv})
+}
+
+//storeKey atomically replaces the key file name,
+//holding the lock of the key directory
+func (k *keychain) storeKey(name string, key encoding.BinaryMarshaler) error {
+	return k.storeKeys([]string{name}, []encoding.BinaryMarshaler{key})
+}
+
+//storeKeys atomically replaces the key files names with keys as storeKey
+//does, restoring the previous files if any of them cannot be replaced
+func (k *keychain) storeKeys(names []string, keys []encoding.BinaryMarshaler) error {
+	paths := make([]string, len(names))
+	data := make([][]byte, len(keys))
+	for i, key := range keys {
+		b, err := key.MarshalBinary()
+
+		if err != nil {
+			return err
+		}
+
+		paths[i], data[i] = k.path(names[i]), b
+	}
+
+	unlock, err := k.lockDirectory()
+
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	k.files.Lock()
+	defer k.files.Unlock()
+	defer func() {
+		for _, name := range names {
+			k.cache.invalidate(name)
+		}
+	}()
+
+	return writeFiles(paths, data)
+}
+
+//LoadPublicEnvelope loads the envelope of the public key stored under name
+func LoadPublicEnvelope(k KeyChain, name string) (*crypto.KeyEnvelope, error) {
+	pub, err := k.LoadPublicKey(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return openEnvelope(pub)
+}
+
+//LoadShareEnvelope loads the envelope of the private key share stored under name
+func LoadShareEnvelope(k KeyChain, name string) (*crypto.KeyEnvelope, error) {
+	priv, err := k.LoadPrivateKey(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return openEnvelope(priv)
+}
+
+//Fingerprint returns the fingerprint of the public key stored under name,
+//which must be sealed in an envelope to know its scheme
+func Fingerprint(k KeyChain, name string) (string, error) {
+	env, err := LoadPublicEnvelope(k, name)
+	if err != nil {
+		return "", err
+	}
+
+	return envelopeFingerprint(env)
+}
+
+//envelopeFingerprint returns the fingerprint recorded in env,
+//computing it for public keys sealed without one
+func envelopeFingerprint(env *crypto.KeyEnvelope) (string, error) {
+	if env.Fingerprint != "" || env.IsShare() {
+		return env.Fingerprint, nil
+	}
+
+	return crypto.Fingerprint("", env)
+}
+
+func openEnvelope(k encoding.BinaryMarshaler) (*crypto.KeyEnvelope, error) {
+	b, err := k.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
 
-	return err
+	return crypto.UnmarshalKeyEnvelope(b)
 }
 
 func ConvertBytesToPubKey(bytes []byte) crypto.PublicKey {
@@ -1,7 +1,9 @@
 package keychain
 
 import (
+	"errors"
 	"fmt"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
 	"github.com/jffp113/CryptoProviderSDK/example/handlers/tbls"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
@@ -20,7 +22,8 @@ func TestKeychain_StoreLoadKeys(test *testing.T) {
 	n, t := 5, 3
 
 	gen := tbls.NewTBLS256KeyGenerator()
-	pub, _ := gen.Gen(n, t)
+	pub, _, err := gen.Gen(n, t)
+	assert.Nil(test, err)
 
 	keyName := fmt.Sprintf("TBLS_%v_%v", n, t)
 	err = ks.StorePublicKey(keyName, pub)
@@ -37,3 +40,72 @@ func TestKeychain_StoreLoadKeys(test *testing.T) {
 
 	assert.Nil(test, err)
 }
+
+func TestKeychain_LoadEnvelopes(test *testing.T) {
+	dir, err := ioutil.TempDir("test", "keystore")
+	assert.Nil(test, err)
+
+	dir = dir + "/"
+	defer os.RemoveAll(dir)
+
+	ks := NewKeyChain(dir)
+	n, t := 5, 3
+
+	pub, shares, err := tbls.NewTBLS256KeyGenerator().Gen(n, t)
+	assert.Nil(test, err)
+
+	pubEnv, err := crypto.NewKeyEnvelope("TBLS256", t, n, 0, "TBLS256_5_3", pub)
+	assert.Nil(test, err)
+	shareEnv, err := crypto.NewKeyEnvelope("TBLS256", t, n, 2, "TBLS256_5_3", shares[1])
+	assert.Nil(test, err)
+
+	assert.Nil(test, ks.StorePublicKey("TBLS256_5_3", pubEnv))
+	assert.Nil(test, ks.StorePrivateKey("TBLS256_5_3", shareEnv))
+	assert.Nil(test, ks.StorePublicKey("raw", pub))
+
+	env, err := LoadPublicEnvelope(ks, "TBLS256_5_3")
+	assert.Nil(test, err)
+	assert.Equal(test, pubEnv.Key, env.Key)
+	assert.False(test, env.IsShare())
+
+	env, err = LoadShareEnvelope(ks, "TBLS256_5_3")
+	assert.Nil(test, err)
+	assert.Equal(test, 2, env.Index)
+	assert.Equal(test, t, env.T)
+	assert.Equal(test, "TBLS256_5_3", env.KeyID)
+
+	_, err = LoadPublicEnvelope(ks, "raw")
+	assert.True(test, errors.Is(err, crypto.ErrBadKeyEncoding))
+}
+
+func TestKeychain_Fingerprint(test *testing.T) {
+	dir, err := ioutil.TempDir("test", "keystore")
+	assert.Nil(test, err)
+
+	dir = dir + "/"
+	defer os.RemoveAll(dir)
+
+	ks := NewKeyChain(dir)
+
+	pub, shares, err := tbls.NewTBLS256KeyGenerator().Gen(5, 3)
+	assert.Nil(test, err)
+
+	pubEnv, _, err := crypto.SealKeys("TBLS256", 3, 5, "TBLS256_5_3", pub, shares)
+	assert.Nil(test, err)
+	assert.Nil(test, ks.StorePublicKey("TBLS256_5_3", pubEnv))
+
+	expected, err := crypto.Fingerprint("TBLS256Optimistic", pub)
+	assert.Nil(test, err)
+
+	fp, err := Fingerprint(ks, "TBLS256_5_3")
+	assert.Nil(test, err)
+	assert.Equal(test, expected, fp)
+
+	//envelopes sealed without a fingerprint get one computed
+	pubEnv.Fingerprint = ""
+	assert.Nil(test, ks.StorePublicKey("TBLS256_5_3", pubEnv))
+
+	fp, err = Fingerprint(ks, "TBLS256_5_3")
+	assert.Nil(test, err)
+	assert.Equal(test, expected, fp)
+}
@@ -0,0 +1,25 @@
+//go:build windows
+// +build windows
+
+package keychain
+
+import (
+	"os"
+)
+
+//Key directories are not locked on Windows: the writers of a keychain
+//are still excluded from each other, but nothing keeps other processes
+//or keychains of the same directory from storing or rotating keys at once
+
+func lockFile(f *os.File) error {
+	return nil
+}
+
+func unlockFile(f *os.File) error {
+	return nil
+}
+
+//syncDir is a no-op as directories cannot be synced on Windows
+func syncDir(dir string) error {
+	return nil
+}
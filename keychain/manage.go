@@ -0,0 +1,337 @@
+package keychain
+
+import (
+	"encoding"
+	"fmt"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//KeyInfo describes a version of a key stored in a keychain.
+//Scheme, threshold, index and fingerprint are only known
+//for keys sealed in a crypto.KeyEnvelope.
+type KeyInfo struct {
+	//Name loads this version of the key
+	Name string
+	//Version is 0 for the current version of the key
+	Version int
+
+	Public  bool
+	Private bool
+
+	Scheme      string
+	T           int
+	N           int
+	Index       int
+	Created     time.Time
+	Fingerprint string
+
+	//Err reports why the metadata of the key could not be read
+	Err error
+}
+
+func (i KeyInfo) String() string {
+	s := i.Name
+	if i.Scheme != "" {
+		s += fmt.Sprintf(" %v %v-of-%v", i.Scheme, i.T, i.N)
+	}
+	if i.Index > 0 {
+		s += fmt.Sprintf(" share %v", i.Index)
+	}
+	if !i.Created.IsZero() {
+		s += " " + i.Created.Format(time.RFC3339)
+	}
+	if i.Fingerprint != "" {
+		s += " " + i.Fingerprint
+	}
+	if i.Err != nil {
+		s += fmt.Sprintf(" (%v)", i.Err)
+	}
+	return s
+}
+
+const versionSeparator = "@"
+
+//VersionName is the name a previous version of the key name is loaded by
+func VersionName(name string, version int) string {
+	if version == 0 {
+		return name
+	}
+	return fmt.Sprintf("%v%v%v", name, versionSeparator, version)
+}
+
+//splitVersion returns the name and version of a key version name
+func splitVersion(name string) (string, int) {
+	i := strings.LastIndex(name, versionSeparator)
+	if i < 0 {
+		return name, 0
+	}
+
+	version, err := strconv.Atoi(name[i+1:])
+	if err != nil || version <= 0 {
+		return name, 0
+	}
+
+	return name[:i], version
+}
+
+func (k *keychain) List() ([]KeyInfo, error) {
+	return listKeys(k, k.directory)
+}
+
+func (k *encryptedKeychain) List() ([]KeyInfo, error) {
+	return listKeys(k, k.directory)
+}
+
+func (k *keychain) Delete(name string) error {
+	if _, err := keyFileName(PublicKeyPrefix, name); err != nil {
+		return err
+	}
+
+	base, _ := splitVersion(name)
+	release := k.names.acquire(base)
+	defer release()
+
+	unlock, err := k.lockDirectory()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	k.files.Lock()
+	defer k.files.Unlock()
+
+	deleted := false
+	for _, prefix := range []string{PublicKeyPrefix, PrivateKeyPrefix} {
+		fileName := fmt.Sprintf(prefix, name)
+		k.cache.invalidate(fileName)
+		err := os.Remove(k.path(fileName))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		deleted = true
+	}
+
+	if !deleted {
+		return fmt.Errorf("%w: %v", crypto.ErrKeyNotFound, name)
+	}
+
+	return syncDir(k.directory)
+}
+
+func (k *keychain) Rotate(name string, pub crypto.PublicKey, priv crypto.PrivateKey) (int, error) {
+	return rotateKey(k, k, name, pub, priv)
+}
+
+func (k *encryptedKeychain) Rotate(name string, pub crypto.PublicKey, priv crypto.PrivateKey) (int, error) {
+	return rotateKey(k, k.keychain, name, pub, priv)
+}
+
+//listKeys describes the keys found in directory, reading them through k
+func listKeys(k KeyChain, directory string) ([]KeyInfo, error) {
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*KeyInfo)
+	modified := make(map[string]time.Time)
+	for _, file := range files {
+		var name string
+		var public bool
+		switch {
+		case file.IsDir():
+			continue
+		case strings.HasPrefix(file.Name(), fmt.Sprintf(PublicKeyPrefix, "")):
+			name, public = strings.TrimPrefix(file.Name(), fmt.Sprintf(PublicKeyPrefix, "")), true
+		case strings.HasPrefix(file.Name(), fmt.Sprintf(PrivateKeyPrefix, "")):
+			name = strings.TrimPrefix(file.Name(), fmt.Sprintf(PrivateKeyPrefix, ""))
+		default:
+			continue
+		}
+
+		info, ok := keys[name]
+		if !ok {
+			_, version := splitVersion(name)
+			info = &KeyInfo{Name: name, Version: version}
+			keys[name] = info
+		}
+		info.Public = info.Public || public
+		info.Private = info.Private || !public
+		modified[name] = file.ModTime().UTC()
+	}
+
+	infos := make([]KeyInfo, 0, len(keys))
+	for name, info := range keys {
+		info.describe(k)
+		if info.Created.IsZero() {
+			info.Created = modified[name]
+		}
+		infos = append(infos, *info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		ni, vi := splitVersion(infos[i].Name)
+		nj, vj := splitVersion(infos[j].Name)
+		if ni != nj {
+			return ni < nj
+		}
+		return vi < vj
+	})
+
+	return infos, nil
+}
+
+//describe fills the metadata of the envelopes of the key
+func (i *KeyInfo) describe(k KeyChain) {
+	if i.Public {
+		env, err := envelopeOf(k.LoadPublicKey(i.Name))
+		if err != nil || env == nil {
+			i.Err = err
+			return
+		}
+		i.fill(env)
+		i.Fingerprint, i.Err = envelopeFingerprint(env)
+	}
+
+	if i.Private {
+		env, err := envelopeOf(k.LoadPrivateKey(i.Name))
+		if err != nil || env == nil {
+			i.Err = err
+			return
+		}
+		if !i.Public {
+			i.fill(env)
+			i.Fingerprint = env.Fingerprint
+		}
+		i.Index = env.Index
+	}
+}
+
+//envelopeOf returns the envelope of a loaded key,
+//or nil if it is a raw key without metadata
+func envelopeOf(key encoding.BinaryMarshaler, err error) (*crypto.KeyEnvelope, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := key.MarshalBinary()
+	if err != nil || !crypto.IsKeyEnvelope(b) {
+		return nil, err
+	}
+
+	return crypto.UnmarshalKeyEnvelope(b)
+}
+
+func (i *KeyInfo) fill(env *crypto.KeyEnvelope) {
+	i.Scheme = env.Scheme
+	i.T = env.T
+	i.N = env.N
+	i.Created = env.Created
+}
+
+//keyFiles reads and writes the keys of a keychain
+//without waiting for the writers of their name
+type keyFiles interface {
+	LoadPublicKey(name string) (crypto.PublicKey, error)
+	LoadPrivateKey(name string) (crypto.PrivateKey, error)
+	storePublicKey(name string, pub crypto.PublicKey) error
+	storePrivateKey(name string, priv crypto.PrivateKey) error
+	storeKeyPair(name string, pub crypto.PublicKey, priv crypto.PrivateKey) error
+}
+
+//rotateKey moves the keys stored under name in the directory of files
+//to the next free version and stores pub and priv in their place.
+//Keys are read and written through k so encrypted keys are encrypted
+//again under their new name.
+func rotateKey(k keyFiles, files *keychain, name string, pub crypto.PublicKey, priv crypto.PrivateKey) (int, error) {
+	release, err := files.lockName(name)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	//held across the stores so other processes cannot pick the same version
+	unlock, err := files.lockDirectory()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	version, err := nextVersion(files.directory, name)
+	if err != nil {
+		return 0, err
+	}
+
+	previous := VersionName(name, version)
+	rotated := false
+
+	if exists(files.path(fmt.Sprintf(PublicKeyPrefix, name))) {
+		old, err := k.LoadPublicKey(name)
+		if err != nil {
+			return 0, err
+		}
+		if err := k.storePublicKey(previous, old); err != nil {
+			return 0, err
+		}
+		rotated = true
+	}
+
+	if exists(files.path(fmt.Sprintf(PrivateKeyPrefix, name))) {
+		old, err := k.LoadPrivateKey(name)
+		if err != nil {
+			return 0, err
+		}
+		if err := k.storePrivateKey(previous, old); err != nil {
+			return 0, err
+		}
+		rotated = true
+	}
+
+	if !rotated {
+		return 0, fmt.Errorf("%w: %v", crypto.ErrKeyNotFound, name)
+	}
+
+	//both keys are replaced or neither is
+	if err := k.storeKeyPair(name, pub, priv); err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+//nextVersion returns the version the current keys of name are rotated to
+func nextVersion(directory string, name string) (int, error) {
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return 0, err
+	}
+
+	last := 0
+	for _, file := range files {
+		for _, prefix := range []string{PublicKeyPrefix, PrivateKeyPrefix} {
+			versioned := strings.TrimPrefix(file.Name(), fmt.Sprintf(prefix, ""))
+			if versioned == file.Name() {
+				continue
+			}
+			if base, version := splitVersion(versioned); base == name && version > last {
+				last = version
+			}
+		}
+	}
+
+	return last + 1, nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
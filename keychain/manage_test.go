@@ -0,0 +1,138 @@
+package keychain
+
+import (
+	"errors"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"github.com/jffp113/CryptoProviderSDK/example/handlers/tbls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func sealedKeys(test *testing.T, keyID string) (*crypto.KeyEnvelope, crypto.PrivateKeyList) {
+	pub, shares, err := tbls.NewTBLS256KeyGenerator().Gen(5, 3)
+	require.Nil(test, err)
+
+	pubEnv, shareEnvs, err := crypto.SealKeys("TBLS256", 3, 5, keyID, pub, shares)
+	require.Nil(test, err)
+	return pubEnv, shareEnvs
+}
+
+func TestKeychain_List(test *testing.T) {
+	dir, err := ioutil.TempDir("test", "keystore")
+	require.Nil(test, err)
+
+	dir = dir + "/"
+	defer os.RemoveAll(dir)
+
+	ks := NewKeyChain(dir)
+	pubEnv, shares := sealedKeys(test, "TBLS256_5_3")
+
+	require.Nil(test, ks.StorePublicKey("TBLS256_5_3", pubEnv))
+	require.Nil(test, ks.StorePrivateKey("TBLS256_5_3", shares[1]))
+	require.Nil(test, ks.StorePublicKey("raw", key("pub")))
+	require.Nil(test, ks.StorePrivateKey("share.1", shares[0]))
+
+	keys, err := ks.List()
+	require.Nil(test, err)
+	require.Len(test, keys, 3)
+
+	assert.Equal(test, "TBLS256_5_3", keys[0].Name)
+	assert.True(test, keys[0].Public)
+	assert.True(test, keys[0].Private)
+	assert.Equal(test, "TBLS256", keys[0].Scheme)
+	assert.Equal(test, 3, keys[0].T)
+	assert.Equal(test, 5, keys[0].N)
+	assert.Equal(test, 2, keys[0].Index)
+	assert.True(test, pubEnv.Created.Equal(keys[0].Created))
+	assert.Equal(test, pubEnv.Fingerprint, keys[0].Fingerprint)
+	assert.Nil(test, keys[0].Err)
+
+	//raw keys have no metadata but their modification time
+	assert.Equal(test, "raw", keys[1].Name)
+	assert.True(test, keys[1].Public)
+	assert.False(test, keys[1].Private)
+	assert.Empty(test, keys[1].Scheme)
+	assert.False(test, keys[1].Created.IsZero())
+	assert.Nil(test, keys[1].Err)
+
+	//shares alone know the fingerprint of their public key
+	assert.Equal(test, "share.1", keys[2].Name)
+	assert.Equal(test, 1, keys[2].Index)
+	assert.Equal(test, pubEnv.Fingerprint, keys[2].Fingerprint)
+}
+
+func TestKeychain_Delete(test *testing.T) {
+	dir, err := ioutil.TempDir("test", "keystore")
+	require.Nil(test, err)
+
+	dir = dir + "/"
+	defer os.RemoveAll(dir)
+
+	ks := NewKeyChain(dir)
+	require.Nil(test, ks.StorePublicKey("TBLS256_5_3", key("pub")))
+	require.Nil(test, ks.StorePrivateKey("TBLS256_5_3", key("priv")))
+
+	require.Nil(test, ks.Delete("TBLS256_5_3"))
+
+	_, err = ks.LoadPublicKey("TBLS256_5_3")
+	assert.NotNil(test, err)
+	_, err = ks.LoadPrivateKey("TBLS256_5_3")
+	assert.NotNil(test, err)
+
+	err = ks.Delete("TBLS256_5_3")
+	assert.True(test, errors.Is(err, crypto.ErrKeyNotFound))
+}
+
+func TestKeychain_Rotate(test *testing.T) {
+	dir, err := ioutil.TempDir("test", "keystore")
+	require.Nil(test, err)
+
+	dir = dir + "/"
+	defer os.RemoveAll(dir)
+
+	ks := newEncryptedKeyChain(dir, []byte("passphrase"), testScryptParams)
+
+	_, err = ks.Rotate("TBLS256_5_3", key("pub0"), key("priv0"))
+	assert.True(test, errors.Is(err, crypto.ErrKeyNotFound))
+
+	require.Nil(test, ks.StorePublicKey("TBLS256_5_3", key("pub0")))
+	require.Nil(test, ks.StorePrivateKey("TBLS256_5_3", key("priv0")))
+
+	version, err := ks.Rotate("TBLS256_5_3", key("pub1"), key("priv1"))
+	require.Nil(test, err)
+	assert.Equal(test, 1, version)
+
+	version, err = ks.Rotate("TBLS256_5_3", key("pub2"), key("priv2"))
+	require.Nil(test, err)
+	assert.Equal(test, 2, version)
+
+	for v, expected := range []string{"2", "0", "1"} {
+		pub, err := ks.LoadPublicKey(VersionName("TBLS256_5_3", v))
+		require.Nil(test, err)
+		assert.Equal(test, key("pub"+expected), pub)
+
+		priv, err := ks.LoadPrivateKey(VersionName("TBLS256_5_3", v))
+		require.Nil(test, err)
+		assert.Equal(test, key("priv"+expected), priv)
+	}
+
+	keys, err := ks.List()
+	require.Nil(test, err)
+	require.Len(test, keys, 3)
+	for i, k := range keys {
+		assert.Equal(test, i, k.Version)
+		assert.Equal(test, VersionName("TBLS256_5_3", i), k.Name)
+	}
+
+	//deleting a previous version leaves the others addressable
+	require.Nil(test, ks.Delete(VersionName("TBLS256_5_3", 1)))
+	_, err = ks.LoadPrivateKey(VersionName("TBLS256_5_3", 2))
+	assert.Nil(test, err)
+
+	version, err = ks.Rotate("TBLS256_5_3", key("pub3"), key("priv3"))
+	require.Nil(test, err)
+	assert.Equal(test, 3, version)
+}
@@ -0,0 +1,158 @@
+package keychain
+
+import (
+	"encoding"
+	"fmt"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+)
+
+//TypedKeyChain loads the keys of a KeyChain decoded by the handler of
+//their scheme, such as a tbls share or an rsa key, instead of as bytes.
+//It still serves the encoded keys, so it can back a crypto.SignerProcessor.
+type TypedKeyChain struct {
+	KeyChain
+	registry *crypto.Registry
+}
+
+//NewTypedKeyChain returns a TypedKeyChain decoding the keys of k
+//with the handlers of the schemes in registry
+func NewTypedKeyChain(k KeyChain, registry *crypto.Registry) *TypedKeyChain {
+	return &TypedKeyChain{KeyChain: k, registry: registry}
+}
+
+//LoadTypedPrivateKey loads the private key share stored under name,
+//decoded by the handler of the scheme of its envelope
+func (k *TypedKeyChain) LoadTypedPrivateKey(name string) (crypto.PrivateKey, error) {
+	return k.LoadPrivateKeyAs("", name)
+}
+
+//LoadTypedPublicKey loads the public key stored under name,
+//decoded by the handler of the scheme of its envelope
+func (k *TypedKeyChain) LoadTypedPublicKey(name string) (crypto.PublicKey, error) {
+	return k.LoadPublicKeyAs("", name)
+}
+
+//LoadPrivateKeyAs loads the private key share stored under name
+//decoded by the handler of scheme. Raw keys written without an
+//envelope can only be loaded this way.
+func (k *TypedKeyChain) LoadPrivateKeyAs(scheme string, name string) (crypto.PrivateKey, error) {
+	stored, err := k.LoadPrivateKey(name)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBytes, handler, err := k.open(scheme, stored, true)
+	if err != nil {
+		return nil, fmt.Errorf("private key %v: %w", name, err)
+	}
+
+	priv, err := handler.UnmarshalPrivate(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("private key %v: %w", name, err)
+	}
+
+	return priv, nil
+}
+
+//LoadPublicKeyAs loads the public key stored under name
+//decoded by the handler of scheme. Raw keys written without an
+//envelope can only be loaded this way.
+func (k *TypedKeyChain) LoadPublicKeyAs(scheme string, name string) (crypto.PublicKey, error) {
+	stored, err := k.LoadPublicKey(name)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBytes, handler, err := k.open(scheme, stored, false)
+	if err != nil {
+		return nil, fmt.Errorf("public key %v: %w", name, err)
+	}
+
+	pub, err := handler.UnmarshalPublic(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("public key %v: %w", name, err)
+	}
+
+	return pub, nil
+}
+
+//Validate decodes every enveloped key of the keychain, so corrupted
+//key files are found when a node starts rather than when first used.
+//Raw keys are skipped as their scheme is unknown.
+func (k *TypedKeyChain) Validate() error {
+	keys, err := k.List()
+	if err != nil {
+		return err
+	}
+
+	invalid := make([]error, 0)
+	for _, info := range keys {
+		if info.Err != nil {
+			invalid = append(invalid, fmt.Errorf("key %v: %w", info.Name, info.Err))
+			continue
+		}
+
+		if info.Scheme == "" {
+			continue
+		}
+
+		if info.Public {
+			if _, err := k.LoadTypedPublicKey(info.Name); err != nil {
+				invalid = append(invalid, err)
+			}
+		}
+
+		if info.Private {
+			if _, err := k.LoadTypedPrivateKey(info.Name); err != nil {
+				invalid = append(invalid, err)
+			}
+		}
+	}
+
+	switch len(invalid) {
+	case 0:
+		return nil
+	case 1:
+		return invalid[0]
+	default:
+		return fmt.Errorf("%w (and %v more invalid keys)", invalid[0], len(invalid)-1)
+	}
+}
+
+//open returns the encoding of a stored key and the handler decoding it.
+//The key must be a share if share is set and a public key otherwise,
+//and usable by scheme unless it is empty.
+func (k *TypedKeyChain) open(scheme string, stored encoding.BinaryMarshaler, share bool) ([]byte, crypto.THSignerHandler, error) {
+	keyBytes, err := stored.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if crypto.IsKeyEnvelope(keyBytes) {
+		env, err := crypto.UnmarshalKeyEnvelope(keyBytes)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if env.IsShare() != share {
+			return nil, nil, fmt.Errorf("%w: unexpected kind of key", crypto.ErrBadKeyEncoding)
+		}
+
+		if scheme == "" {
+			scheme = env.Scheme
+		} else if !k.registry.SharesKeys(env.Scheme, scheme) {
+			return nil, nil, fmt.Errorf("%w: key of %v cannot be used with %v", crypto.ErrBadKeyEncoding, env.Scheme, scheme)
+		}
+
+		keyBytes = env.Key
+	} else if scheme == "" {
+		return nil, nil, fmt.Errorf("%w: raw key of unknown scheme", crypto.ErrBadKeyEncoding)
+	}
+
+	handler, err := k.registry.Lookup(scheme)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return keyBytes, handler, nil
+}
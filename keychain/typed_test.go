@@ -0,0 +1,98 @@
+package keychain
+
+import (
+	"errors"
+	"github.com/jffp113/CryptoProviderSDK/crypto"
+	"github.com/jffp113/CryptoProviderSDK/example/handlers/tbls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func newTestTypedKeyChain(test *testing.T) (*TypedKeyChain, string) {
+	dir, err := ioutil.TempDir("test", "typed")
+	require.Nil(test, err)
+
+	dir = dir + "/"
+	return NewTypedKeyChain(NewKeyChain(dir), crypto.DefaultRegistry), dir
+}
+
+func TestTypedKeychain_LoadKeys(test *testing.T) {
+	ks, dir := newTestTypedKeyChain(test)
+	defer os.RemoveAll(dir)
+
+	pubEnv, shares := sealedKeys(test, "TBLS256_5_3")
+	require.Nil(test, ks.StorePublicKey("TBLS256_5_3", pubEnv))
+	for i, share := range shares {
+		require.Nil(test, ks.StorePrivateKey(string(crypto.ShareKeyID("TBLS256_5_3", i+1)), share))
+	}
+
+	pub, err := ks.LoadTypedPublicKey("TBLS256_5_3")
+	require.Nil(test, err)
+
+	//the keys are the ones of the handler, not their encoding
+	handler := tbls.NewTBLS256CryptoHandler()
+	msg := []byte("Test typed keychain")
+	sigShares := make([][]byte, 0)
+	for i := 1; i <= 3; i++ {
+		priv, err := ks.LoadTypedPrivateKey(string(crypto.ShareKeyID("TBLS256_5_3", i)))
+		require.Nil(test, err)
+
+		sigShare, err := handler.Sign(msg, priv)
+		require.Nil(test, err)
+		sigShares = append(sigShares, sigShare)
+	}
+
+	sig, err := handler.Aggregate(sigShares, msg, pub, 3, 5)
+	require.Nil(test, err)
+	assert.Nil(test, handler.Verify(sig, msg, pub))
+
+	//the keys can be used by every scheme sharing them
+	_, err = ks.LoadPrivateKeyAs("TBLS256Optimistic", "TBLS256_5_3.1")
+	assert.Nil(test, err)
+
+	_, err = ks.LoadPrivateKeyAs("BLS", "TBLS256_5_3.1")
+	assert.True(test, errors.Is(err, crypto.ErrBadKeyEncoding))
+}
+
+func TestTypedKeychain_RawKeys(test *testing.T) {
+	ks, dir := newTestTypedKeyChain(test)
+	defer os.RemoveAll(dir)
+
+	pub, _, err := tbls.NewTBLS256KeyGenerator().Gen(5, 3)
+	require.Nil(test, err)
+	require.Nil(test, ks.StorePublicKey("raw", pub))
+
+	_, err = ks.LoadTypedPublicKey("raw")
+	assert.True(test, errors.Is(err, crypto.ErrBadKeyEncoding))
+
+	_, err = ks.LoadPublicKeyAs("TBLS256", "raw")
+	assert.Nil(test, err)
+
+	_, err = ks.LoadPublicKeyAs("Unregistered", "raw")
+	assert.True(test, errors.Is(err, crypto.ErrUnsupported))
+
+	//raw keys cannot be validated
+	assert.Nil(test, ks.Validate())
+}
+
+func TestTypedKeychain_Validate(test *testing.T) {
+	ks, dir := newTestTypedKeyChain(test)
+	defer os.RemoveAll(dir)
+
+	pubEnv, shares := sealedKeys(test, "TBLS256_5_3")
+	require.Nil(test, ks.StorePublicKey("TBLS256_5_3", pubEnv))
+	require.Nil(test, ks.StorePrivateKey("TBLS256_5_3", shares[0]))
+	require.Nil(test, ks.Validate())
+
+	corrupted := *pubEnv
+	corrupted.Key = []byte("not a point")
+	require.Nil(test, ks.StorePublicKey("corrupted", &corrupted))
+
+	err := ks.Validate()
+	assert.True(test, errors.Is(err, crypto.ErrBadKeyEncoding))
+	assert.True(test, strings.Contains(err.Error(), "corrupted"))
+}
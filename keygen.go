@@ -1,21 +1,33 @@
 package main
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"github.com/jessevdk/go-flags"
 	"github.com/jffp113/CryptoProviderSDK/crypto"
-	"github.com/jffp113/CryptoProviderSDK/example/handlers/tbls"
-	"github.com/jffp113/CryptoProviderSDK/example/handlers/trsa"
+	_ "github.com/jffp113/CryptoProviderSDK/example/handlers/bls"
+	_ "github.com/jffp113/CryptoProviderSDK/example/handlers/ecdsa"
+	_ "github.com/jffp113/CryptoProviderSDK/example/handlers/ed25519"
+	_ "github.com/jffp113/CryptoProviderSDK/example/handlers/rsa"
+	_ "github.com/jffp113/CryptoProviderSDK/example/handlers/tbls"
+	_ "github.com/jffp113/CryptoProviderSDK/example/handlers/trsa"
 	"github.com/jffp113/CryptoProviderSDK/keychain"
+	"io/ioutil"
 	"os"
 )
 
 type Opts struct {
 	//Verbose []bool `short:"v" long:"verbose" description:"Increase verbosity"`
-	T       int    `short:"t" long:"threshold" description:"Low limit of necessary signatures" default:"3"`
-	N       int    `short:"n" long:"shares" description:"Number of shares" default:"5"`
-	GenPath string `short:"p" long:"path" description:"Key Generation Path" default:"./resources/keys/"`
-	Scheme  string `short:"s" long:"scheme" description:"Scheme" default:"TBLS256"`
+	T          int    `short:"t" long:"threshold" description:"Low limit of necessary signatures" default:"3"`
+	N          int    `short:"n" long:"shares" description:"Number of shares" default:"5"`
+	GenPath    string `short:"p" long:"path" description:"Key Generation Path" default:"./resources/keys/"`
+	Scheme     string `short:"s" long:"scheme" description:"Scheme" default:"TBLS256"`
+	List       bool   `short:"l" long:"list" description:"List the available schemes"`
+	Passphrase string `long:"keychain-passphrase" description:"Passphrase encrypting the generated shares" env:"KEYCHAIN_PASSPHRASE"`
+	ListKeys   bool   `long:"list-keys" description:"List the keys stored under the generation path"`
+	Rotate     bool   `long:"rotate" description:"Keep the previous version of replaced keys"`
+	Proof      bool   `long:"proof-of-possession" description:"Print the proof of possession of the generated key along with it"`
 }
 
 func main() {
@@ -37,43 +49,148 @@ func main() {
 		os.Exit(2)
 	}
 
+	if opts.List {
+		for _, d := range crypto.Descriptors() {
+			fmt.Println(d)
+		}
+		return
+	}
+
+	if opts.ListKeys {
+		if err := listKeys(opts); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	scheme, ok := crypto.DefaultRegistry.Describe(opts.Scheme)
+
+	if !ok {
+		fmt.Printf("Error: Unknown scheme %v\n", opts.Scheme)
+		os.Exit(2)
+	}
 
-	keygen := getKeyGen(opts.Scheme)
+	keygen, err := crypto.LookupDescriptor(scheme)
 
-	pub, priv := keygen.Gen(opts.N, opts.T)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	if !crypto.DescribeScheme(keygen).Threshold && (opts.N != 1 || opts.T != 1) {
+		//schemes without a threshold generate a single key
+		fmt.Printf("%v has no threshold, generating a 1-of-1 key\n", opts.Scheme)
+		opts.N, opts.T = 1, 1
+	}
 
-	for _,schemeParam := range []string{"","Optimistic","Pessimistic"} {
-		keyName := fmt.Sprintf("%v%v_%v_%v", opts.Scheme,schemeParam, opts.N, opts.T)
+	pub, priv, err := keygen.Gen(opts.N, opts.T)
+
+	if err != nil {
+		fmt.Printf("Failed to generate keys: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(priv) != opts.N {
+		fmt.Printf("Error: %v generated %v keys instead of %v shares\n", opts.Scheme, len(priv), opts.N)
+		os.Exit(1)
+	}
+
+	//keys of schemes requiring proofs of possession are stored with theirs
+	var proof []byte
+	if _, ok := keygen.(crypto.PossessionProver); ok || opts.Proof {
+		proof, err = proveKey(keygen, priv)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	//keys are stored for every scheme they can be used with
+	for _, d := range crypto.Descriptors() {
+		if !d.SharesKeysWith(scheme) {
+			continue
+		}
+		keyName := fmt.Sprintf("%v_%v_%v", d.Name(), opts.N, opts.T)
+		pubEnv, shares, err := crypto.SealKeys(d.Name(), opts.T, opts.N, keyName, pub, priv)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		//stored along with the key, so clients loading it can send it when aggregating
+		pubEnv.Proof = proof
 		for i := 1; i <= opts.N; i++ {
 			path := fmt.Sprintf("%v/%v/", opts.GenPath, i)
 			os.MkdirAll(path, os.ModePerm)
-			keychain := keychain.NewKeyChain(path)
-			err := keychain.StorePublicKey(keyName, pub)
-			if err != nil {
-				fmt.Println(err)
-				return
-			}
-			err = keychain.StorePrivateKey(keyName, priv[i-1])
+			keychain := keychain.Open(path, []byte(opts.Passphrase))
+			err := storeKeys(keychain, keyName, pubEnv, shares[i-1], opts.Rotate)
 			if err != nil {
 				fmt.Println(err)
 				return
 			}
 		}
+		if opts.Proof {
+			fmt.Printf("%v %v %v\n", keyName, pubEnv.Fingerprint, hex.EncodeToString(proof))
+		} else {
+			fmt.Printf("%v %v\n", keyName, pubEnv.Fingerprint)
+		}
+	}
+
+}
+
+//proveKey returns the proof of possession of the generated key,
+//as independent keys are only aggregated along with their proofs
+func proveKey(keygen crypto.THSignerHandler, priv crypto.PrivateKeyList) ([]byte, error) {
+	prover, ok := keygen.(crypto.PossessionProver)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v keys have no proof of possession", crypto.ErrUnsupported, keygen.SchemeName())
+	}
+
+	if len(priv) != 1 {
+		return nil, fmt.Errorf("proofs of possession are made for a single key, not %v shares", len(priv))
+	}
+
+	return prover.ProvePossession(priv[0])
+}
+
+//storeKeys stores the keys of a node, rotating the ones they replace if asked to
+func storeKeys(k keychain.KeyChain, name string, pub crypto.PublicKey, priv crypto.PrivateKey, rotate bool) error {
+	if rotate {
+		_, err := k.Rotate(name, pub, priv)
+		if !errors.Is(err, crypto.ErrKeyNotFound) {
+			return err
+		}
+	}
+
+	if err := k.StorePublicKey(name, pub); err != nil {
+		return err
 	}
 
+	return k.StorePrivateKey(name, priv)
 }
 
-func getKeyGen(scheme string) crypto.KeyShareGenerator {
-	switch scheme {
-	case "TBLS256":
-		return tbls.NewTBLS256KeyGenerator()
-	case "TRSA1024":
-		return trsa.NewTRSAKeyGenerator(1024)
-	case "TRSA2048":
-		return trsa.NewTRSAKeyGenerator(2048)
-	case "TRSA3072":
-		return trsa.NewTRSAKeyGenerator(3072)
-	default:
-		return nil
+//listKeys prints the keys of every node keychain under the generation path
+func listKeys(opts Opts) error {
+	nodes, err := ioutil.ReadDir(opts.GenPath)
+	if err != nil {
+		return err
 	}
+
+	for _, node := range nodes {
+		if !node.IsDir() {
+			continue
+		}
+
+		keys, err := keychain.Open(fmt.Sprintf("%v/%v/", opts.GenPath, node.Name()), []byte(opts.Passphrase)).List()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%v:\n", node.Name())
+		for _, key := range keys {
+			fmt.Printf("  %v\n", key)
+		}
+	}
+
+	return nil
 }